@@ -7,6 +7,12 @@ const (
 	BotDescription = "A bot account created by the ServiceNow plugin."
 
 	HeaderMattermostUserID = "Mattermost-User-Id"
+
+	// Headers of the signed webhook requests sent by ServiceNow
+	HeaderWebhookSignature = "X-ServiceNow-Signature"
+	HeaderWebhookTimestamp = "X-ServiceNow-Timestamp"
+	HeaderWebhookEventID   = "X-ServiceNow-Event-Id"
+	WebhookSignaturePrefix = "sha256="
 	CommandTrigger         = "servicenow"
 
 	ConnectSuccessMessage = "#### Welcome to the Mattermost ServiceNow Plugin\n" +
@@ -18,17 +24,28 @@ const (
 
 	ServiceNowForMattermostNotificationsAppID = "x_830655_mm_std"
 	ServiceNowSysIDRegex                      = "[0-9a-f]{32}"
+	ServiceNowTableNameRegex                  = "^[a-z][a-z0-9_]*$"
 	SysQueryParam                             = "sysparm_query"
 	SysQueryParamLimit                        = "sysparm_limit"
 	SysQueryParamOffset                       = "sysparm_offset"
 	SysQueryParamFields                       = "sysparm_fields"
 	SysQueryParamDisplayValue                 = "sysparm_display_value"
 	SysQueryParamText                         = "sysparm_text"
+	SysQueryParamExcludeReferenceLink         = "sysparm_exclude_reference_link"
 
-	UpdateSetNotUploadedMessage = "it looks like the notifications have not been configured in ServiceNow by uploading and committing the update set."
+	UpdateSetNotUploadedMessage       = "it looks like the notifications have not been configured in ServiceNow by uploading and committing the update set."
+	RecordAssignedToMe                = "The record has been assigned to you."
+	ApprovalRequestedMessage          = "Your approval has been requested"
+	SuggestedKnowledgeArticlesMessage = "These knowledge articles might help you with the incident %s:"
+	WarRoomCreatedMessage             = "The war room ~%s has been created for the incident."
+	WarRoomExistsMessage              = "The war room ~%s already exists for the incident. You have been added to it."
+	WarRoomPurpose                    = "War room for the incident %s"
+	WarRoomWorkNote                   = "A war room has been created for this incident in Mattermost: %s"
 
 	SubscriptionTypeRecord           = "record"
 	SubscriptionTypeBulk             = "object"
+	SubscriptionTypeApproval         = "approval"
+	SubscriptionTypePersonal         = "personal"
 	RecordTypeProblem                = "problem"
 	RecordTypeIncident               = "incident"
 	RecordTypeChangeRequest          = "change_request"
@@ -36,6 +53,8 @@ const (
 	RecordTypeTask                   = "task"
 	RecordTypeChangeTask             = "change_task"
 	RecordTypeFollowOnTask           = "cert_follow_on_task"
+	RecordTypeCatalogRequest         = "sc_request"
+	RecordTypeRequestedItem          = "sc_req_item"
 	SubscriptionEventPriority        = "priority"
 	SubscriptionEventState           = "state"
 	SubscriptionEventCommented       = "commented"
@@ -43,6 +62,73 @@ const (
 	SubscriptionEventAssignmentGroup = "assignment_group"
 	SubscriptionEventCreated         = "created"
 	BulkSubscription                 = "Bulk"
+	PersonalSubscription             = "Personal"
+
+	// Events for which the notifications of a personal subscription are sent
+	PersonalSubscriptionEvents = SubscriptionEventAssignedTo + "," + SubscriptionEventAssignmentGroup + "," + SubscriptionEventCommented
+
+	// Encoded query matching the records assigned to one of the groups of the user making the request
+	QueryAssignedToMyGroups = FieldAssignmentGroup + "INjavascript:getMyGroups()"
+
+	// Encoded query matching the records assigned to the user making the request
+	QueryAssignedToMe = FieldAssignedTo + "=javascript:gs.getUserID()"
+
+	// Knowledge articles
+	KnowledgeWorkflowStatePublished = "published"
+	MaxKnowledgeSearchResults       = 5
+	KnowledgeArticleSnippetLength   = 200
+	MaxKnowledgeArticleLength       = 12000
+	MaxSuggestedKnowledgeArticles   = 3
+
+	// Failed notifications
+	MaxDeadLetterNotificationsToList = 20
+
+	// Delivery modes of the notifications of bulk subscriptions
+	DeliveryModeImmediate = "immediate"
+	DeliveryModeHourly    = "hourly"
+	DeliveryModeDaily     = "daily"
+
+	// Maximum number of events listed in a notification digest, the remaining events are only counted
+	MaxNotificationDigestEvents = 100
+
+	// Encoded query operator for the keyword search of ServiceNow, which matches the records containing any of the words
+	// of the search term in their text fields and returns the most relevant records first
+	QueryTextSearch = "123TEXTQUERY321"
+
+	// Record type used for searching the records of all the searchable record types at once
+	RecordTypeAll = "all"
+
+	// Events for which the notifications of the subscription of a war room are sent
+	WarRoomSubscriptionEvents = SubscriptionEventPriority + "," + SubscriptionEventState + "," + SubscriptionEventCommented + "," + SubscriptionEventAssignedTo + "," + SubscriptionEventAssignmentGroup
+
+	// Maximum number of the members of the assignment group invited to a war room
+	MaxWarRoomGroupMembers = 100
+
+	// Maximum number of records unfurled in a single message
+	MaxUnfurledRecordsPerPost = 3
+
+	// Values of the states of the incidents
+	IncidentStateOnHold   = "3"
+	IncidentStateResolved = "6"
+	IncidentStateClosed   = "7"
+
+	// Values of the states of the problems
+	ProblemStateNew               = "101"
+	ProblemStateAssess            = "102"
+	ProblemStateRootCauseAnalysis = "103"
+	ProblemStateFixInProgress     = "104"
+	ProblemStateResolved          = "106"
+	ProblemStateClosed            = "107"
+
+	// Values of the states of the change requests
+	ChangeStateNew       = "-5"
+	ChangeStateAssess    = "-4"
+	ChangeStateAuthorize = "-3"
+	ChangeStateScheduled = "-2"
+	ChangeStateImplement = "-1"
+	ChangeStateReview    = "0"
+	ChangeStateClosed    = "3"
+	ChangeStateCanceled  = "4"
 
 	// Filters
 	FilterCreatedByMe     = "me"
@@ -53,21 +139,33 @@ const (
 	// #nosec G101 -- This is a false positive. The below line is not a hardcoded credential
 	ContextTokenKey ServiceNowOAuthToken = "ServiceNow-Oauth-Token"
 
+	// Used for storing the connected user in the request context, so that the handlers don't need to load it again
+	ContextUserKey ConnectedUser = "Connected-User"
+
 	DefaultPage                                = 0
 	DefaultPerPage                             = 20
 	MaxPerPage                                 = 100
 	CharacterThresholdForSearchingRecords      = 3
 	CharacterThresholdForSearchingCatalogItems = 4
+	MaxShortDescriptionLength                  = 160
+	ThreadMessageTimeFormat                    = "2006-01-02 15:04 MST"
 	QueryParamPage                             = "page"
 	QueryParamPerPage                          = "per_page"
 	QueryParamChannelID                        = "channel_id"
 	QueryParamUserID                           = "user_id"
 	QueryParamSubscriptionType                 = "subscription_type"
 	QueryParamSearchTerm                       = "search"
+	QueryParamActive                           = "active"
+	QueryParamAssignedToMe                     = "assigned_to_me"
+	QueryParamState                            = "state"
 	PathParamSubscriptionID                    = "subscription_id"
 	PathParamTeamID                            = "team_id"
 	PathParamRecordType                        = "record_type"
 	PathParamRecordID                          = "record_id"
+	PathParamField                             = "field"
+	PathParamItemID                            = "item_id"
+	QueryParamDependentValue                   = "dependent_value"
+	QueryParamRecordID                         = "record_id"
 
 	// ServiceNow table fields
 	FieldSysID                = "sys_id"
@@ -77,8 +175,50 @@ const (
 	FieldCommentsAndWorkNotes = "comments_and_work_notes"
 	FieldAssignedTo           = "assigned_to"
 	FieldAssignmentGroup      = "assignment_group"
+	FieldPriority             = "priority"
 	FieldKnowledgeBase        = "knowledge_base"
 	FieldCategory             = "category"
+	FieldSubcategory          = "subcategory"
+	FieldUrgency              = "urgency"
+	FieldImpact               = "impact"
+	FieldName                 = "name"
+	FieldLabel                = "label"
+	FieldValue                = "value"
+	FieldDependentValue       = "dependent_value"
+	FieldSequence             = "sequence"
+	FieldRequest              = "request"
+	FieldComments             = "comments"
+	FieldWorkNotes            = "work_notes"
+	FieldElement              = "element"
+	FieldElementID            = "element_id"
+	FieldSysCreatedBy         = "sys_created_by"
+	FieldSysCreatedOn         = "sys_created_on"
+	FieldCloseCode            = "close_code"
+	FieldCloseNotes           = "close_notes"
+	FieldHoldReason           = "hold_reason"
+	FieldResolutionCode       = "resolution_code"
+	FieldState                = "state"
+	FieldActive               = "active"
+	FieldWorkflowState        = "workflow_state"
+	FieldGroup                = "group"
+	FieldUser                 = "user"
+
+	// ServiceNow tables used for looking up the values of the incident fields
+	TableChoice            = "sys_choice"
+	TableUserGroup         = "sys_user_group"
+	TableUser              = "sys_user"
+	TableConfigurationItem = "cmdb_ci"
+	TableJournalField      = "sys_journal_field"
+	TableApproval          = "sysapproval_approver"
+	TableGroupMember       = "sys_user_grmember"
+
+	// States of an approval
+	ApprovalStateApproved = "approved"
+	ApprovalStateRejected = "rejected"
+
+	// Types of the comment entries of a record
+	CommentEntryTypeComment  = "comment"
+	CommentEntryTypeWorkNote = "work_note"
 
 	// Websocket events
 	WSEventConnect                        = "connect"
@@ -90,6 +230,7 @@ const (
 	WSEventOpenCommentModal               = "comment_modal"
 	WSEventOpenUpdateStateModal           = "update_state"
 	WSEventOpenCreateIncidentModal        = "create_incident"
+	WSEventOpenReassignModal              = "reassign_modal"
 
 	// API Errors
 	APIErrorIDNotConnected               = "not_connected"
@@ -106,10 +247,31 @@ const (
 	APIErrorRefreshTokenExpired          = "Your connection with ServiceNow has expired. Please reconnect your account."
 	APIErrorCreateIncident               = "Error in creating the incident"
 	APIErrorSearchingCatalogItems        = "Error in searching for catalog items in ServiceNow"
+	APIErrorCreateRecord                 = "Error in creating the record"
+	APIErrorGetCatalogItem               = "Error in getting the catalog item from ServiceNow"
+	APIErrorOrderCatalogItem             = "Error in ordering the catalog item"
 
 	// Slack attachment context constants
-	ContextNameRecordType = "record_type"
-	ContextNameRecordID   = "record_id"
+	ContextNameRecordType   = "record_type"
+	ContextNameRecordID     = "record_id"
+	ContextNameRecordNumber = "record_number"
+	ContextNameChannelID    = "channel_id"
+	ContextNameCommentType  = "comment_type"
+	ContextNameApprovalID   = "approval_id"
+
+	// Names of the elements of the interactive dialogs
+	DialogElementNameComments = "comments"
+
+	// Types of the fields of a catalog item form
+	FormFieldTypeText      = "text"
+	FormFieldTypeTextarea  = "textarea"
+	FormFieldTypeNumber    = "number"
+	FormFieldTypeBool      = "bool"
+	FormFieldTypeSelect    = "select"
+	FormFieldTypeRadio     = "radio"
+	FormFieldTypeReference = "reference"
+	FormFieldTypeDate      = "date"
+	FormFieldTypeDateTime  = "datetime"
 
 	// Slash commands
 	CommandHelp           = "help"
@@ -123,7 +285,18 @@ const (
 	SubCommandEdit        = "edit"
 	SubCommandDelete      = "delete"
 	CommandIncident       = "incident"
+	CommandKnowledgeBase  = "kb"
+	SubCommandWarRoom     = "warroom"
 	SubCommandCreate      = "create"
+	CommandNotifications  = "notifications"
+	SubCommandFailed      = "failed"
+	SubCommandReplay      = "replay"
+
+	// Slash command flags
+	FlagDescription = "description"
+	FlagCaller      = "caller"
+	FlagUrgency     = "urgency"
+	FlagPersonal    = "personal"
 )
 
 // #nosec G101 -- This is a false positive. The below line is not a hardcoded credential
@@ -133,19 +306,42 @@ const (
 	ErrorEmptyServiceNowOAuthClientSecret = "serviceNow OAuth clientSecret should not be empty"
 	ErrorEmptyEncryptionSecret            = "encryption secret should not be empty"
 	ErrorEmptyWebhookSecret               = "webhook secret should not be empty"
+	ErrorInvalidRecordTypesConfig         = "record types configuration is not valid"
 	ErrorInvalidRecordType                = "Invalid record type"
 	ErrorInvalidTeamID                    = "Invalid team ID"
 	ErrorInvalidChannelID                 = "Invalid channel ID"
 	ErrorInvalidSecret                    = "Invalid secret"
+	ErrorInvalidSignature                 = "Invalid signature"
+	ErrorMissingSignature                 = "The request is not signed"
+	ErrorInvalidWebhookEventID            = "Invalid webhook event ID"
+	ErrorEventSubscriptionMismatch        = "Dropping the notification as it does not match its subscription"
 	ErrorInvalidQueryParam                = "Invalid query param"
 	ErrorNotAuthorized                    = "Not authorized"
 	ErrorUserAlreadyConnected             = "user is already connected to ServiceNow"
 	ErrorMissingUserCodeState             = "missing user, code or state"
 	ErrorUserIDMismatchInOAuth            = "not authorized, user ID mismatch"
 	ErrorEmptyComment                     = "comment should not be empty"
+	ErrorCommentAndWorkNotes              = "a comment and a work note can't be added together"
+	ErrorWorkNotesNotSupported            = "Work notes are not supported for the record type"
 	ErrorGeneric                          = "Something went wrong."
 	ErrorGetUsers                         = "Failed to get the users."
 	ErrorEmptyShortDescription            = "short description should not be empty"
+	ErrorInvalidUrgency                   = "urgency is not valid"
+	ErrorInvalidImpact                    = "impact is not valid"
+	ErrorSubcategoryWithoutCategory       = "subcategory can't be set without a category"
+	ErrorInvalidAssignmentGroup           = "assignment group is not valid"
+	ErrorInvalidAssignedTo                = "assigned to user is not valid"
+	ErrorEmptyAssignment                  = "either the user or the group to assign the record to should be provided"
+	ErrorAssignmentNotSupported           = "Records of this type can't be assigned from Mattermost"
+	ErrorInvalidRecordID                  = "record ID is not valid"
+	ErrorAssignRecord                     = "Error in assigning the record"
+	ErrorSearchingUsers                   = "Error in searching for users in ServiceNow"
+	ErrorServiceNowUserNotFound           = "ServiceNow user details of the connected user are missing. Please reconnect your account."
+	ErrorInvalidConfigurationItem         = "configuration item is not valid"
+	ErrorInvalidIncidentField             = "Invalid incident field"
+	ErrorGetChoices                       = "Error in getting the choices"
+	ErrorSearchingAssignmentGroups        = "Error in searching for assignment groups in ServiceNow"
+	ErrorSearchingConfigurationItems      = "Error in searching for configuration items in ServiceNow"
 	ErrorGetBotChannel                    = "Couldn't get the bot's DM channel"
 	ErrorSearchTermThreshold              = "The search term must be at least %d characters long."
 	ErrorGetUser                          = "Unable to get the user"
@@ -159,47 +355,115 @@ const (
 	ErrorEditingSubscription              = "Error in editing the subscription"
 	ErrorDeleteSubscription               = "Error in deleting the subscription"
 	ErrorGetComments                      = "Error in getting all comments"
+	ErrorGetJournalEntries                = "Error in getting the journal entries of the record, falling back to the comments and work notes field"
 	ErrorCreateComment                    = "Error in creating the comment"
 	ErrorSearchingRecord                  = "Error in searching for records in ServiceNow"
 	ErrorGetRecord                        = "Error in getting record from ServiceNow"
 	ErrorGetStates                        = "Error in getting the states"
 	ErrorUpdateState                      = "Error in updating the state"
+	ErrorInvalidChoice                    = "%s is not valid"
+	ErrorInvalidStateTransition           = "The record can't be moved to this state from its current state"
+	ErrorGetCurrentState                  = "Error in getting the current state of the record"
 	ErrorACLRestrictsRecordRetrieval      = "ACL restricts the record retrieval"
 	ErrorHandlingNestedFields             = "Error in handling the nested fields"
+	ErrorGetPost                          = "Error in getting the post"
+	ErrorGetPostThread                    = "Error in getting the post thread"
+	ErrorInvalidPostID                    = "post ID is not valid"
+	ErrorRecordTypeNotCreatable           = "records of this type can't be created from Mattermost"
+	ErrorEmptyMessage                     = "the message doesn't have any text to create the record from"
+	ErrorInvalidQuantity                  = "quantity is not valid"
+	ErrorMissingRequiredField             = "%s is required"
+	ErrorGetRequestedItems                = "Error in getting the requested items of the order"
 	ErrorCommandInvalidNumberOfParams     = "Some field(s) are missing to run the command. Please run `/servicenow help` for more information."
 	ErrorUserMismatch                     = "User ID does not match with the currently logged-in user ID."
 	ErrorInsufficientPermissions          = "user has insufficient permissions for the current channel"
 	ErrorChannelPermissionsForUser        = "unable to get the channel permissions for a user"
 	ErrorNoActiveSubscriptions            = "You don't have any active subscriptions."
 	ErrorInvalidChannelType               = "invalid channel type for performing action"
+	ErrorStoreSubscriptionFilters         = "Error in storing the subscription filters"
+	ErrorUpdateSubscriptionFilters        = "Error in updating the subscription filters"
+	ErrorDeleteSubscriptionFilters        = "Error in deleting the subscription filters"
+	ErrorLoadSubscriptionFilters          = "Error in loading the subscription filters"
+	ErrorLoadNotificationThread           = "Error in loading the notification thread of the record"
+	ErrorStoreNotificationThread          = "Error in storing the notification thread of the record"
+	ErrorDeleteNotificationThread         = "Error in deleting the notification thread of the record"
+	ErrorUpdateNotificationThread         = "Error in updating the root post of the notification thread"
+	ErrorInvalidApprovalID                = "approval ID is not valid"
+	ErrorInvalidApprovalState             = "approval state is not valid"
+	ErrorEmptyRejectionComment            = "a comment is required for rejecting the approval"
+	ErrorUpdateApproval                   = "Error in updating the approval"
+	ErrorOpenRejectDialog                 = "Error in opening the dialog for rejecting the approval"
+	ErrorApproverNotFound                 = "Unable to find the Mattermost user of the approver"
+	ErrorApproverNotConnected             = "The approver has not connected their ServiceNow account"
+	ErrorApproverMismatch                 = "The ServiceNow account of the Mattermost user does not match the approver"
+	ErrorUpdatePost                       = "Error in updating the post"
+	ErrorInvalidSearchState               = "state is not valid"
+	ErrorInvalidArticleID                 = "knowledge article ID is not valid"
+	ErrorPostKnowledgeArticle             = "Error in posting the knowledge article"
+	ErrorInvalidIncidentNumber            = "incident number is not valid"
+	ErrorInvalidIncidentID                = "incident ID is not valid"
+	ErrorIncidentNotFound                 = "Unable to find the incident %s"
+	ErrorCreateWarRoom                    = "Error in creating the war room"
+	ErrorSuggestKnowledgeArticles         = "Error in searching the knowledge articles to suggest for the incident"
+	ErrorKnowledgeArticleNotPublished     = "The knowledge article is not published"
+	ErrorSearchFilterNotSupported         = "The state and assigned to me filters are not supported for this record type"
+	ErrorPushWebhookSecret                = "Unable to update the webhook secret in ServiceNow"
+	ErrorEnqueueNotification              = "Unable to queue the notification"
+	ErrorLoadNotificationQueue            = "Unable to load the queued notifications"
+	ErrorUpdateNotificationQueue          = "Unable to update the queued notification"
+	ErrorNotificationDeadLettered         = "Unable to post the notification after all the attempts, it has been moved to the failed notifications"
+	ErrorLoadDeadLetterNotifications      = "Unable to load the failed notifications"
+	ErrorStoreSubscriptionDeliveryMode    = "Error in storing the delivery mode of the subscription"
+	ErrorUpdateSubscriptionDeliveryMode   = "Error in updating the delivery mode of the subscription"
+	ErrorDeleteSubscriptionDeliveryMode   = "Error in deleting the delivery mode of the subscription"
+	ErrorLoadSubscriptionDeliveryMode     = "Error in loading the delivery mode of the subscription"
+	ErrorAddEventToNotificationDigest     = "Unable to add the event to the notification digest"
+	ErrorLoadNotificationDigests          = "Unable to load the notification digests"
+	ErrorDeleteNotificationDigest         = "Unable to delete the notification digest"
 )
 
 // kv store keys prefix
 const (
-	UserKeyPrefix   = "user_"
-	OAuth2KeyPrefix = "oauth2_"
+	UserKeyPrefix                   = "user_"
+	OAuth2KeyPrefix                 = "oauth2_"
+	SubscriptionFiltersKeyPrefix    = "filters_"
+	NotificationThreadKeyPrefix     = "thread_"
+	WebhookEventKeyPrefix           = "event_"
+	SubscriptionCacheKeyPrefix      = "subscription_"
+	QueuedNotificationKeyPrefix     = "queued_notification_"
+	DeadLetterNotificationKeyPrefix = "failed_notification_"
+	DeliveryModeKeyPrefix           = "delivery_mode_"
+	NotificationDigestKeyPrefix     = "digest_"
+	WarRoomKeyPrefix                = "war_room_"
+
+	PreviousWebhookSecretKey = "previous_webhook_secret"
+	WebhookSecretRotationKey = "webhook_secret_rotation"
+	NotificationDigestsKey   = "notification_digests"
 )
 
 var (
-	ValidSubscriptionTypes = map[string]bool{
-		SubscriptionTypeRecord: true,
-		SubscriptionTypeBulk:   true,
-	}
-
-	ValidSubscriptionRecordTypes = map[string]bool{
-		RecordTypeIncident:      true,
-		RecordTypeProblem:       true,
-		RecordTypeChangeRequest: true,
+	// CatalogVariableFormFieldTypes maps the friendly types of the catalog item variables supported by the plugin to form field types
+	CatalogVariableFormFieldTypes = map[string]string{
+		"single_line_text":      FormFieldTypeText,
+		"wide_single_line_text": FormFieldTypeText,
+		"email":                 FormFieldTypeText,
+		"url":                   FormFieldTypeText,
+		"ip_address":            FormFieldTypeText,
+		"multi_line_text":       FormFieldTypeTextarea,
+		"numeric_scale":         FormFieldTypeNumber,
+		"checkbox":              FormFieldTypeBool,
+		"yes_no":                FormFieldTypeSelect,
+		"select_box":            FormFieldTypeSelect,
+		"multiple_choice":       FormFieldTypeRadio,
+		"reference":             FormFieldTypeReference,
+		"date":                  FormFieldTypeDate,
+		"date_time":             FormFieldTypeDateTime,
 	}
 
-	ValidRecordTypesForSearching = map[string]bool{
-		RecordTypeIncident:      true,
-		RecordTypeProblem:       true,
-		RecordTypeChangeRequest: true,
-		RecordTypeKnowledge:     true,
-		RecordTypeTask:          true,
-		RecordTypeChangeTask:    true,
-		RecordTypeFollowOnTask:  true,
+	ValidSubscriptionTypes = map[string]bool{
+		SubscriptionTypeRecord:   true,
+		SubscriptionTypeBulk:     true,
+		SubscriptionTypePersonal: true,
 	}
 
 	ValidSubscriptionEvents = map[string]bool{
@@ -211,6 +475,109 @@ var (
 		SubscriptionEventAssignmentGroup: true,
 	}
 
+	// RecordTypesWithoutTaskFields are the record types which don't have the state and assigned to fields of the task table
+	RecordTypesWithoutTaskFields = map[string]bool{
+		RecordTypeKnowledge: true,
+	}
+
+	// RecordNumberPrefixes maps the prefixes of the record numbers to the types of the records
+	RecordNumberPrefixes = map[string]string{
+		"INC":   RecordTypeIncident,
+		"PRB":   RecordTypeProblem,
+		"CHG":   RecordTypeChangeRequest,
+		"KB":    RecordTypeKnowledge,
+		"TASK":  RecordTypeTask,
+		"CTASK": RecordTypeChangeTask,
+	}
+
+	ValidUrgencies = map[string]bool{
+		"1": true,
+		"2": true,
+		"3": true,
+	}
+
+	ValidImpacts = map[string]bool{
+		"1": true,
+		"2": true,
+		"3": true,
+	}
+
+	IncidentFieldsWithChoices = map[string]bool{
+		FieldUrgency:     true,
+		FieldImpact:      true,
+		FieldCategory:    true,
+		FieldSubcategory: true,
+	}
+
+	// StateRequiredFields maps the states of a record type to the fields which ServiceNow requires for moving a record to that state
+	StateRequiredFields = map[string]map[string][]string{
+		RecordTypeIncident: {
+			IncidentStateOnHold:   {FieldHoldReason},
+			IncidentStateResolved: {FieldCloseCode, FieldCloseNotes},
+			IncidentStateClosed:   {FieldCloseCode, FieldCloseNotes},
+		},
+		RecordTypeProblem: {
+			ProblemStateResolved: {FieldResolutionCode, FieldCloseNotes},
+		},
+		RecordTypeChangeRequest: {
+			ChangeStateClosed: {FieldCloseCode, FieldCloseNotes},
+		},
+	}
+
+	// StateTransitions contains the states to which a record can be moved from its current state, for the record types whose states follow a state model
+	StateTransitions = map[string]map[string][]string{
+		RecordTypeProblem: {
+			ProblemStateNew:               {ProblemStateAssess},
+			ProblemStateAssess:            {ProblemStateRootCauseAnalysis, ProblemStateNew},
+			ProblemStateRootCauseAnalysis: {ProblemStateFixInProgress, ProblemStateResolved},
+			ProblemStateFixInProgress:     {ProblemStateResolved, ProblemStateRootCauseAnalysis},
+			ProblemStateResolved:          {ProblemStateClosed, ProblemStateRootCauseAnalysis},
+		},
+		RecordTypeChangeRequest: {
+			ChangeStateNew:       {ChangeStateAssess, ChangeStateCanceled},
+			ChangeStateAssess:    {ChangeStateAuthorize, ChangeStateNew, ChangeStateCanceled},
+			ChangeStateAuthorize: {ChangeStateScheduled, ChangeStateNew, ChangeStateCanceled},
+			ChangeStateScheduled: {ChangeStateImplement, ChangeStateNew, ChangeStateCanceled},
+			ChangeStateImplement: {ChangeStateReview, ChangeStateCanceled},
+			ChangeStateReview:    {ChangeStateClosed, ChangeStateCanceled},
+		},
+	}
+
+	// StateFieldTypes contains the form field types of the fields required for state transitions
+	StateFieldTypes = map[string]string{
+		FieldCloseCode:      FormFieldTypeSelect,
+		FieldCloseNotes:     FormFieldTypeTextarea,
+		FieldHoldReason:     FormFieldTypeSelect,
+		FieldResolutionCode: FormFieldTypeSelect,
+	}
+
+	StateFieldLabels = map[string]string{
+		FieldCloseCode:      "Close code",
+		FieldCloseNotes:     "Close notes",
+		FieldHoldReason:     "On hold reason",
+		FieldResolutionCode: "Resolution code",
+	}
+
+	ValidDeliveryModes = map[string]bool{
+		DeliveryModeImmediate: true,
+		DeliveryModeHourly:    true,
+		DeliveryModeDaily:     true,
+	}
+
+	FormattedDeliveryModes = map[string]string{
+		DeliveryModeImmediate: "Immediate",
+		DeliveryModeHourly:    "Hourly digest",
+		DeliveryModeDaily:     "Daily digest",
+	}
+
+	ValidPriorities = map[string]bool{
+		"1": true,
+		"2": true,
+		"3": true,
+		"4": true,
+		"5": true,
+	}
+
 	FormattedEventNames = map[string]string{
 		SubscriptionEventCreated:         "New record created",
 		SubscriptionEventPriority:        "Priority changed",
@@ -219,28 +586,8 @@ var (
 		SubscriptionEventAssignedTo:      "Assigned to changed",
 		SubscriptionEventAssignmentGroup: "Assignment group changed",
 	}
-
-	FormattedRecordTypes = map[string]string{
-		RecordTypeProblem:       "Problem",
-		RecordTypeIncident:      "Incident",
-		RecordTypeChangeRequest: "Change Request",
-	}
-
-	RecordTypesSupportingComments = map[string]bool{
-		RecordTypeIncident:      true,
-		RecordTypeProblem:       true,
-		RecordTypeChangeRequest: true,
-		RecordTypeTask:          true,
-		RecordTypeChangeTask:    true,
-		RecordTypeFollowOnTask:  true,
-	}
-
-	RecordTypesSupportingStateUpdation = map[string]bool{
-		RecordTypeIncident:     true,
-		RecordTypeTask:         true,
-		RecordTypeChangeTask:   true,
-		RecordTypeFollowOnTask: true,
-	}
 )
 
 type ServiceNowOAuthToken string
+
+type ConnectedUser string
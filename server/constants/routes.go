@@ -23,6 +23,23 @@ const (
 	PathSearchCatalogItems     = "/catalog"
 	PathGetUsers               = "/users"
 	PathCreateIncident         = "/incident"
+	PathGetRecordTypes         = "/record-types"
+	PathGetIncidentChoices     = "/incident/choices/{field}"
+	PathSearchAssignmentGroups = "/incident/assignment-groups"
+	PathSearchCIs              = "/incident/configuration-items"
+	PathCreateRecordFromPost   = "/create-from-post"
+	PathGetCatalogItem         = "/catalog/{item_id:" + ServiceNowSysIDRegex + "}"
+	PathOrderCatalogItem       = PathGetCatalogItem + "/order"
+	PathOpenSubscriptionModal  = "/subscription-modal"
+	PathAssignToMe             = "/assign-to-me"
+	PathOpenReassignModal      = "/reassign-modal"
+	PathUpdateAssignment       = "/assignment/{record_type}/{record_id:" + ServiceNowSysIDRegex + "}"
+	PathSearchServiceNowUsers  = "/servicenow-users"
+	PathApproveRecord          = "/approval/approve"
+	PathOpenRejectDialog       = "/approval/reject-dialog"
+	PathRejectRecord           = "/approval/reject"
+	PathPostKnowledgeArticle   = "/kb/post"
+	PathCreateWarRoom          = "/war-room"
 
 	// ServiceNow API paths
 	PathActivateSubscriptions         = "api/now/table/" + ServiceNowForMattermostNotificationsAppID + "_servicenow_for_mattermost_notifications_auth"
@@ -30,6 +47,8 @@ const (
 	PathGetRecordsFromServiceNow      = "api/now/table/{tableName}"
 	PathGetStatesFromServiceNow       = "api/" + ServiceNowForMattermostNotificationsAppID + "/getstates/{record_type}"
 	PathGetCatalogItemsFromServiceNow = "api/sn_sc/servicecatalog/items"
+	PathGetCatalogItemFromServiceNow  = PathGetCatalogItemsFromServiceNow + "/{sys_id}"
+	PathOrderCatalogItemInServiceNow  = PathGetCatalogItemFromServiceNow + "/order_now"
 	PathGetUserFromServiceNow         = "/api/now/table/sys_user"
 
 	// ServiceNow URLs
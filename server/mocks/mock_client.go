@@ -59,27 +59,48 @@ func (_m *Client) AddComment(recordType string, recordID string, payload *serial
 	return r0, r1
 }
 
-// CheckForDuplicateSubscription provides a mock function with given fields: _a0
-func (_m *Client) CheckForDuplicateSubscription(_a0 *serializer.SubscriptionPayload) (bool, int, error) {
-	ret := _m.Called(_a0)
+// AssignRecord provides a mock function with given fields: recordType, recordID, payload
+func (_m *Client) AssignRecord(recordType string, recordID string, payload *serializer.ServiceNowAssignmentPayload) (int, error) {
+	ret := _m.Called(recordType, recordID, payload)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, string, *serializer.ServiceNowAssignmentPayload) int); ok {
+		r0 = rf(recordType, recordID, payload)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, *serializer.ServiceNowAssignmentPayload) error); ok {
+		r1 = rf(recordType, recordID, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CheckRecordMatchesQuery provides a mock function with given fields: recordType, recordID, query
+func (_m *Client) CheckRecordMatchesQuery(recordType string, recordID string, query string) (bool, int, error) {
+	ret := _m.Called(recordType, recordID, query)
 
 	var r0 bool
-	if rf, ok := ret.Get(0).(func(*serializer.SubscriptionPayload) bool); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(string, string, string) bool); ok {
+		r0 = rf(recordType, recordID, query)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
 	var r1 int
-	if rf, ok := ret.Get(1).(func(*serializer.SubscriptionPayload) int); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(string, string, string) int); ok {
+		r1 = rf(recordType, recordID, query)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(*serializer.SubscriptionPayload) error); ok {
-		r2 = rf(_a0)
+	if rf, ok := ret.Get(2).(func(string, string, string) error); ok {
+		r2 = rf(recordType, recordID, query)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -117,6 +138,36 @@ func (_m *Client) CreateIncident(_a0 *serializer.IncidentPayload) (*serializer.I
 	return r0, r1, r2
 }
 
+// CreateRecord provides a mock function with given fields: tableName, record
+func (_m *Client) CreateRecord(tableName string, record *serializer.ServiceNowRecordPayload) (*serializer.ServiceNowRecord, int, error) {
+	ret := _m.Called(tableName, record)
+
+	var r0 *serializer.ServiceNowRecord
+	if rf, ok := ret.Get(0).(func(string, *serializer.ServiceNowRecordPayload) *serializer.ServiceNowRecord); ok {
+		r0 = rf(tableName, record)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*serializer.ServiceNowRecord)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, *serializer.ServiceNowRecordPayload) int); ok {
+		r1 = rf(tableName, record)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, *serializer.ServiceNowRecordPayload) error); ok {
+		r2 = rf(tableName, record)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // CreateSubscription provides a mock function with given fields: _a0
 func (_m *Client) CreateSubscription(_a0 *serializer.SubscriptionPayload) (*serializer.SubscriptionResponse, int, error) {
 	ret := _m.Called(_a0)
@@ -258,6 +309,156 @@ func (_m *Client) GetAllSubscriptions(channelID string, userID string, subscript
 	return r0, r1, r2
 }
 
+// GetCatalogItem provides a mock function with given fields: sysID
+func (_m *Client) GetCatalogItem(sysID string) (*serializer.ServiceNowCatalogItemDetails, int, error) {
+	ret := _m.Called(sysID)
+
+	var r0 *serializer.ServiceNowCatalogItemDetails
+	if rf, ok := ret.Get(0).(func(string) *serializer.ServiceNowCatalogItemDetails); ok {
+		r0 = rf(sysID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*serializer.ServiceNowCatalogItemDetails)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string) int); ok {
+		r1 = rf(sysID)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(sysID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetChoices provides a mock function with given fields: tableName, field, dependentValue
+func (_m *Client) GetChoices(tableName string, field string, dependentValue string) ([]*serializer.ServiceNowChoice, int, error) {
+	ret := _m.Called(tableName, field, dependentValue)
+
+	var r0 []*serializer.ServiceNowChoice
+	if rf, ok := ret.Get(0).(func(string, string, string) []*serializer.ServiceNowChoice); ok {
+		r0 = rf(tableName, field, dependentValue)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*serializer.ServiceNowChoice)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, string, string) int); ok {
+		r1 = rf(tableName, field, dependentValue)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string) error); ok {
+		r2 = rf(tableName, field, dependentValue)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetDuplicateSubscriptions provides a mock function with given fields: _a0
+func (_m *Client) GetDuplicateSubscriptions(_a0 *serializer.SubscriptionPayload) ([]*serializer.SubscriptionResponse, int, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []*serializer.SubscriptionResponse
+	if rf, ok := ret.Get(0).(func(*serializer.SubscriptionPayload) []*serializer.SubscriptionResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*serializer.SubscriptionResponse)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(*serializer.SubscriptionPayload) int); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(*serializer.SubscriptionPayload) error); ok {
+		r2 = rf(_a0)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetGroupMembers provides a mock function with given fields: groupID
+func (_m *Client) GetGroupMembers(groupID string) ([]string, int, error) {
+	ret := _m.Called(groupID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(groupID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string) int); ok {
+		r1 = rf(groupID)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(groupID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetJournalEntries provides a mock function with given fields: recordType, recordID, limit, offset
+func (_m *Client) GetJournalEntries(recordType string, recordID string, limit string, offset string) ([]*serializer.ServiceNowJournalEntry, int, error) {
+	ret := _m.Called(recordType, recordID, limit, offset)
+
+	var r0 []*serializer.ServiceNowJournalEntry
+	if rf, ok := ret.Get(0).(func(string, string, string, string) []*serializer.ServiceNowJournalEntry); ok {
+		r0 = rf(recordType, recordID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*serializer.ServiceNowJournalEntry)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, string, string, string) int); ok {
+		r1 = rf(recordType, recordID, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string) error); ok {
+		r2 = rf(recordType, recordID, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetMe provides a mock function with given fields: userEmail
 func (_m *Client) GetMe(userEmail string) (*serializer.ServiceNowUser, int, error) {
 	ret := _m.Called(userEmail)
@@ -288,6 +489,36 @@ func (_m *Client) GetMe(userEmail string) (*serializer.ServiceNowUser, int, erro
 	return r0, r1, r2
 }
 
+// GetRecordByNumber provides a mock function with given fields: tableName, number
+func (_m *Client) GetRecordByNumber(tableName string, number string) (*serializer.ServiceNowRecord, int, error) {
+	ret := _m.Called(tableName, number)
+
+	var r0 *serializer.ServiceNowRecord
+	if rf, ok := ret.Get(0).(func(string, string) *serializer.ServiceNowRecord); ok {
+		r0 = rf(tableName, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*serializer.ServiceNowRecord)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, string) int); ok {
+		r1 = rf(tableName, number)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(tableName, number)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetRecordFromServiceNow provides a mock function with given fields: tableName, sysID
 func (_m *Client) GetRecordFromServiceNow(tableName string, sysID string) (*serializer.ServiceNowRecord, int, error) {
 	ret := _m.Called(tableName, sysID)
@@ -318,6 +549,64 @@ func (_m *Client) GetRecordFromServiceNow(tableName string, sysID string) (*seri
 	return r0, r1, r2
 }
 
+// GetRecordState provides a mock function with given fields: recordType, recordID
+func (_m *Client) GetRecordState(recordType string, recordID string) (string, int, error) {
+	ret := _m.Called(recordType, recordID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(recordType, recordID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, string) int); ok {
+		r1 = rf(recordType, recordID)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string) error); ok {
+		r2 = rf(recordType, recordID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetRequestedItems provides a mock function with given fields: requestID
+func (_m *Client) GetRequestedItems(requestID string) ([]*serializer.ServiceNowPartialRecord, int, error) {
+	ret := _m.Called(requestID)
+
+	var r0 []*serializer.ServiceNowPartialRecord
+	if rf, ok := ret.Get(0).(func(string) []*serializer.ServiceNowPartialRecord); ok {
+		r0 = rf(requestID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*serializer.ServiceNowPartialRecord)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string) int); ok {
+		r1 = rf(requestID)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string) error); ok {
+		r2 = rf(requestID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetStatesFromServiceNow provides a mock function with given fields: recordType
 func (_m *Client) GetStatesFromServiceNow(recordType string) ([]*serializer.ServiceNowState, int, error) {
 	ret := _m.Called(recordType)
@@ -378,6 +667,36 @@ func (_m *Client) GetSubscription(subscriptionID string) (*serializer.Subscripti
 	return r0, r1, r2
 }
 
+// OrderCatalogItem provides a mock function with given fields: sysID, order
+func (_m *Client) OrderCatalogItem(sysID string, order *serializer.ServiceNowCatalogOrderRequest) (*serializer.ServiceNowCatalogOrderResponse, int, error) {
+	ret := _m.Called(sysID, order)
+
+	var r0 *serializer.ServiceNowCatalogOrderResponse
+	if rf, ok := ret.Get(0).(func(string, *serializer.ServiceNowCatalogOrderRequest) *serializer.ServiceNowCatalogOrderResponse); ok {
+		r0 = rf(sysID, order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*serializer.ServiceNowCatalogOrderResponse)
+		}
+	}
+
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, *serializer.ServiceNowCatalogOrderRequest) int); ok {
+		r1 = rf(sysID, order)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, *serializer.ServiceNowCatalogOrderRequest) error); ok {
+		r2 = rf(sysID, order)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SearchCatalogItemsInServiceNow provides a mock function with given fields: searchTerm, limit, offset
 func (_m *Client) SearchCatalogItemsInServiceNow(searchTerm string, limit string, offset string) ([]*serializer.ServiceNowCatalogItem, int, error) {
 	ret := _m.Called(searchTerm, limit, offset)
@@ -408,19 +727,49 @@ func (_m *Client) SearchCatalogItemsInServiceNow(searchTerm string, limit string
 	return r0, r1, r2
 }
 
-// SearchRecordsInServiceNow provides a mock function with given fields: tableName, searchTerm, limit, offset
-func (_m *Client) SearchRecordsInServiceNow(tableName string, searchTerm string, limit string, offset string) ([]*serializer.ServiceNowPartialRecord, int, error) {
-	ret := _m.Called(tableName, searchTerm, limit, offset)
+// SearchRecordsInServiceNow provides a mock function with given fields: tableName, searchTerm, limit, offset, filters
+func (_m *Client) SearchRecordsInServiceNow(tableName string, searchTerm string, limit string, offset string, filters *serializer.RecordSearchFilters) ([]*serializer.ServiceNowPartialRecord, int, error) {
+	ret := _m.Called(tableName, searchTerm, limit, offset, filters)
 
 	var r0 []*serializer.ServiceNowPartialRecord
-	if rf, ok := ret.Get(0).(func(string, string, string, string) []*serializer.ServiceNowPartialRecord); ok {
-		r0 = rf(tableName, searchTerm, limit, offset)
+	if rf, ok := ret.Get(0).(func(string, string, string, string, *serializer.RecordSearchFilters) []*serializer.ServiceNowPartialRecord); ok {
+		r0 = rf(tableName, searchTerm, limit, offset, filters)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*serializer.ServiceNowPartialRecord)
 		}
 	}
 
+	var r1 int
+	if rf, ok := ret.Get(1).(func(string, string, string, string, *serializer.RecordSearchFilters) int); ok {
+		r1 = rf(tableName, searchTerm, limit, offset, filters)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(string, string, string, string, *serializer.RecordSearchFilters) error); ok {
+		r2 = rf(tableName, searchTerm, limit, offset, filters)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SearchReferenceRecords provides a mock function with given fields: tableName, searchTerm, limit, offset
+func (_m *Client) SearchReferenceRecords(tableName string, searchTerm string, limit string, offset string) ([]*serializer.ServiceNowReference, int, error) {
+	ret := _m.Called(tableName, searchTerm, limit, offset)
+
+	var r0 []*serializer.ServiceNowReference
+	if rf, ok := ret.Get(0).(func(string, string, string, string) []*serializer.ServiceNowReference); ok {
+		r0 = rf(tableName, searchTerm, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*serializer.ServiceNowReference)
+		}
+	}
+
 	var r1 int
 	if rf, ok := ret.Get(1).(func(string, string, string, string) int); ok {
 		r1 = rf(tableName, searchTerm, limit, offset)
@@ -438,6 +787,27 @@ func (_m *Client) SearchRecordsInServiceNow(tableName string, searchTerm string,
 	return r0, r1, r2
 }
 
+// UpdateApproval provides a mock function with given fields: approvalID, payload
+func (_m *Client) UpdateApproval(approvalID string, payload *serializer.ServiceNowApprovalPayload) (int, error) {
+	ret := _m.Called(approvalID, payload)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(string, *serializer.ServiceNowApprovalPayload) int); ok {
+		r0 = rf(approvalID, payload)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, *serializer.ServiceNowApprovalPayload) error); ok {
+		r1 = rf(approvalID, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateStateOfRecordInServiceNow provides a mock function with given fields: recordType, recordID, payload
 func (_m *Client) UpdateStateOfRecordInServiceNow(recordType string, recordID string, payload *serializer.ServiceNowUpdateStatePayload) (int, error) {
 	ret := _m.Called(recordType, recordID, payload)
@@ -17,6 +17,90 @@ type Store struct {
 	mock.Mock
 }
 
+// AddEventToNotificationDigest provides a mock function with given fields: digest, event
+func (_m *Store) AddEventToNotificationDigest(digest *serializer.NotificationDigest, event *serializer.ServiceNowEvent) error {
+	ret := _m.Called(digest, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*serializer.NotificationDigest, *serializer.ServiceNowEvent) error); ok {
+		r0 = rf(digest, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteCachedSubscription provides a mock function with given fields: subscriptionID
+func (_m *Store) DeleteCachedSubscription(subscriptionID string) error {
+	ret := _m.Called(subscriptionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(subscriptionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNotificationDigest provides a mock function with given fields: digestID
+func (_m *Store) DeleteNotificationDigest(digestID string) error {
+	ret := _m.Called(digestID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(digestID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteNotificationThread provides a mock function with given fields: channelID, recordID
+func (_m *Store) DeleteNotificationThread(channelID string, recordID string) error {
+	ret := _m.Called(channelID, recordID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(channelID, recordID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSubscriptionDeliveryMode provides a mock function with given fields: subscriptionID
+func (_m *Store) DeleteSubscriptionDeliveryMode(subscriptionID string) error {
+	ret := _m.Called(subscriptionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(subscriptionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteSubscriptionFilters provides a mock function with given fields: subscriptionID
+func (_m *Store) DeleteSubscriptionFilters(subscriptionID string) error {
+	ret := _m.Called(subscriptionID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(subscriptionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteUser provides a mock function with given fields: mattermostUserID
 func (_m *Store) DeleteUser(mattermostUserID string) error {
 	ret := _m.Called(mattermostUserID)
@@ -31,6 +115,48 @@ func (_m *Store) DeleteUser(mattermostUserID string) error {
 	return r0
 }
 
+// DeleteWebhookEvent provides a mock function with given fields: eventID
+func (_m *Store) DeleteWebhookEvent(eventID string) error {
+	ret := _m.Called(eventID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(eventID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DequeueNotification provides a mock function with given fields: notificationID
+func (_m *Store) DequeueNotification(notificationID string) error {
+	ret := _m.Called(notificationID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(notificationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnqueueNotification provides a mock function with given fields: notification
+func (_m *Store) EnqueueNotification(notification *serializer.QueuedNotification) error {
+	ret := _m.Called(notification)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*serializer.QueuedNotification) error); ok {
+		r0 = rf(notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAllUsers provides a mock function with given fields:
 func (_m *Store) GetAllUsers() ([]*serializer.IncidentCaller, error) {
 	ret := _m.Called()
@@ -54,6 +180,186 @@ func (_m *Store) GetAllUsers() ([]*serializer.IncidentCaller, error) {
 	return r0, r1
 }
 
+// LoadCachedSubscription provides a mock function with given fields: subscriptionID
+func (_m *Store) LoadCachedSubscription(subscriptionID string) (*serializer.SubscriptionResponse, error) {
+	ret := _m.Called(subscriptionID)
+
+	var r0 *serializer.SubscriptionResponse
+	if rf, ok := ret.Get(0).(func(string) *serializer.SubscriptionResponse); ok {
+		r0 = rf(subscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*serializer.SubscriptionResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadDeadLetterNotifications provides a mock function with given fields:
+func (_m *Store) LoadDeadLetterNotifications() ([]*serializer.QueuedNotification, error) {
+	ret := _m.Called()
+
+	var r0 []*serializer.QueuedNotification
+	if rf, ok := ret.Get(0).(func() []*serializer.QueuedNotification); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*serializer.QueuedNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadNotificationDigests provides a mock function with given fields:
+func (_m *Store) LoadNotificationDigests() ([]*serializer.NotificationDigest, error) {
+	ret := _m.Called()
+
+	var r0 []*serializer.NotificationDigest
+	if rf, ok := ret.Get(0).(func() []*serializer.NotificationDigest); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*serializer.NotificationDigest)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadNotificationThread provides a mock function with given fields: channelID, recordID
+func (_m *Store) LoadNotificationThread(channelID string, recordID string) (string, error) {
+	ret := _m.Called(channelID, recordID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(channelID, recordID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(channelID, recordID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadPreviousWebhookSecret provides a mock function with given fields:
+func (_m *Store) LoadPreviousWebhookSecret() (*serializer.PreviousWebhookSecret, error) {
+	ret := _m.Called()
+
+	var r0 *serializer.PreviousWebhookSecret
+	if rf, ok := ret.Get(0).(func() *serializer.PreviousWebhookSecret); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*serializer.PreviousWebhookSecret)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadQueuedNotifications provides a mock function with given fields:
+func (_m *Store) LoadQueuedNotifications() ([]*serializer.QueuedNotification, error) {
+	ret := _m.Called()
+
+	var r0 []*serializer.QueuedNotification
+	if rf, ok := ret.Get(0).(func() []*serializer.QueuedNotification); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*serializer.QueuedNotification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadSubscriptionDeliveryMode provides a mock function with given fields: subscriptionID
+func (_m *Store) LoadSubscriptionDeliveryMode(subscriptionID string) (string, error) {
+	ret := _m.Called(subscriptionID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(subscriptionID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoadSubscriptionFilters provides a mock function with given fields: subscriptionID
+func (_m *Store) LoadSubscriptionFilters(subscriptionID string) (*serializer.SubscriptionFilters, error) {
+	ret := _m.Called(subscriptionID)
+
+	var r0 *serializer.SubscriptionFilters
+	if rf, ok := ret.Get(0).(func(string) *serializer.SubscriptionFilters); ok {
+		r0 = rf(subscriptionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*serializer.SubscriptionFilters)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadUser provides a mock function with given fields: mattermostUserID
 func (_m *Store) LoadUser(mattermostUserID string) (*serializer.User, error) {
 	ret := _m.Called(mattermostUserID)
@@ -77,6 +383,83 @@ func (_m *Store) LoadUser(mattermostUserID string) (*serializer.User, error) {
 	return r0, r1
 }
 
+// LoadWarRoomChannelID provides a mock function with given fields: teamID, incidentID
+func (_m *Store) LoadWarRoomChannelID(teamID string, incidentID string) (string, error) {
+	ret := _m.Called(teamID, incidentID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(teamID, incidentID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(teamID, incidentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveNotificationToDeadLetters provides a mock function with given fields: notification
+func (_m *Store) MoveNotificationToDeadLetters(notification *serializer.QueuedNotification) error {
+	ret := _m.Called(notification)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*serializer.QueuedNotification) error); ok {
+		r0 = rf(notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveNotificationToQueue provides a mock function with given fields: notification
+func (_m *Store) MoveNotificationToQueue(notification *serializer.QueuedNotification) error {
+	ret := _m.Called(notification)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*serializer.QueuedNotification) error); ok {
+		r0 = rf(notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreCachedSubscription provides a mock function with given fields: subscription
+func (_m *Store) StoreCachedSubscription(subscription *serializer.SubscriptionResponse) error {
+	ret := _m.Called(subscription)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*serializer.SubscriptionResponse) error); ok {
+		r0 = rf(subscription)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreNotificationThread provides a mock function with given fields: channelID, recordID, postID
+func (_m *Store) StoreNotificationThread(channelID string, recordID string, postID string) error {
+	ret := _m.Called(channelID, recordID, postID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(channelID, recordID, postID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreOAuth2State provides a mock function with given fields: state
 func (_m *Store) StoreOAuth2State(state string) error {
 	ret := _m.Called(state)
@@ -91,6 +474,48 @@ func (_m *Store) StoreOAuth2State(state string) error {
 	return r0
 }
 
+// StorePreviousWebhookSecret provides a mock function with given fields: previousSecret, ttlSeconds
+func (_m *Store) StorePreviousWebhookSecret(previousSecret *serializer.PreviousWebhookSecret, ttlSeconds int64) error {
+	ret := _m.Called(previousSecret, ttlSeconds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*serializer.PreviousWebhookSecret, int64) error); ok {
+		r0 = rf(previousSecret, ttlSeconds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreSubscriptionDeliveryMode provides a mock function with given fields: subscriptionID, deliveryMode
+func (_m *Store) StoreSubscriptionDeliveryMode(subscriptionID string, deliveryMode string) error {
+	ret := _m.Called(subscriptionID, deliveryMode)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(subscriptionID, deliveryMode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreSubscriptionFilters provides a mock function with given fields: subscriptionID, filters
+func (_m *Store) StoreSubscriptionFilters(subscriptionID string, filters *serializer.SubscriptionFilters) error {
+	ret := _m.Called(subscriptionID, filters)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *serializer.SubscriptionFilters) error); ok {
+		r0 = rf(subscriptionID, filters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // StoreUser provides a mock function with given fields: user
 func (_m *Store) StoreUser(user *serializer.User) error {
 	ret := _m.Called(user)
@@ -105,6 +530,90 @@ func (_m *Store) StoreUser(user *serializer.User) error {
 	return r0
 }
 
+// StoreWarRoomChannelID provides a mock function with given fields: teamID, incidentID, channelID
+func (_m *Store) StoreWarRoomChannelID(teamID string, incidentID string, channelID string) error {
+	ret := _m.Called(teamID, incidentID, channelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(teamID, incidentID, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreWebhookEvent provides a mock function with given fields: eventID
+func (_m *Store) StoreWebhookEvent(eventID string) (bool, error) {
+	ret := _m.Called(eventID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(eventID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(eventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StoreWebhookSecretRotation provides a mock function with given fields: secretHash
+func (_m *Store) StoreWebhookSecretRotation(secretHash string) (bool, error) {
+	ret := _m.Called(secretHash)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(secretHash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(secretHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateQueuedNotification provides a mock function with given fields: notification
+func (_m *Store) UpdateQueuedNotification(notification *serializer.QueuedNotification) error {
+	ret := _m.Called(notification)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*serializer.QueuedNotification) error); ok {
+		r0 = rf(notification)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateUserToken provides a mock function with given fields: mattermostUserID, encodedToken, shouldUpdate
+func (_m *Store) UpdateUserToken(mattermostUserID string, encodedToken string, shouldUpdate func(storedToken string) bool) error {
+	ret := _m.Called(mattermostUserID, encodedToken, shouldUpdate)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, func(storedToken string) bool) error); ok {
+		r0 = rf(mattermostUserID, encodedToken, shouldUpdate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // VerifyOAuth2State provides a mock function with given fields: state
 func (_m *Store) VerifyOAuth2State(state string) error {
 	ret := _m.Called(state)
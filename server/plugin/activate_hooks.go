@@ -34,12 +34,30 @@ func (p *Plugin) OnActivate() error {
 
 	p.router = p.InitAPI()
 	p.store = p.NewStore(p.API)
+	if err = p.startNotificationQueueWorker(); err != nil {
+		return errors.Wrap(err, "failed to start the notification queue worker")
+	}
+
+	if err = p.startNotificationDigestJob(); err != nil {
+		return errors.Wrap(err, "failed to schedule the notification digests")
+	}
+
 	p.initializeTelemetry()
 
 	return nil
 }
 
 func (p *Plugin) OnDeactivate() error {
+	if p.stopNotificationQueueWorker != nil {
+		p.stopNotificationQueueWorker()
+	}
+
+	if p.notificationDigestJob != nil {
+		if err := p.notificationDigestJob.Close(); err != nil {
+			p.API.LogWarn("Failed to close the notification digest job", "error", err.Error())
+		}
+	}
+
 	if err := p.telemetryClient.Close(); err != nil {
 		p.API.LogWarn("Telemetry client failed to close", "error", err.Error())
 	}
@@ -8,9 +8,12 @@ import (
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"regexp"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/mattermost/mattermost-server/v6/model"
@@ -52,7 +55,24 @@ func (p *Plugin) InitAPI() *mux.Router {
 	s.HandleFunc(constants.PathGetConfig, p.checkAuth(p.getConfig)).Methods(http.MethodGet)
 	s.HandleFunc(constants.PathGetUsers, p.checkAuth(p.checkOAuth(p.handleGetUsers))).Methods(http.MethodGet)
 	s.HandleFunc(constants.PathCreateIncident, p.checkAuth(p.checkOAuth(p.createIncident))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathCreateRecordFromPost, p.checkAuth(p.checkOAuth(p.createRecordFromPost))).Methods(http.MethodPost)
 	s.HandleFunc(constants.PathSearchCatalogItems, p.checkAuth(p.checkOAuth(p.searchCatalogItemsInServiceNow))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathGetCatalogItem, p.checkAuth(p.checkOAuth(p.getCatalogItem))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathOrderCatalogItem, p.checkAuth(p.checkOAuth(p.orderCatalogItem))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathOpenSubscriptionModal, p.checkAuth(p.handleOpenSubscriptionModal)).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathGetRecordTypes, p.checkAuth(p.getRecordTypes)).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathGetIncidentChoices, p.checkAuth(p.checkOAuth(p.getIncidentFieldChoices))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathSearchAssignmentGroups, p.checkAuth(p.checkOAuth(p.searchAssignmentGroups))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathSearchCIs, p.checkAuth(p.checkOAuth(p.searchConfigurationItems))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathSearchServiceNowUsers, p.checkAuth(p.checkOAuth(p.searchServiceNowUsers))).Methods(http.MethodGet)
+	s.HandleFunc(constants.PathAssignToMe, p.checkAuth(p.checkOAuth(p.handleAssignToMe))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathOpenReassignModal, p.checkAuth(p.handleOpenReassignModal)).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathUpdateAssignment, p.checkAuth(p.checkOAuth(p.updateAssignmentOfRecord))).Methods(http.MethodPatch)
+	s.HandleFunc(constants.PathApproveRecord, p.checkAuth(p.checkOAuth(p.handleApproveRecord))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathPostKnowledgeArticle, p.checkAuth(p.checkOAuth(p.handlePostKnowledgeArticle))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathCreateWarRoom, p.checkAuth(p.checkOAuth(p.handleCreateWarRoom))).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathOpenRejectDialog, p.checkAuth(p.handleOpenRejectDialog)).Methods(http.MethodPost)
+	s.HandleFunc(constants.PathRejectRecord, p.checkAuth(p.checkOAuth(p.handleRejectRecord))).Methods(http.MethodPost)
 
 	// 404 handler
 	r.Handle("{anything:.*}", http.NotFoundHandler())
@@ -93,6 +113,7 @@ func (p *Plugin) checkOAuth(handler http.HandlerFunc) http.HandlerFunc {
 		}
 
 		ctx := context.WithValue(r.Context(), constants.ContextTokenKey, token)
+		ctx = context.WithValue(ctx, constants.ContextUserKey, user)
 		r = r.Clone(ctx)
 		handler(w, r)
 	}
@@ -130,6 +151,10 @@ func (p *Plugin) getConfig(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (p *Plugin) getRecordTypes(w http.ResponseWriter, r *http.Request) {
+	p.writeJSONArray(w, 0, p.getConfiguration().RecordTypeRegistry.List())
+}
+
 func (p *Plugin) getConnected(w http.ResponseWriter, r *http.Request) {
 	resp := &serializer.ConnectedResponse{
 		Connected: false,
@@ -144,9 +169,35 @@ func (p *Plugin) getConnected(w http.ResponseWriter, r *http.Request) {
 }
 
 // checkAuthBySecret verifies if provided request is performed by an authorized source.
+// The signed requests are verified by their signature, while the others are verified by the secret in the URL,
+// unless the signature is required by the configuration.
 func (p *Plugin) checkAuthBySecret(handleFunc http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if status, err := verifyHTTPSecret(p.getConfiguration().WebhookSecret, r.FormValue("secret")); err != nil {
+		config := p.getConfiguration()
+		if r.Header.Get(constants.HeaderWebhookSignature) != "" {
+			status, err := p.verifyWithWebhookSecrets(func(secret string) (int, error) {
+				return verifyWebhookRequest(r, secret, time.Now())
+			})
+			if err != nil {
+				p.API.LogError(constants.ErrorInvalidSignature, "Error", err.Error())
+				p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: status, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorInvalidSignature, err.Error())})
+				return
+			}
+
+			handleFunc(w, r)
+			return
+		}
+
+		if config.RequireWebhookSignature {
+			p.API.LogError(constants.ErrorMissingSignature)
+			p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusUnauthorized, Message: constants.ErrorMissingSignature})
+			return
+		}
+
+		status, err := p.verifyWithWebhookSecrets(func(secret string) (int, error) {
+			return verifyHTTPSecret(secret, r.FormValue("secret"))
+		})
+		if err != nil {
 			p.API.LogError(constants.ErrorInvalidSecret, "Error", err.Error())
 			p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: status, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorInvalidSecret, err.Error())})
 			return
@@ -224,7 +275,7 @@ func (p *Plugin) createSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = subscription.IsValidForCreation(p.getConfiguration().MattermostSiteURL); err != nil {
+	if err = subscription.IsValidForCreation(p.getConfiguration().MattermostSiteURL, p.getConfiguration().RecordTypeRegistry); err != nil {
 		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
 		return
@@ -243,8 +294,12 @@ func (p *Plugin) createSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Filters and delivery mode are stored in the plugin's KV store instead of ServiceNow
+	filters, deliveryMode := subscription.Filters, subscription.DeliveryMode
+	subscription.Filters, subscription.DeliveryMode = nil, nil
+
 	client := p.GetClientFromRequest(r)
-	exists, statusCode, err := client.CheckForDuplicateSubscription(subscription)
+	exists, statusCode, err := p.IsDuplicateSubscription(client, subscription, filters)
 	if err != nil {
 		_ = p.handleClientError(w, r, err, false, statusCode, "", "")
 		p.API.LogError("Error in checking for duplicate subscription", "Error", err.Error())
@@ -267,6 +322,24 @@ func (p *Plugin) createSubscription(w http.ResponseWriter, r *http.Request) {
 		resp.Number = *subscription.RecordNumber
 	}
 
+	if !filters.IsEmpty() {
+		if err = p.store.StoreSubscriptionFilters(resp.SysID, filters); err != nil {
+			p.API.LogError(constants.ErrorStoreSubscriptionFilters, "SubscriptionID", resp.SysID, "Error", err.Error())
+			p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorStoreSubscriptionFilters, err.Error())})
+			return
+		}
+		resp.Filters = filters
+	}
+
+	if deliveryMode != nil && *deliveryMode != constants.DeliveryModeImmediate {
+		if err = p.store.StoreSubscriptionDeliveryMode(resp.SysID, *deliveryMode); err != nil {
+			p.API.LogError(constants.ErrorStoreSubscriptionDeliveryMode, "SubscriptionID", resp.SysID, "Error", err.Error())
+			p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorStoreSubscriptionDeliveryMode, err.Error())})
+			return
+		}
+		resp.DeliveryMode = *deliveryMode
+	}
+
 	post := resp.CreateSubscriptionCreatedPost(p.botID, p.getConfiguration().ServiceNowBaseURL)
 	if _, postErr := p.API.CreatePost(post); postErr != nil {
 		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
@@ -321,6 +394,8 @@ func (p *Plugin) getAllSubscriptions(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if subscription.Type == constants.SubscriptionTypeBulk {
+			p.LoadSubscriptionFilters(subscription)
+			p.LoadSubscriptionDeliveryMode(subscription)
 			bulkSubscriptions = append(bulkSubscriptions, subscription)
 			continue
 		}
@@ -350,6 +425,7 @@ func (p *Plugin) deleteSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	p.deleteSubscriptionData(subscriptionID)
 	returnStatusOK(w)
 }
 
@@ -363,7 +439,7 @@ func (p *Plugin) editSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err = subscription.IsValidForUpdation(p.getConfiguration().MattermostSiteURL); err != nil {
+	if err = subscription.IsValidForUpdation(p.getConfiguration().MattermostSiteURL, p.getConfiguration().RecordTypeRegistry); err != nil {
 		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
 		return
@@ -376,6 +452,9 @@ func (p *Plugin) editSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	filters, deliveryMode := subscription.Filters, subscription.DeliveryMode
+	subscription.Filters, subscription.DeliveryMode = nil, nil
+
 	client := p.GetClientFromRequest(r)
 	resp, statusCode, editErr := client.EditSubscription(subscriptionID, subscription)
 	if editErr != nil {
@@ -388,10 +467,45 @@ func (p *Plugin) editSubscription(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	p.deleteCachedSubscription(subscriptionID)
 	if subscription.RecordNumber != nil {
 		resp.Number = *subscription.RecordNumber
 	}
 
+	var filtersErr error
+	switch {
+	case resp.Type != constants.SubscriptionTypeBulk || (filters != nil && filters.IsEmpty()):
+		filtersErr = p.store.DeleteSubscriptionFilters(subscriptionID)
+	case filters != nil:
+		filtersErr = p.store.StoreSubscriptionFilters(subscriptionID, filters)
+		resp.Filters = filters
+	default:
+		p.LoadSubscriptionFilters(resp)
+	}
+
+	if filtersErr != nil {
+		p.API.LogError(constants.ErrorUpdateSubscriptionFilters, "SubscriptionID", subscriptionID, "Error", filtersErr.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorUpdateSubscriptionFilters, filtersErr.Error())})
+		return
+	}
+
+	var deliveryModeErr error
+	switch {
+	case resp.Type != constants.SubscriptionTypeBulk || (deliveryMode != nil && *deliveryMode == constants.DeliveryModeImmediate):
+		deliveryModeErr = p.store.DeleteSubscriptionDeliveryMode(subscriptionID)
+	case deliveryMode != nil:
+		deliveryModeErr = p.store.StoreSubscriptionDeliveryMode(subscriptionID, *deliveryMode)
+		resp.DeliveryMode = *deliveryMode
+	default:
+		p.LoadSubscriptionDeliveryMode(resp)
+	}
+
+	if deliveryModeErr != nil {
+		p.API.LogError(constants.ErrorUpdateSubscriptionDeliveryMode, "SubscriptionID", subscriptionID, "Error", deliveryModeErr.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorUpdateSubscriptionDeliveryMode, deliveryModeErr.Error())})
+		return
+	}
+
 	post := resp.CreateSubscriptionEditedPost(p.botID, p.getConfiguration().ServiceNowBaseURL)
 	if _, postErr := p.API.CreatePost(post); postErr != nil {
 		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
@@ -441,7 +555,7 @@ func (p *Plugin) getUserChannelsForTeam(w http.ResponseWriter, r *http.Request)
 func (p *Plugin) searchRecordsInServiceNow(w http.ResponseWriter, r *http.Request) {
 	pathParams := mux.Vars(r)
 	recordType := pathParams[constants.PathParamRecordType]
-	if !constants.ValidRecordTypesForSearching[recordType] {
+	if recordType != constants.RecordTypeAll && !p.getConfiguration().RecordTypeRegistry.IsSearchable(recordType) {
 		p.API.LogError("Invalid record type while searching", "Record type", recordType)
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidRecordType})
 		return
@@ -453,9 +567,27 @@ func (p *Plugin) searchRecordsInServiceNow(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	filters, err := getRecordSearchFilters(r)
+	if err != nil {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: err.Error()})
+		return
+	}
+
+	if recordType != constants.RecordTypeAll && !filters.IsSupportedBy(recordType) {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorSearchFilterNotSupported})
+		return
+	}
+
 	page, perPage := GetPageAndPerPage(r)
 	client := p.GetClientFromRequest(r)
-	records, statusCode, err := client.SearchRecordsInServiceNow(recordType, searchTerm, fmt.Sprint(perPage), fmt.Sprint(page*perPage))
+	var records []*serializer.ServiceNowPartialRecord
+	var statusCode int
+	if recordType == constants.RecordTypeAll {
+		records, statusCode, err = p.searchRecordsOfAllRecordTypes(client, searchTerm, filters, page, perPage)
+	} else {
+		records, statusCode, err = client.SearchRecordsInServiceNow(recordType, searchTerm, fmt.Sprint(perPage), fmt.Sprint(page*perPage), filters)
+	}
+
 	if err != nil {
 		p.API.LogError(constants.ErrorSearchingRecord, "Error", err.Error())
 		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorSearchingRecord, err.Error()))
@@ -468,10 +600,111 @@ func (p *Plugin) searchRecordsInServiceNow(w http.ResponseWriter, r *http.Reques
 	p.writeJSONArray(w, statusCode, records)
 }
 
+// searchRecordsOfAllRecordTypes searches the records of all the searchable record types concurrently
+// and returns the requested page of the merged results, ranked by their relevance to the search term.
+// The record types which fail to be searched are skipped, unless all of them fail.
+func (p *Plugin) searchRecordsOfAllRecordTypes(client Client, searchTerm string, filters *serializer.RecordSearchFilters, page, perPage int) ([]*serializer.ServiceNowPartialRecord, int, error) {
+	var recordTypes []string
+	for _, recordType := range p.getConfiguration().RecordTypeRegistry.List() {
+		if recordType.Search && filters.IsSupportedBy(recordType.Name) {
+			recordTypes = append(recordTypes, recordType.Name)
+		}
+	}
+
+	// The records of the requested page can come from any of the record types,
+	// so the records of all the pages up to the requested one are fetched from each of them
+	limit := fmt.Sprint((page + 1) * perPage)
+	results := make([][]*serializer.ServiceNowPartialRecord, len(recordTypes))
+	statusCodes := make([]int, len(recordTypes))
+	errs := make([]error, len(recordTypes))
+	wg := sync.WaitGroup{}
+	for i, recordType := range recordTypes {
+		wg.Add(1)
+		go func(i int, recordType string) {
+			defer wg.Done()
+			results[i], statusCodes[i], errs[i] = client.SearchRecordsInServiceNow(recordType, searchTerm, limit, "0", filters)
+		}(i, recordType)
+	}
+	wg.Wait()
+
+	var records []*serializer.ServiceNowPartialRecord
+	recordsBySysID := map[string]*serializer.ServiceNowPartialRecord{}
+	failed := 0
+	for i, recordType := range recordTypes {
+		if errs[i] != nil {
+			p.API.LogDebug(constants.ErrorSearchingRecord, "Record type", recordType, "Error", errs[i].Error())
+			failed++
+			continue
+		}
+
+		for _, record := range results[i] {
+			record.RecordType = recordType
+			// The task table contains the records of the tables extending it, like incidents and problems,
+			// so the same record can be found in multiple record types. The more specific record type is kept.
+			if existingRecord, ok := recordsBySysID[record.SysID]; ok {
+				if existingRecord.RecordType == constants.RecordTypeTask {
+					*existingRecord = *record
+				}
+				continue
+			}
+
+			recordsBySysID[record.SysID] = record
+			records = append(records, record)
+		}
+	}
+
+	if len(recordTypes) > 0 && failed == len(recordTypes) {
+		return nil, statusCodes[0], errs[0]
+	}
+
+	serializer.RankSearchResults(records, searchTerm)
+	start := page * perPage
+	if start >= len(records) {
+		return nil, http.StatusOK, nil
+	}
+
+	end := start + perPage
+	if end > len(records) {
+		end = len(records)
+	}
+
+	return records[start:end], http.StatusOK, nil
+}
+
+// getRecordSearchFilters returns the filters for searching the records from the query params of the request
+func getRecordSearchFilters(r *http.Request) (*serializer.RecordSearchFilters, error) {
+	query := r.URL.Query()
+	filters := &serializer.RecordSearchFilters{
+		State: strings.TrimSpace(query.Get(constants.QueryParamState)),
+	}
+
+	for queryParam, value := range map[string]*bool{
+		constants.QueryParamActive:       &filters.ActiveOnly,
+		constants.QueryParamAssignedToMe: &filters.AssignedToMe,
+	} {
+		if query.Get(queryParam) == "" {
+			continue
+		}
+
+		parsedValue, err := strconv.ParseBool(query.Get(queryParam))
+		if err != nil {
+			return nil, errors.Errorf("Query param %s is not valid", queryParam)
+		}
+
+		*value = parsedValue
+	}
+
+	if err := filters.Validate(); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
 func (p *Plugin) getRecordFromServiceNow(w http.ResponseWriter, r *http.Request) {
 	pathParams := mux.Vars(r)
 	recordType := pathParams[constants.PathParamRecordType]
-	if !constants.ValidRecordTypesForSearching[recordType] {
+	if !p.getConfiguration().RecordTypeRegistry.IsSearchable(recordType) {
 		p.API.LogError("Invalid record type while trying to get record", "Record type", recordType)
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidRecordType})
 		return
@@ -497,6 +730,13 @@ func (p *Plugin) getRecordFromServiceNow(w http.ResponseWriter, r *http.Request)
 }
 
 func (p *Plugin) handleNotification(w http.ResponseWriter, r *http.Request) {
+	eventID := r.Header.Get(constants.HeaderWebhookEventID)
+	if eventID != "" && !webhookEventIDRegex.MatchString(eventID) {
+		p.API.LogError(constants.ErrorInvalidWebhookEventID, "EventID", eventID)
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidWebhookEventID})
+		return
+	}
+
 	event, err := serializer.ServiceNowEventFromJSON(r.Body)
 	if err != nil {
 		p.API.LogError(constants.ErrorUnmarshallingRequestBody, "Error", err.Error())
@@ -504,10 +744,28 @@ func (p *Plugin) handleNotification(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	post := event.CreateNotificationPost(p.botID, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL())
-	if _, postErr := p.API.CreatePost(post); postErr != nil {
-		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
+	// The repeated deliveries of an event are acknowledged without being posted again
+	if p.isDuplicateWebhookEvent(eventID) {
+		p.API.LogDebug("Dropping the repeated delivery of the webhook event", "EventID", eventID)
+		returnStatusOK(w)
+		return
+	}
+
+	// The notification is posted by the worker of the queue, so that it is retried if it can't be posted now
+	if err = p.enqueueNotification(event); err != nil {
+		p.API.LogError(constants.ErrorEnqueueNotification, "Error", err.Error())
+
+		// The event is forgotten, so that it is accepted if ServiceNow delivers it again
+		if eventID != "" {
+			if deleteErr := p.store.DeleteWebhookEvent(eventID); deleteErr != nil {
+				p.API.LogWarn("Unable to forget the webhook event", "EventID", eventID, "Error", deleteErr.Error())
+			}
+		}
+
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: constants.ErrorEnqueueNotification})
+		return
 	}
+
 	returnStatusOK(w)
 }
 
@@ -541,7 +799,7 @@ func (p *Plugin) shareRecordInChannel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !constants.ValidRecordTypesForSearching[shareRecordData.RecordType] {
+	if !p.getConfiguration().RecordTypeRegistry.IsShareable(shareRecordData.RecordType) {
 		p.API.LogError("Invalid record type while trying to share record", "Record type", shareRecordData.RecordType)
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidRecordType})
 		return
@@ -562,7 +820,7 @@ func (p *Plugin) shareRecordInChannel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	post := record.CreateSharingPost(channelID, p.botID, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL(), user.Username)
+	post := record.CreateSharingPost(channelID, p.botID, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL(), user.Username, p.getConfiguration().RecordTypeRegistry)
 	if _, postErr := p.API.CreatePost(post); postErr != nil {
 		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
 	}
@@ -573,28 +831,46 @@ func (p *Plugin) shareRecordInChannel(w http.ResponseWriter, r *http.Request) {
 func (p *Plugin) getCommentsForRecord(w http.ResponseWriter, r *http.Request) {
 	pathParams := mux.Vars(r)
 	recordType := pathParams[constants.PathParamRecordType]
-	if !constants.RecordTypesSupportingComments[recordType] {
+	if !p.getConfiguration().RecordTypeRegistry.SupportsComments(recordType) {
 		p.API.LogError(constants.ErrorInvalidRecordType, "Record type", recordType)
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidRecordType})
 		return
 	}
 
 	recordID := pathParams[constants.PathParamRecordID]
+	page, perPage := GetPageAndPerPage(r)
 	client := p.GetClientFromRequest(r)
-	response, statusCode, err := client.GetAllComments(recordType, recordID)
+	response := &serializer.CommentsResponse{
+		Entries: []*serializer.CommentEntry{},
+	}
+
+	entries, statusCode, err := client.GetJournalEntries(recordType, recordID, fmt.Sprint(perPage), fmt.Sprint(page*perPage))
+	if err == nil {
+		for _, entry := range entries {
+			response.Entries = append(response.Entries, entry.ToCommentEntry())
+		}
+
+		p.writeJSON(w, statusCode, response)
+		return
+	}
+
+	// Reading the journal entries can be restricted by ACLs, so the display value of the record's field is used in that case
+	p.API.LogWarn(constants.ErrorGetJournalEntries, "Record ID", recordID, "Error", err.Error())
+	comments, statusCode, err := client.GetAllComments(recordType, recordID)
 	if err != nil {
 		p.API.LogError(constants.ErrorGetComments, "Record ID", recordID, "Error", err.Error())
 		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorGetComments, err.Error()))
 		return
 	}
 
-	p.writeJSON(w, statusCode, response.CommentsAndWorkNotes)
+	response.CommentsAndWorkNotes = comments.CommentsAndWorkNotes
+	p.writeJSON(w, statusCode, response)
 }
 
 func (p *Plugin) addCommentsOnRecord(w http.ResponseWriter, r *http.Request) {
 	pathParams := mux.Vars(r)
 	recordType := pathParams[constants.PathParamRecordType]
-	if !constants.RecordTypesSupportingComments[recordType] {
+	if !p.getConfiguration().RecordTypeRegistry.SupportsComments(recordType) {
 		p.API.LogError(constants.ErrorInvalidRecordType, "Record type", recordType)
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidRecordType})
 		return
@@ -607,6 +883,18 @@ func (p *Plugin) addCommentsOnRecord(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = payload.Validate(); err != nil {
+		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
+		return
+	}
+
+	if payload.IsWorkNote() && !p.getConfiguration().RecordTypeRegistry.SupportsWorkNotes(recordType) {
+		p.API.LogError(constants.ErrorWorkNotesNotSupported, "Record type", recordType)
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorWorkNotesNotSupported})
+		return
+	}
+
 	recordID := pathParams[constants.PathParamRecordID]
 	client := p.GetClientFromRequest(r)
 	statusCode, err := client.AddComment(recordType, recordID, payload)
@@ -622,31 +910,86 @@ func (p *Plugin) addCommentsOnRecord(w http.ResponseWriter, r *http.Request) {
 func (p *Plugin) getStatesForRecordType(w http.ResponseWriter, r *http.Request) {
 	pathParams := mux.Vars(r)
 	recordType := pathParams[constants.PathParamRecordType]
-	if !constants.RecordTypesSupportingStateUpdation[recordType] {
+	if !p.getConfiguration().RecordTypeRegistry.SupportsStateUpdation(recordType) {
 		p.API.LogError(constants.ErrorInvalidRecordType, "Record type", recordType)
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidRecordType})
 		return
 	}
 
+	// The states of the record types following a state model depend on the current state of the record
+	recordID := r.URL.Query().Get(constants.QueryParamRecordID)
+	if serializer.HasStateModel(recordType) {
+		if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), recordID); err != nil || !valid {
+			p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidQueryParam})
+			return
+		}
+	}
+
+	statesRecordType := recordType
 	if recordType == constants.RecordTypeFollowOnTask {
-		recordType = constants.RecordTypeTask
+		statesRecordType = constants.RecordTypeTask
 	}
 
 	client := p.GetClientFromRequest(r)
-	states, statusCode, err := client.GetStatesFromServiceNow(recordType)
+	states, statusCode, err := client.GetStatesFromServiceNow(statesRecordType)
 	if err != nil {
-		p.API.LogError(constants.ErrorGetStates, "Record Type", recordType, "Error", err.Error())
+		p.API.LogError(constants.ErrorGetStates, "Record Type", statesRecordType, "Error", err.Error())
 		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorGetStates, err.Error()))
 		return
 	}
 
-	p.writeJSONArray(w, statusCode, states)
+	if serializer.HasStateModel(recordType) {
+		currentState, currentStateStatusCode, currentStateErr := client.GetRecordState(recordType, recordID)
+		if currentStateErr != nil {
+			p.API.LogError(constants.ErrorGetCurrentState, "Record ID", recordID, "Error", currentStateErr.Error())
+			_ = p.handleClientError(w, r, currentStateErr, false, currentStateStatusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorGetCurrentState, currentStateErr.Error()))
+			return
+		}
+
+		states = serializer.GetNextStates(states, recordType, currentState)
+	}
+
+	choices := map[string][]*serializer.ServiceNowChoice{}
+	for _, state := range states {
+		state.RequiredFields, statusCode, err = p.getStateRequiredFields(client, recordType, state.Value, choices)
+		if err != nil {
+			p.API.LogError(constants.ErrorGetChoices, "Record Type", recordType, "Error", err.Error())
+			_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorGetChoices, err.Error()))
+			return
+		}
+	}
+
+	p.writeJSONArray(w, http.StatusOK, states)
+}
+
+// getStateRequiredFields returns the fields required for moving a record to the given state along with the allowed values of the fields having choices.
+// The choices fetched from ServiceNow are cached in the given map so that they are fetched only once for each field.
+func (p *Plugin) getStateRequiredFields(client Client, recordType, state string, choices map[string][]*serializer.ServiceNowChoice) ([]*serializer.StateField, int, error) {
+	fields := serializer.GetStateRequiredFields(recordType, state)
+	for _, field := range fields {
+		if field.Type != constants.FormFieldTypeSelect {
+			continue
+		}
+
+		if _, ok := choices[field.Name]; !ok {
+			fieldChoices, statusCode, err := client.GetChoices(recordType, field.Name, "")
+			if err != nil {
+				return nil, statusCode, err
+			}
+
+			choices[field.Name] = fieldChoices
+		}
+
+		field.Options = choices[field.Name]
+	}
+
+	return fields, http.StatusOK, nil
 }
 
 func (p *Plugin) updateStateOfRecord(w http.ResponseWriter, r *http.Request) {
 	pathParams := mux.Vars(r)
 	recordType := pathParams[constants.PathParamRecordType]
-	if !constants.RecordTypesSupportingStateUpdation[recordType] {
+	if !p.getConfiguration().RecordTypeRegistry.SupportsStateUpdation(recordType) {
 		p.API.LogError(constants.ErrorInvalidRecordType, "Record type", recordType)
 		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidRecordType})
 		return
@@ -667,7 +1010,35 @@ func (p *Plugin) updateStateOfRecord(w http.ResponseWriter, r *http.Request) {
 
 	recordID := pathParams[constants.PathParamRecordID]
 	client := p.GetClientFromRequest(r)
-	statusCode, err := client.UpdateStateOfRecordInServiceNow(recordType, recordID, payload)
+	if serializer.HasStateModel(recordType) {
+		currentState, statusCode, currentStateErr := client.GetRecordState(recordType, recordID)
+		if currentStateErr != nil {
+			p.API.LogError(constants.ErrorGetCurrentState, "Record ID", recordID, "Error", currentStateErr.Error())
+			_ = p.handleClientError(w, r, currentStateErr, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorGetCurrentState, currentStateErr.Error()))
+			return
+		}
+
+		if !serializer.IsValidStateTransition(recordType, currentState, payload.State) {
+			p.API.LogError(constants.ErrorInvalidStateTransition, "Record ID", recordID, "Current state", currentState, "State", payload.State)
+			p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidStateTransition})
+			return
+		}
+	}
+
+	requiredFields, statusCode, err := p.getStateRequiredFields(client, recordType, payload.State, map[string][]*serializer.ServiceNowChoice{})
+	if err != nil {
+		p.API.LogError(constants.ErrorGetChoices, "Record Type", recordType, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorGetChoices, err.Error()))
+		return
+	}
+
+	if err = payload.ValidateRequiredFields(requiredFields); err != nil {
+		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
+		return
+	}
+
+	statusCode, err = client.UpdateStateOfRecordInServiceNow(recordType, recordID, payload)
 	if err != nil {
 		p.API.LogError("Error in updating the state", "Record ID", recordID, "Error", err.Error())
 		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("Error in updating the state. Error: %s", err.Error()))
@@ -677,7 +1048,42 @@ func (p *Plugin) updateStateOfRecord(w http.ResponseWriter, r *http.Request) {
 	returnStatusOK(w)
 }
 
-func (p *Plugin) handleOpenCommentModal(w http.ResponseWriter, r *http.Request) {
+func (p *Plugin) updateAssignmentOfRecord(w http.ResponseWriter, r *http.Request) {
+	pathParams := mux.Vars(r)
+	recordType := pathParams[constants.PathParamRecordType]
+	if !p.getConfiguration().RecordTypeRegistry.SupportsAssignment(recordType) {
+		p.API.LogError(constants.ErrorInvalidRecordType, "Record type", recordType)
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidRecordType})
+		return
+	}
+
+	payload, err := serializer.ServiceNowAssignmentPayloadFromJSON(r.Body)
+	if err != nil {
+		p.API.LogError(constants.ErrorUnmarshallingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorUnmarshallingRequestBody, err.Error())})
+		return
+	}
+
+	if err = payload.Validate(); err != nil {
+		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
+		return
+	}
+
+	recordID := pathParams[constants.PathParamRecordID]
+	client := p.GetClientFromRequest(r)
+	statusCode, err := client.AssignRecord(recordType, recordID, payload)
+	if err != nil {
+		p.API.LogError(constants.ErrorAssignRecord, "Record ID", recordID, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorAssignRecord, err.Error()))
+		return
+	}
+
+	returnStatusOK(w)
+}
+
+// handleAssignToMe assigns the record present in the context of the post action to the ServiceNow user of the Mattermost user who clicked it
+func (p *Plugin) handleAssignToMe(w http.ResponseWriter, r *http.Request) {
 	response := &model.PostActionIntegrationResponse{}
 	decoder := json.NewDecoder(r.Body)
 	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
@@ -687,16 +1093,40 @@ func (p *Plugin) handleOpenCommentModal(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	p.API.PublishWebSocketEvent(
-		constants.WSEventOpenCommentModal,
-		postActionIntegrationRequest.Context,
-		&model.WebsocketBroadcast{UserId: postActionIntegrationRequest.UserId},
-	)
+	recordType, _ := postActionIntegrationRequest.Context[constants.ContextNameRecordType].(string)
+	recordID, _ := postActionIntegrationRequest.Context[constants.ContextNameRecordID].(string)
+	if !p.getConfiguration().RecordTypeRegistry.SupportsAssignment(recordType) {
+		response.EphemeralText = constants.ErrorAssignmentNotSupported
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), recordID); err != nil || !valid {
+		response.EphemeralText = constants.ErrorInvalidRecordID
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	user := GetUserFromRequest(r)
+	if user == nil || user.ServiceNowUser == nil || user.ServiceNowUser.UserID == "" {
+		response.EphemeralText = constants.ErrorServiceNowUserNotFound
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	client := p.GetClientFromRequest(r)
+	if _, err := client.AssignRecord(recordType, recordID, &serializer.ServiceNowAssignmentPayload{AssignedTo: user.ServiceNowUser.UserID}); err != nil {
+		p.API.LogError(constants.ErrorAssignRecord, "Record ID", recordID, "Error", err.Error())
+		response.EphemeralText = fmt.Sprintf("%s. Error: %s", constants.ErrorAssignRecord, err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
 
+	response.EphemeralText = constants.RecordAssignedToMe
 	p.returnPostActionIntegrationResponse(w, response)
 }
 
-func (p *Plugin) handleOpenStateModal(w http.ResponseWriter, r *http.Request) {
+func (p *Plugin) handleOpenReassignModal(w http.ResponseWriter, r *http.Request) {
 	response := &model.PostActionIntegrationResponse{}
 	decoder := json.NewDecoder(r.Body)
 	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
@@ -707,7 +1137,7 @@ func (p *Plugin) handleOpenStateModal(w http.ResponseWriter, r *http.Request) {
 	}
 
 	p.API.PublishWebSocketEvent(
-		constants.WSEventOpenUpdateStateModal,
+		constants.WSEventOpenReassignModal,
 		postActionIntegrationRequest.Context,
 		&model.WebsocketBroadcast{UserId: postActionIntegrationRequest.UserId},
 	)
@@ -715,72 +1145,430 @@ func (p *Plugin) handleOpenStateModal(w http.ResponseWriter, r *http.Request) {
 	p.returnPostActionIntegrationResponse(w, response)
 }
 
-func (p *Plugin) handleGetUsers(w http.ResponseWriter, _ *http.Request) {
-	users, err := p.store.GetAllUsers()
-	if err != nil {
-		p.API.LogError(constants.ErrorGetUsers, "Error", err.Error())
-		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorGetUsers, err.Error())})
+func (p *Plugin) handleApproveRecord(w http.ResponseWriter, r *http.Request) {
+	response := &model.PostActionIntegrationResponse{}
+	decoder := json.NewDecoder(r.Body)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := decoder.Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError("Error decoding PostActionIntegrationRequest params: ", err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
 		return
 	}
 
-	p.writeJSONArray(w, http.StatusOK, users)
-}
-
-func (p *Plugin) createIncident(w http.ResponseWriter, r *http.Request) {
-	incident, err := serializer.IncidentFromJSON(r.Body)
-	if err != nil {
-		p.API.LogError(constants.ErrorUnmarshallingRequestBody, "Error", err.Error())
-		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorUnmarshallingRequestBody, err.Error())})
+	approvalID, _ := postActionIntegrationRequest.Context[constants.ContextNameApprovalID].(string)
+	if !serializer.IsValidApprovalID(approvalID) {
+		response.EphemeralText = constants.ErrorInvalidApprovalID
+		p.returnPostActionIntegrationResponse(w, response)
 		return
 	}
 
-	if err = incident.IsValid(); err != nil {
-		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
-		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
+	client := p.GetClientFromRequest(r)
+	payload := &serializer.ServiceNowApprovalPayload{State: constants.ApprovalStateApproved}
+	if _, err := client.UpdateApproval(approvalID, payload); err != nil {
+		p.API.LogError(constants.ErrorUpdateApproval, "ApprovalID", approvalID, "Error", err.Error())
+		response.EphemeralText = fmt.Sprintf("%s. Error: %s", constants.ErrorUpdateApproval, err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
 		return
 	}
 
-	userID := r.Header.Get(constants.HeaderMattermostUserID)
-	permissionStatusCode, permissionErr := p.HasChannelPermissions(userID, incident.ChannelID)
-	if permissionErr != nil {
-		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: permissionStatusCode, Message: permissionErr.Error()})
+	post, appErr := p.API.GetPost(postActionIntegrationRequest.PostId)
+	if appErr != nil {
+		p.API.LogError(constants.ErrorGetPost, "PostID", postActionIntegrationRequest.PostId, "Error", appErr.Error())
+		p.returnPostActionIntegrationResponse(w, response)
 		return
 	}
 
-	client := p.GetClientFromRequest(r)
-	response, statusCode, err := client.CreateIncident(incident)
-	if err != nil {
-		p.API.LogError(constants.APIErrorCreateIncident, "Error", err.Error())
-		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.APIErrorCreateIncident, err.Error()))
-		return
+	if serializer.UpdateApprovalPost(post, payload.State, payload.Comments) {
+		response.Update = post
 	}
 
-	record := serializer.ServiceNowRecord{
-		SysID:            response.SysID,
-		Number:           response.Number,
-		ShortDescription: response.ShortDescription,
-		Description:      response.Description,
-		RecordType:       constants.RecordTypeIncident,
-		State:            response.State,
-		Priority:         response.Priority,
-		AssignedTo:       response.AssignedTo,
-		AssignmentGroup:  response.AssignmentGroup,
-	}
+	p.returnPostActionIntegrationResponse(w, response)
+}
 
-	if err := record.HandleNestedFields(p.getConfiguration().ServiceNowBaseURL); err != nil {
-		p.API.LogError(constants.ErrorHandlingNestedFields, "Error", err.Error())
-		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorHandlingNestedFields, err.Error())})
+// handlePostKnowledgeArticle posts the text of a knowledge article chosen from the results of the "/servicenow kb" command in the channel
+func (p *Plugin) handlePostKnowledgeArticle(w http.ResponseWriter, r *http.Request) {
+	response := &model.PostActionIntegrationResponse{}
+	decoder := json.NewDecoder(r.Body)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := decoder.Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError("Error decoding PostActionIntegrationRequest params: ", err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
 		return
 	}
 
-	channelID := incident.ChannelID
-	post := record.CreateSharingPost(channelID, p.botID, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL(), "")
-	if _, postErr := p.API.CreatePost(post); postErr != nil {
-		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
+	articleID, _ := postActionIntegrationRequest.Context[constants.ContextNameRecordID].(string)
+	if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), articleID); err != nil || !valid {
+		response.EphemeralText = constants.ErrorInvalidArticleID
+		p.returnPostActionIntegrationResponse(w, response)
+		return
 	}
 
-	p.writeJSON(w, statusCode, record)
-}
+	userID := r.Header.Get(constants.HeaderMattermostUserID)
+	channelID := postActionIntegrationRequest.ChannelId
+	if !p.API.HasPermissionToChannel(userID, channelID, model.PermissionCreatePost) {
+		response.EphemeralText = constants.ErrorInsufficientPermissions
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	user, userErr := p.API.GetUser(userID)
+	if userErr != nil {
+		p.API.LogError(constants.ErrorGetUser, "UserID", userID, "Error", userErr.Error())
+		response.EphemeralText = constants.ErrorGeneric
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	client := p.GetClientFromRequest(r)
+	article, _, err := client.GetRecordFromServiceNow(constants.RecordTypeKnowledge, articleID)
+	if err != nil {
+		p.API.LogError(constants.ErrorGetRecord, "SysID", articleID, "Error", err.Error())
+		response.EphemeralText = fmt.Sprintf("%s. Error: %s", constants.ErrorGetRecord, err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	if !article.IsPublished() {
+		response.EphemeralText = constants.ErrorKnowledgeArticleNotPublished
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	article.RecordType = constants.RecordTypeKnowledge
+	if err = article.HandleNestedFields(p.getConfiguration().ServiceNowBaseURL); err != nil {
+		p.API.LogError(constants.ErrorHandlingNestedFields, "Error", err.Error())
+		response.EphemeralText = fmt.Sprintf("%s. Error: %s", constants.ErrorHandlingNestedFields, err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	post := article.CreateKnowledgeArticlePost(channelID, p.botID, p.getConfiguration().ServiceNowBaseURL, user.Username)
+	if _, postErr := p.API.CreatePost(post); postErr != nil {
+		p.API.LogError(constants.ErrorPostKnowledgeArticle, "Error", postErr.Error())
+		response.EphemeralText = constants.ErrorPostKnowledgeArticle
+	}
+
+	p.returnPostActionIntegrationResponse(w, response)
+}
+
+// handleCreateWarRoom creates the war room for the incident shared in the post
+func (p *Plugin) handleCreateWarRoom(w http.ResponseWriter, r *http.Request) {
+	response := &model.PostActionIntegrationResponse{}
+	decoder := json.NewDecoder(r.Body)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := decoder.Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError("Error decoding PostActionIntegrationRequest params: ", err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	incidentID, _ := postActionIntegrationRequest.Context[constants.ContextNameRecordID].(string)
+	if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), incidentID); err != nil || !valid {
+		response.EphemeralText = constants.ErrorInvalidIncidentID
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	client := p.GetClientFromRequest(r)
+	incident, _, err := client.GetRecordFromServiceNow(constants.RecordTypeIncident, incidentID)
+	if err != nil {
+		p.API.LogError(constants.ErrorGetRecord, "SysID", incidentID, "Error", err.Error())
+		response.EphemeralText = fmt.Sprintf("%s. Error: %s", constants.ErrorGetRecord, err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	userID := r.Header.Get(constants.HeaderMattermostUserID)
+	response.EphemeralText = p.createWarRoomResponse(client, userID, postActionIntegrationRequest.TeamId, incident)
+	p.returnPostActionIntegrationResponse(w, response)
+}
+
+// handleOpenRejectDialog opens an interactive dialog for taking the comment of the approver while rejecting an approval
+func (p *Plugin) handleOpenRejectDialog(w http.ResponseWriter, r *http.Request) {
+	response := &model.PostActionIntegrationResponse{}
+	decoder := json.NewDecoder(r.Body)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := decoder.Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError("Error decoding PostActionIntegrationRequest params: ", err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	approvalID, _ := postActionIntegrationRequest.Context[constants.ContextNameApprovalID].(string)
+	if !serializer.IsValidApprovalID(approvalID) {
+		response.EphemeralText = constants.ErrorInvalidApprovalID
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	recordNumber, _ := postActionIntegrationRequest.Context[constants.ContextNameRecordNumber].(string)
+	dialog := model.OpenDialogRequest{
+		TriggerId: postActionIntegrationRequest.TriggerId,
+		URL:       fmt.Sprintf("%s%s", p.GetPluginURL(), constants.PathRejectRecord),
+		Dialog: model.Dialog{
+			CallbackId:       approvalID,
+			Title:            "Reject Approval",
+			IntroductionText: fmt.Sprintf("Rejecting the approval requested for **%s**", recordNumber),
+			SubmitLabel:      "Reject",
+			State:            postActionIntegrationRequest.PostId,
+			Elements: []model.DialogElement{
+				{
+					DisplayName: "Comment",
+					Name:        constants.DialogElementNameComments,
+					Type:        "textarea",
+					Placeholder: "Reason for rejecting the approval",
+				},
+			},
+		},
+	}
+
+	if appErr := p.API.OpenInteractiveDialog(dialog); appErr != nil {
+		p.API.LogError(constants.ErrorOpenRejectDialog, "Error", appErr.Error())
+		response.EphemeralText = constants.ErrorOpenRejectDialog
+	}
+
+	p.returnPostActionIntegrationResponse(w, response)
+}
+
+func (p *Plugin) handleRejectRecord(w http.ResponseWriter, r *http.Request) {
+	request := &model.SubmitDialogRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		p.API.LogError(constants.ErrorUnmarshallingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorUnmarshallingRequestBody, err.Error())})
+		return
+	}
+
+	if request.Cancelled {
+		returnStatusOK(w)
+		return
+	}
+
+	approvalID := request.CallbackId
+	if !serializer.IsValidApprovalID(approvalID) {
+		p.writeJSON(w, 0, &model.SubmitDialogResponse{Error: constants.ErrorInvalidApprovalID})
+		return
+	}
+
+	comments, _ := request.Submission[constants.DialogElementNameComments].(string)
+	payload := &serializer.ServiceNowApprovalPayload{State: constants.ApprovalStateRejected, Comments: comments}
+	if err := payload.Validate(); err != nil {
+		p.writeJSON(w, 0, &model.SubmitDialogResponse{Errors: map[string]string{constants.DialogElementNameComments: err.Error()}})
+		return
+	}
+
+	client := p.GetClientFromRequest(r)
+	if _, err := client.UpdateApproval(approvalID, payload); err != nil {
+		p.API.LogError(constants.ErrorUpdateApproval, "ApprovalID", approvalID, "Error", err.Error())
+		p.writeJSON(w, 0, &model.SubmitDialogResponse{Error: fmt.Sprintf("%s. Error: %s", constants.ErrorUpdateApproval, err.Error())})
+		return
+	}
+
+	post, appErr := p.API.GetPost(request.State)
+	if appErr != nil {
+		p.API.LogError(constants.ErrorGetPost, "PostID", request.State, "Error", appErr.Error())
+		returnStatusOK(w)
+		return
+	}
+
+	if serializer.UpdateApprovalPost(post, payload.State, payload.Comments) {
+		if _, appErr = p.API.UpdatePost(post); appErr != nil {
+			p.API.LogError(constants.ErrorUpdatePost, "PostID", post.Id, "Error", appErr.Error())
+		}
+	}
+
+	returnStatusOK(w)
+}
+
+func (p *Plugin) handleOpenCommentModal(w http.ResponseWriter, r *http.Request) {
+	response := &model.PostActionIntegrationResponse{}
+	decoder := json.NewDecoder(r.Body)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := decoder.Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError("Error decoding PostActionIntegrationRequest params: ", err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	p.API.PublishWebSocketEvent(
+		constants.WSEventOpenCommentModal,
+		postActionIntegrationRequest.Context,
+		&model.WebsocketBroadcast{UserId: postActionIntegrationRequest.UserId},
+	)
+
+	p.returnPostActionIntegrationResponse(w, response)
+}
+
+func (p *Plugin) handleOpenStateModal(w http.ResponseWriter, r *http.Request) {
+	response := &model.PostActionIntegrationResponse{}
+	decoder := json.NewDecoder(r.Body)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := decoder.Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError("Error decoding PostActionIntegrationRequest params: ", err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	p.API.PublishWebSocketEvent(
+		constants.WSEventOpenUpdateStateModal,
+		postActionIntegrationRequest.Context,
+		&model.WebsocketBroadcast{UserId: postActionIntegrationRequest.UserId},
+	)
+
+	p.returnPostActionIntegrationResponse(w, response)
+}
+
+func (p *Plugin) handleOpenSubscriptionModal(w http.ResponseWriter, r *http.Request) {
+	response := &model.PostActionIntegrationResponse{}
+	decoder := json.NewDecoder(r.Body)
+	postActionIntegrationRequest := &model.PostActionIntegrationRequest{}
+	if err := decoder.Decode(&postActionIntegrationRequest); err != nil {
+		p.API.LogError("Error decoding PostActionIntegrationRequest params: ", err.Error())
+		p.returnPostActionIntegrationResponse(w, response)
+		return
+	}
+
+	p.API.PublishWebSocketEvent(
+		constants.WSEventOpenAddSubscriptionModal,
+		postActionIntegrationRequest.Context,
+		&model.WebsocketBroadcast{UserId: postActionIntegrationRequest.UserId},
+	)
+
+	p.returnPostActionIntegrationResponse(w, response)
+}
+
+func (p *Plugin) handleGetUsers(w http.ResponseWriter, _ *http.Request) {
+	users, err := p.store.GetAllUsers()
+	if err != nil {
+		p.API.LogError(constants.ErrorGetUsers, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorGetUsers, err.Error())})
+		return
+	}
+
+	p.writeJSONArray(w, http.StatusOK, users)
+}
+
+func (p *Plugin) createIncident(w http.ResponseWriter, r *http.Request) {
+	incident, err := serializer.IncidentFromJSON(r.Body)
+	if err != nil {
+		p.API.LogError(constants.ErrorUnmarshallingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorUnmarshallingRequestBody, err.Error())})
+		return
+	}
+
+	if err = incident.IsValid(); err != nil {
+		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
+		return
+	}
+
+	userID := r.Header.Get(constants.HeaderMattermostUserID)
+	permissionStatusCode, permissionErr := p.HasChannelPermissions(userID, incident.ChannelID)
+	if permissionErr != nil {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: permissionStatusCode, Message: permissionErr.Error()})
+		return
+	}
+
+	client := p.GetClientFromRequest(r)
+	response, statusCode, err := client.CreateIncident(incident)
+	if err != nil {
+		p.API.LogError(constants.APIErrorCreateIncident, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.APIErrorCreateIncident, err.Error()))
+		return
+	}
+
+	record, err := p.ShareCreatedIncident(incident.ChannelID, response)
+	if err != nil {
+		p.API.LogError(constants.ErrorHandlingNestedFields, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorHandlingNestedFields, err.Error())})
+		return
+	}
+
+	go p.SuggestKnowledgeArticles(client, userID, incident.ChannelID, response)
+
+	p.writeJSON(w, statusCode, record)
+}
+
+func (p *Plugin) createRecordFromPost(w http.ResponseWriter, r *http.Request) {
+	payload, err := serializer.CreateRecordFromPostPayloadFromJSON(r.Body)
+	if err != nil {
+		p.API.LogError(constants.ErrorUnmarshallingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorUnmarshallingRequestBody, err.Error())})
+		return
+	}
+
+	if err = payload.IsValid(p.getConfiguration().RecordTypeRegistry); err != nil {
+		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
+		return
+	}
+
+	post, appErr := p.API.GetPost(payload.PostID)
+	if appErr != nil {
+		p.API.LogError(constants.ErrorGetPost, "PostID", payload.PostID, "Error", appErr.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: appErr.StatusCode, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorGetPost, appErr.Error())})
+		return
+	}
+
+	userID := r.Header.Get(constants.HeaderMattermostUserID)
+	permissionStatusCode, permissionErr := p.HasChannelPermissions(userID, post.ChannelId)
+	if permissionErr != nil {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: permissionStatusCode, Message: permissionErr.Error()})
+		return
+	}
+
+	shortDescription, description, err := p.GetRecordDetailsFromPost(post)
+	if err != nil {
+		p.API.LogError(constants.ErrorGetPostThread, "PostID", payload.PostID, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorGetPostThread, err.Error())})
+		return
+	}
+
+	if payload.ShortDescription != "" {
+		shortDescription = payload.ShortDescription
+	}
+
+	if payload.Description != "" {
+		description = payload.Description
+	}
+
+	if shortDescription == "" {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorEmptyMessage})
+		return
+	}
+
+	recordPayload := &serializer.ServiceNowRecordPayload{
+		ShortDescription: shortDescription,
+		Description:      description,
+	}
+
+	if payload.RecordType == constants.RecordTypeIncident {
+		if user := GetUserFromRequest(r); user != nil && user.ServiceNowUser != nil {
+			recordPayload.Caller = user.ServiceNowUser.UserID
+		}
+	}
+
+	client := p.GetClientFromRequest(r)
+	record, statusCode, err := client.CreateRecord(payload.RecordType, recordPayload)
+	if err != nil {
+		p.API.LogError(constants.APIErrorCreateRecord, "Record type", payload.RecordType, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.APIErrorCreateRecord, err.Error()))
+		return
+	}
+
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+
+	record.RecordType = payload.RecordType
+	if err = p.ShareCreatedRecord(post.ChannelId, rootID, record); err != nil {
+		p.API.LogError(constants.ErrorHandlingNestedFields, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusInternalServerError, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorHandlingNestedFields, err.Error())})
+		return
+	}
+
+	p.writeJSON(w, statusCode, record)
+}
 
 func (p *Plugin) searchCatalogItemsInServiceNow(w http.ResponseWriter, r *http.Request) {
 	searchTerm := r.URL.Query().Get(constants.QueryParamSearchTerm)
@@ -801,6 +1589,139 @@ func (p *Plugin) searchCatalogItemsInServiceNow(w http.ResponseWriter, r *http.R
 	p.writeJSONArray(w, statusCode, items)
 }
 
+func (p *Plugin) getCatalogItem(w http.ResponseWriter, r *http.Request) {
+	itemID := mux.Vars(r)[constants.PathParamItemID]
+	client := p.GetClientFromRequest(r)
+	item, statusCode, err := client.GetCatalogItem(itemID)
+	if err != nil {
+		p.API.LogError(constants.APIErrorGetCatalogItem, "ItemID", itemID, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.APIErrorGetCatalogItem, err.Error()))
+		return
+	}
+
+	p.writeJSON(w, statusCode, item.ToForm())
+}
+
+func (p *Plugin) orderCatalogItem(w http.ResponseWriter, r *http.Request) {
+	payload, err := serializer.CatalogItemOrderPayloadFromJSON(r.Body)
+	if err != nil {
+		p.API.LogError(constants.ErrorUnmarshallingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorUnmarshallingRequestBody, err.Error())})
+		return
+	}
+
+	if err = payload.IsValid(); err != nil {
+		p.API.LogError(constants.ErrorValidatingRequestBody, "Error", err.Error())
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
+		return
+	}
+
+	userID := r.Header.Get(constants.HeaderMattermostUserID)
+	permissionStatusCode, permissionErr := p.HasChannelPermissions(userID, payload.ChannelID)
+	if permissionErr != nil {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: permissionStatusCode, Message: permissionErr.Error()})
+		return
+	}
+
+	itemID := mux.Vars(r)[constants.PathParamItemID]
+	client := p.GetClientFromRequest(r)
+	item, statusCode, err := client.GetCatalogItem(itemID)
+	if err != nil {
+		p.API.LogError(constants.APIErrorGetCatalogItem, "ItemID", itemID, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.APIErrorGetCatalogItem, err.Error()))
+		return
+	}
+
+	if err = item.ToForm().ValidateVariables(payload.Variables); err != nil {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, err.Error())})
+		return
+	}
+
+	response, statusCode, err := client.OrderCatalogItem(itemID, payload.ToServiceNowRequest())
+	if err != nil {
+		p.API.LogError(constants.APIErrorOrderCatalogItem, "ItemID", itemID, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.APIErrorOrderCatalogItem, err.Error()))
+		return
+	}
+
+	order := &serializer.CatalogItemOrder{
+		RequestNumber: response.RequestNumber,
+		RequestID:     response.RequestID,
+	}
+
+	// The order has already been placed, so the post is created even if the requested items can't be fetched
+	requestedItems, _, err := client.GetRequestedItems(response.RequestID)
+	if err != nil {
+		p.API.LogError(constants.ErrorGetRequestedItems, "RequestID", response.RequestID, "Error", err.Error())
+	} else {
+		order.RequestedItems = requestedItems
+	}
+
+	username := userID
+	if user, userErr := p.API.GetUser(userID); userErr != nil {
+		p.API.LogError(constants.ErrorGetUser, "UserID", userID, "Error", userErr.Error())
+	} else {
+		username = user.Username
+	}
+
+	post := order.CreateOrderPost(payload.ChannelID, p.botID, item.Name, username, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL(), p.getConfiguration().RecordTypeRegistry)
+	if _, postErr := p.API.CreatePost(post); postErr != nil {
+		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
+	}
+
+	p.writeJSON(w, statusCode, order)
+}
+
+func (p *Plugin) getIncidentFieldChoices(w http.ResponseWriter, r *http.Request) {
+	field := mux.Vars(r)[constants.PathParamField]
+	if !constants.IncidentFieldsWithChoices[field] {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: constants.ErrorInvalidIncidentField})
+		return
+	}
+
+	dependentValue := r.URL.Query().Get(constants.QueryParamDependentValue)
+	client := p.GetClientFromRequest(r)
+	choices, statusCode, err := client.GetChoices(constants.RecordTypeIncident, field, dependentValue)
+	if err != nil {
+		p.API.LogError(constants.ErrorGetChoices, "Field", field, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", constants.ErrorGetChoices, err.Error()))
+		return
+	}
+
+	p.writeJSONArray(w, statusCode, choices)
+}
+
+func (p *Plugin) searchAssignmentGroups(w http.ResponseWriter, r *http.Request) {
+	p.searchReferenceRecords(w, r, constants.TableUserGroup, constants.ErrorSearchingAssignmentGroups)
+}
+
+func (p *Plugin) searchConfigurationItems(w http.ResponseWriter, r *http.Request) {
+	p.searchReferenceRecords(w, r, constants.TableConfigurationItem, constants.ErrorSearchingConfigurationItems)
+}
+
+func (p *Plugin) searchServiceNowUsers(w http.ResponseWriter, r *http.Request) {
+	p.searchReferenceRecords(w, r, constants.TableUser, constants.ErrorSearchingUsers)
+}
+
+func (p *Plugin) searchReferenceRecords(w http.ResponseWriter, r *http.Request, tableName, errorMessage string) {
+	searchTerm := r.URL.Query().Get(constants.QueryParamSearchTerm)
+	if len(searchTerm) < constants.CharacterThresholdForSearchingRecords {
+		p.handleAPIError(w, &serializer.APIErrorResponse{StatusCode: http.StatusBadRequest, Message: fmt.Sprintf(constants.ErrorSearchTermThreshold, constants.CharacterThresholdForSearchingRecords)})
+		return
+	}
+
+	page, perPage := GetPageAndPerPage(r)
+	client := p.GetClientFromRequest(r)
+	records, statusCode, err := client.SearchReferenceRecords(tableName, searchTerm, fmt.Sprint(perPage), fmt.Sprint(page*perPage))
+	if err != nil {
+		p.API.LogError(errorMessage, "Error", err.Error())
+		_ = p.handleClientError(w, r, err, false, statusCode, "", fmt.Sprintf("%s. Error: %s", errorMessage, err.Error()))
+		return
+	}
+
+	p.writeJSONArray(w, statusCode, records)
+}
+
 func returnStatusOK(w http.ResponseWriter) {
 	m := make(map[string]string)
 	w.Header().Set("Content-Type", "application/json")
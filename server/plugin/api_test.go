@@ -206,13 +206,21 @@ func TestGetUserChannelsForTeam(t *testing.T) {
 func TestAPISearchRecordsInServiceNow(t *testing.T) {
 	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathSearchRecords)
 	limit, offset := testutils.GetLimitAndOffset()
+	recordTypes := []*serializer.RecordType{
+		{Name: constants.RecordTypeIncident, Search: true},
+		{Name: constants.RecordTypeTask, Search: true},
+		{Name: constants.RecordTypeKnowledge, Search: true},
+		{Name: constants.RecordTypeProblem},
+	}
 	for name, test := range map[string]struct {
 		RecordType           string
 		SearchTerm           string
+		QueryParams          url.Values
 		SetupAPI             func(*plugintest.API)
 		SetupClient          func(client *mock_plugin.Client)
 		ExpectedStatusCode   int
 		ExpectedCount        int
+		ExpectedNumbers      []string
 		ExpectedErrorMessage string
 	}{
 		"success": {
@@ -220,13 +228,94 @@ func TestAPISearchRecordsInServiceNow(t *testing.T) {
 			SearchTerm: testutils.GetSearchTerm(true),
 			SetupAPI:   func(api *plugintest.API) {},
 			SetupClient: func(client *mock_plugin.Client) {
-				client.On("SearchRecordsInServiceNow", constants.RecordTypeIncident, testutils.GetSearchTerm(true), limit, offset).Return(
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeIncident, testutils.GetSearchTerm(true), limit, offset, &serializer.RecordSearchFilters{}).Return(
 					testutils.GetServiceNowPartialRecords(3), http.StatusOK, nil,
 				)
 			},
 			ExpectedStatusCode: http.StatusOK,
 			ExpectedCount:      3,
 		},
+		"success with filters": {
+			RecordType: constants.RecordTypeIncident,
+			SearchTerm: testutils.GetSearchTerm(true),
+			QueryParams: url.Values{
+				constants.QueryParamActive:       {"true"},
+				constants.QueryParamAssignedToMe: {"true"},
+				constants.QueryParamState:        {"2"},
+			},
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeIncident, testutils.GetSearchTerm(true), limit, offset, &serializer.RecordSearchFilters{ActiveOnly: true, AssignedToMe: true, State: "2"}).Return(
+					testutils.GetServiceNowPartialRecords(2), http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      2,
+		},
+		"global search": {
+			RecordType: constants.RecordTypeAll,
+			SearchTerm: "inc0001",
+			SetupAPI:   func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeIncident, "inc0001", limit, "0", &serializer.RecordSearchFilters{}).Return(
+					[]*serializer.ServiceNowPartialRecord{
+						{SysID: "incident1", Number: "INC0001234", ShortDescription: "Printer is not working", UpdatedOn: "2026-01-01 10:00:00"},
+						{SysID: "incident2", Number: "INC0001", ShortDescription: "Network is down", UpdatedOn: "2025-01-01 10:00:00"},
+					}, http.StatusOK, nil,
+				)
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeTask, "inc0001", limit, "0", &serializer.RecordSearchFilters{}).Return(
+					[]*serializer.ServiceNowPartialRecord{
+						{SysID: "incident1", Number: "INC0001234", ShortDescription: "Printer is not working", UpdatedOn: "2026-01-01 10:00:00"},
+					}, http.StatusOK, nil,
+				)
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeKnowledge, "inc0001", limit, "0", &serializer.RecordSearchFilters{}).Return(
+					[]*serializer.ServiceNowPartialRecord{
+						{SysID: "article1", Number: "KB0000001", ShortDescription: "Steps for resolving INC0001", UpdatedOn: "2026-02-01 10:00:00"},
+					}, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      3,
+			ExpectedNumbers:    []string{"INC0001", "INC0001234", "KB0000001"},
+		},
+		"global search with filters skips the record types not supporting them": {
+			RecordType: constants.RecordTypeAll,
+			SearchTerm: testutils.GetSearchTerm(true),
+			QueryParams: url.Values{
+				constants.QueryParamAssignedToMe: {"true"},
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeIncident, testutils.GetSearchTerm(true), limit, "0", &serializer.RecordSearchFilters{AssignedToMe: true}).Return(
+					testutils.GetServiceNowPartialRecords(1), http.StatusOK, nil,
+				)
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeTask, testutils.GetSearchTerm(true), limit, "0", &serializer.RecordSearchFilters{AssignedToMe: true}).Return(
+					nil, http.StatusForbidden, fmt.Errorf("new error"),
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      1,
+		},
+		"global search fails for all the record types": {
+			RecordType: constants.RecordTypeAll,
+			SearchTerm: testutils.GetSearchTerm(true),
+			QueryParams: url.Values{
+				constants.QueryParamState: {"2"},
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", mock.AnythingOfType("string"), testutils.GetSearchTerm(true), limit, "0", &serializer.RecordSearchFilters{State: "2"}).Return(
+					nil, http.StatusForbidden, fmt.Errorf("new error"),
+				)
+			},
+			ExpectedStatusCode: http.StatusForbidden,
+			ExpectedCount:      -1,
+		},
 		"invalid record type": {
 			RecordType: "testRecordType",
 			SetupAPI: func(api *plugintest.API) {
@@ -247,6 +336,42 @@ func TestAPISearchRecordsInServiceNow(t *testing.T) {
 			ExpectedCount:        -1,
 			ExpectedErrorMessage: fmt.Sprintf("The search term must be at least %d characters long.", constants.CharacterThresholdForSearchingRecords),
 		},
+		"invalid filter": {
+			RecordType: constants.RecordTypeIncident,
+			SearchTerm: testutils.GetSearchTerm(true),
+			QueryParams: url.Values{
+				constants.QueryParamActive: {"yes please"},
+			},
+			SetupAPI:             func(api *plugintest.API) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: fmt.Sprintf("Query param %s is not valid", constants.QueryParamActive),
+		},
+		"invalid state": {
+			RecordType: constants.RecordTypeIncident,
+			SearchTerm: testutils.GetSearchTerm(true),
+			QueryParams: url.Values{
+				constants.QueryParamState: {"2^active=false"},
+			},
+			SetupAPI:             func(api *plugintest.API) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: constants.ErrorInvalidSearchState,
+		},
+		"filter not supported by the record type": {
+			RecordType: constants.RecordTypeKnowledge,
+			SearchTerm: testutils.GetSearchTerm(true),
+			QueryParams: url.Values{
+				constants.QueryParamState: {"2"},
+			},
+			SetupAPI:             func(api *plugintest.API) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: constants.ErrorSearchFilterNotSupported,
+		},
 		"failed to get records": {
 			RecordType: constants.RecordTypeIncident,
 			SearchTerm: testutils.GetSearchTerm(true),
@@ -254,7 +379,7 @@ func TestAPISearchRecordsInServiceNow(t *testing.T) {
 				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
 			},
 			SetupClient: func(client *mock_plugin.Client) {
-				client.On("SearchRecordsInServiceNow", constants.RecordTypeIncident, testutils.GetSearchTerm(true), limit, offset).Return(
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeIncident, testutils.GetSearchTerm(true), limit, offset, &serializer.RecordSearchFilters{}).Return(
 					nil, http.StatusForbidden, fmt.Errorf("new error"),
 				)
 			},
@@ -267,6 +392,7 @@ func TestAPISearchRecordsInServiceNow(t *testing.T) {
 			defer monkey.UnpatchAll()
 
 			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			p.getConfiguration().RecordTypeRegistry = serializer.NewRecordTypeRegistry(recordTypes)
 			client := setupPluginForCheckOAuthMiddleware(p, t)
 			test.SetupClient(client)
 			test.SetupAPI(api)
@@ -276,6 +402,9 @@ func TestAPISearchRecordsInServiceNow(t *testing.T) {
 			queryParams := url.Values{
 				constants.QueryParamSearchTerm: {test.SearchTerm},
 			}
+			for key, values := range test.QueryParams {
+				queryParams[key] = values
+			}
 			r := httptest.NewRequest(http.MethodGet, strings.Replace(requestURL, "{record_type}", test.RecordType, 1), nil)
 			r.URL.RawQuery = queryParams.Encode()
 			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
@@ -288,11 +417,14 @@ func TestAPISearchRecordsInServiceNow(t *testing.T) {
 			assert.Equal(test.ExpectedStatusCode, result.StatusCode)
 
 			if test.ExpectedCount != -1 {
-				var channels []*model.Channel
-				err := json.NewDecoder(result.Body).Decode(&channels)
+				var records []*serializer.ServiceNowPartialRecord
+				err := json.NewDecoder(result.Body).Decode(&records)
 				require.Nil(t, err)
 
-				assert.Equal(test.ExpectedCount, len(channels))
+				assert.Equal(test.ExpectedCount, len(records))
+				for i, number := range test.ExpectedNumbers {
+					assert.Equal(number, records[i].Number)
+				}
 			}
 
 			if test.ExpectedErrorMessage != "" {
@@ -616,22 +748,52 @@ func TestShareRecordInChannel(t *testing.T) {
 func TestGetCommentsForRecord(t *testing.T) {
 	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathCommentsForRecord)
 	requestURL = strings.Replace(requestURL, "{record_id:[0-9a-f]{32}}", testutils.GetServiceNowSysID(), 1)
+	limit, offset := testutils.GetLimitAndOffset()
 	for name, test := range map[string]struct {
 		RecordType           string
 		SetupAPI             func(*plugintest.API)
 		SetupClient          func(client *mock_plugin.Client)
 		ExpectedStatusCode   int
+		ExpectedResponse     *serializer.CommentsResponse
 		ExpectedErrorMessage string
 	}{
 		"success": {
 			RecordType: constants.RecordTypeIncident,
 			SetupAPI:   func(api *plugintest.API) {},
 			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetJournalEntries", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), limit, offset).Return(
+					[]*serializer.ServiceNowJournalEntry{
+						{Element: constants.FieldWorkNotes, Value: "mockWorkNote", CreatedBy: "admin", CreatedOn: "2022-11-29 12:14:37"},
+						{Element: constants.FieldComments, Value: "mockComment", CreatedBy: "abel.tuter", CreatedOn: "2022-11-29 12:10:00"},
+					}, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedResponse: &serializer.CommentsResponse{
+				Entries: []*serializer.CommentEntry{
+					{Author: "admin", CreatedOn: "2022-11-29 12:14:37", Type: constants.CommentEntryTypeWorkNote, Value: "mockWorkNote"},
+					{Author: "abel.tuter", CreatedOn: "2022-11-29 12:10:00", Type: constants.CommentEntryTypeComment, Value: "mockComment"},
+				},
+			},
+		},
+		"fallback to the comments and work notes field": {
+			RecordType: constants.RecordTypeIncident,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogWarn", testutils.GetMockArgumentsWithType("string", 5)...)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetJournalEntries", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), limit, offset).Return(
+					nil, http.StatusForbidden, fmt.Errorf("acl error"),
+				)
 				client.On("GetAllComments", constants.RecordTypeIncident, testutils.GetServiceNowSysID()).Return(
 					testutils.GetServiceNowComments(), http.StatusOK, nil,
 				)
 			},
 			ExpectedStatusCode: http.StatusOK,
+			ExpectedResponse: &serializer.CommentsResponse{
+				Entries:              []*serializer.CommentEntry{},
+				CommentsAndWorkNotes: testutils.GetServiceNowComments().CommentsAndWorkNotes,
+			},
 		},
 		"invalid record type": {
 			RecordType: "testRecordType",
@@ -645,9 +807,13 @@ func TestGetCommentsForRecord(t *testing.T) {
 		"failed to get comments": {
 			RecordType: constants.RecordTypeIncident,
 			SetupAPI: func(api *plugintest.API) {
+				api.On("LogWarn", testutils.GetMockArgumentsWithType("string", 5)...)
 				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...)
 			},
 			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetJournalEntries", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), limit, offset).Return(
+					nil, http.StatusInternalServerError, fmt.Errorf("new error"),
+				)
 				client.On("GetAllComments", constants.RecordTypeIncident, testutils.GetServiceNowSysID()).Return(
 					nil, http.StatusInternalServerError, fmt.Errorf("new error"),
 				)
@@ -661,8 +827,8 @@ func TestGetCommentsForRecord(t *testing.T) {
 				api.On("LogError", mock.AnythingOfType("string"), "Error", "marshal error")
 			},
 			SetupClient: func(client *mock_plugin.Client) {
-				client.On("GetAllComments", constants.RecordTypeIncident, testutils.GetServiceNowSysID()).Return(
-					testutils.GetServiceNowComments(), http.StatusOK, nil,
+				client.On("GetJournalEntries", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), limit, offset).Return(
+					[]*serializer.ServiceNowJournalEntry{}, http.StatusOK, nil,
 				)
 
 				monkey.Patch(json.Marshal, func(_ interface{}) ([]byte, error) {
@@ -692,6 +858,14 @@ func TestGetCommentsForRecord(t *testing.T) {
 			defer result.Body.Close()
 
 			assert.Equal(test.ExpectedStatusCode, result.StatusCode)
+			if test.ExpectedResponse != nil {
+				var resp *serializer.CommentsResponse
+				err := json.NewDecoder(result.Body).Decode(&resp)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedResponse, resp)
+			}
+
 			if test.ExpectedErrorMessage != "" {
 				var resp *serializer.APIErrorResponse
 				err := json.NewDecoder(result.Body).Decode(&resp)
@@ -708,6 +882,7 @@ func TestAddCommentsOnRecord(t *testing.T) {
 	requestURL = strings.Replace(requestURL, "{record_id:[0-9a-f]{32}}", testutils.GetServiceNowSysID(), 1)
 	for name, test := range map[string]struct {
 		RecordType           string
+		RecordTypes          []*serializer.RecordType
 		RequestBody          string
 		SetupAPI             func(*plugintest.API)
 		SetupClient          func(client *mock_plugin.Client)
@@ -727,6 +902,59 @@ func TestAddCommentsOnRecord(t *testing.T) {
 			},
 			ExpectedStatusCode: http.StatusOK,
 		},
+		"success with work note": {
+			RecordType: constants.RecordTypeIncident,
+			RequestBody: `{
+				"work_notes": "mockWorkNote"
+			}`,
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("AddComment", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), &serializer.ServiceNowCommentPayload{WorkNotes: "mockWorkNote"}).Return(
+					http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"empty comment": {
+			RecordType: constants.RecordTypeIncident,
+			RequestBody: `{
+				"comments": "  "
+			}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorEmptyComment,
+		},
+		"comment and work note together": {
+			RecordType: constants.RecordTypeIncident,
+			RequestBody: `{
+				"comments": "mockComment",
+				"work_notes": "mockWorkNote"
+			}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorCommentAndWorkNotes,
+		},
+		"work notes not supported": {
+			RecordType: "u_custom_table",
+			RecordTypes: []*serializer.RecordType{
+				{Name: "u_custom_table", DisplayName: "Custom Table", Search: true, Comment: true},
+			},
+			RequestBody: `{
+				"work_notes": "mockWorkNote"
+			}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", constants.ErrorWorkNotesNotSupported, "Record type", "u_custom_table").Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorWorkNotesNotSupported,
+		},
 		"invalid record type": {
 			RecordType: "testRecordType",
 			SetupAPI: func(api *plugintest.API) {
@@ -767,6 +995,9 @@ func TestAddCommentsOnRecord(t *testing.T) {
 			defer monkey.UnpatchAll()
 
 			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			if test.RecordTypes != nil {
+				p.getConfiguration().RecordTypeRegistry = serializer.NewRecordTypeRegistry(test.RecordTypes)
+			}
 			client := setupPluginForCheckOAuthMiddleware(p, t)
 			test.SetupClient(client)
 			test.SetupAPI(api)
@@ -797,6 +1028,7 @@ func TestGetStatesForRecordType(t *testing.T) {
 	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathGetStatesForRecordType)
 	for name, test := range map[string]struct {
 		RecordType           string
+		RecordID             string
 		SetupAPI             func(*plugintest.API)
 		SetupClient          func(client *mock_plugin.Client)
 		ExpectedStatusCode   int
@@ -814,6 +1046,100 @@ func TestGetStatesForRecordType(t *testing.T) {
 			ExpectedStatusCode: http.StatusOK,
 			ExpectedCount:      3,
 		},
+		"success with required fields": {
+			RecordType: constants.RecordTypeIncident,
+			SetupAPI:   func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetStatesFromServiceNow", constants.RecordTypeIncident).Return(
+					[]*serializer.ServiceNowState{
+						{Label: "New", Value: "1"},
+						{Label: "On Hold", Value: constants.IncidentStateOnHold},
+						{Label: "Resolved", Value: constants.IncidentStateResolved},
+						{Label: "Closed", Value: constants.IncidentStateClosed},
+					}, http.StatusOK, nil,
+				)
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldHoldReason, "").Return(
+					[]*serializer.ServiceNowChoice{{Label: "Awaiting Caller", Value: "1"}}, http.StatusOK, nil,
+				).Once()
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldCloseCode, "").Return(
+					[]*serializer.ServiceNowChoice{{Label: "Solved (Permanently)", Value: "Solved (Permanently)"}}, http.StatusOK, nil,
+				).Once()
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      4,
+		},
+		"failed to get the choices of the required fields": {
+			RecordType: constants.RecordTypeIncident,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetStatesFromServiceNow", constants.RecordTypeIncident).Return(
+					[]*serializer.ServiceNowState{{Label: "Resolved", Value: constants.IncidentStateResolved}}, http.StatusOK, nil,
+				)
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldCloseCode, "").Return(
+					nil, http.StatusInternalServerError, fmt.Errorf("get choices error"),
+				)
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+			ExpectedCount:      -1,
+		},
+		"success for a record type following a state model": {
+			RecordType: constants.RecordTypeChangeRequest,
+			RecordID:   testutils.GetServiceNowSysID(),
+			SetupAPI:   func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetStatesFromServiceNow", constants.RecordTypeChangeRequest).Return(
+					[]*serializer.ServiceNowState{
+						{Label: "New", Value: constants.ChangeStateNew},
+						{Label: "Assess", Value: constants.ChangeStateAssess},
+						{Label: "Authorize", Value: constants.ChangeStateAuthorize},
+						{Label: "Scheduled", Value: constants.ChangeStateScheduled},
+						{Label: "Implement", Value: constants.ChangeStateImplement},
+						{Label: "Canceled", Value: constants.ChangeStateCanceled},
+					}, http.StatusOK, nil,
+				)
+				client.On("GetRecordState", constants.RecordTypeChangeRequest, testutils.GetServiceNowSysID()).Return(
+					constants.ChangeStateAssess, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      3,
+		},
+		"missing record ID for a record type following a state model": {
+			RecordType:           constants.RecordTypeProblem,
+			SetupAPI:             func(api *plugintest.API) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: constants.ErrorInvalidQueryParam,
+		},
+		"invalid record ID for a record type following a state model": {
+			RecordType:           constants.RecordTypeProblem,
+			RecordID:             testutils.GetServiceNowSysID() + "0",
+			SetupAPI:             func(api *plugintest.API) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: constants.ErrorInvalidQueryParam,
+		},
+		"failed to get the current state of the record": {
+			RecordType: constants.RecordTypeProblem,
+			RecordID:   testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetStatesFromServiceNow", constants.RecordTypeProblem).Return(
+					testutils.GetServiceNowStates(3), http.StatusOK, nil,
+				)
+				client.On("GetRecordState", constants.RecordTypeProblem, testutils.GetServiceNowSysID()).Return(
+					"", http.StatusInternalServerError, fmt.Errorf("get record state error"),
+				)
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+			ExpectedCount:      -1,
+		},
 		"invalid record type": {
 			RecordType: "testRecordType",
 			SetupAPI: func(api *plugintest.API) {
@@ -878,7 +1204,12 @@ func TestGetStatesForRecordType(t *testing.T) {
 			defer api.AssertExpectations(t)
 
 			w := httptest.NewRecorder()
-			r := httptest.NewRequest(http.MethodGet, strings.Replace(requestURL, "{record_type}", test.RecordType, 1), nil)
+			url := strings.Replace(requestURL, "{record_type}", test.RecordType, 1)
+			if test.RecordID != "" {
+				url = fmt.Sprintf("%s?%s=%s", url, constants.QueryParamRecordID, test.RecordID)
+			}
+
+			r := httptest.NewRequest(http.MethodGet, url, nil)
 			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
 			p.ServeHTTP(nil, w, r)
 
@@ -931,6 +1262,110 @@ func TestUpdateStateOfRecord(t *testing.T) {
 			},
 			ExpectedStatusCode: http.StatusOK,
 		},
+		"success with required fields": {
+			RecordType: constants.RecordTypeIncident,
+			RequestBody: `{
+				"state": "6",
+				"close_code": "Solved (Permanently)",
+				"close_notes": "mockNotes"
+			}`,
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldCloseCode, "").Return(
+					[]*serializer.ServiceNowChoice{{Label: "Solved (Permanently)", Value: "Solved (Permanently)"}}, http.StatusOK, nil,
+				)
+				client.On("UpdateStateOfRecordInServiceNow", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), &serializer.ServiceNowUpdateStatePayload{
+					State:      constants.IncidentStateResolved,
+					CloseCode:  "Solved (Permanently)",
+					CloseNotes: "mockNotes",
+				}).Return(http.StatusOK, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"missing required field": {
+			RecordType: constants.RecordTypeIncident,
+			RequestBody: `{
+				"state": "6",
+				"close_code": "Solved (Permanently)"
+			}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldCloseCode, "").Return(
+					[]*serializer.ServiceNowChoice{{Label: "Solved (Permanently)", Value: "Solved (Permanently)"}}, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: fmt.Sprintf(constants.ErrorMissingRequiredField, constants.StateFieldLabels[constants.FieldCloseNotes]),
+		},
+		"invalid choice for required field": {
+			RecordType: constants.RecordTypeIncident,
+			RequestBody: `{
+				"state": "3",
+				"hold_reason": "mockReason"
+			}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldHoldReason, "").Return(
+					[]*serializer.ServiceNowChoice{{Label: "Awaiting Caller", Value: "1"}}, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: fmt.Sprintf(constants.ErrorInvalidChoice, constants.StateFieldLabels[constants.FieldHoldReason]),
+		},
+		"success when the required field has no choices": {
+			RecordType: constants.RecordTypeIncident,
+			RequestBody: `{
+				"state": "3",
+				"hold_reason": "mockReason"
+			}`,
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldHoldReason, "").Return(
+					[]*serializer.ServiceNowChoice{}, http.StatusOK, nil,
+				)
+				client.On("UpdateStateOfRecordInServiceNow", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), &serializer.ServiceNowUpdateStatePayload{
+					State:      "3",
+					HoldReason: "mockReason",
+				}).Return(http.StatusOK, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"success for a valid state transition": {
+			RecordType: constants.RecordTypeChangeRequest,
+			RequestBody: `{
+				"state": "-4"
+			}`,
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordState", constants.RecordTypeChangeRequest, testutils.GetServiceNowSysID()).Return(
+					constants.ChangeStateNew, http.StatusOK, nil,
+				)
+				client.On("UpdateStateOfRecordInServiceNow", constants.RecordTypeChangeRequest, testutils.GetServiceNowSysID(), &serializer.ServiceNowUpdateStatePayload{State: constants.ChangeStateAssess}).Return(
+					http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"invalid state transition": {
+			RecordType: constants.RecordTypeChangeRequest,
+			RequestBody: `{
+				"state": "-1"
+			}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 7)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordState", constants.RecordTypeChangeRequest, testutils.GetServiceNowSysID()).Return(
+					constants.ChangeStateNew, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorInvalidStateTransition,
+		},
 		"invalid record type": {
 			RecordType: "testRecordType",
 			SetupAPI: func(api *plugintest.API) {
@@ -1013,12 +1448,16 @@ func TestHandleNotification(t *testing.T) {
 	for name, test := range map[string]struct {
 		RequestBody        string
 		SetupAPI           func(*plugintest.API)
+		SetupStore         func(*mock_plugin.Store)
 		ExpectedStatusCode int
 	}{
 		"success": {
-			RequestBody: "{}",
-			SetupAPI: func(api *plugintest.API) {
-				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, nil)
+			RequestBody: `{"number": "INC0000001"}`,
+			SetupAPI:    func(api *plugintest.API) {},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("EnqueueNotification", mock.MatchedBy(func(n *serializer.QueuedNotification) bool {
+					return n.ID != "" && n.Event.Number == "INC0000001" && n.Attempts == 0
+				})).Return(nil)
 			},
 			ExpectedStatusCode: http.StatusOK,
 		},
@@ -1027,21 +1466,26 @@ func TestHandleNotification(t *testing.T) {
 			SetupAPI: func(api *plugintest.API) {
 				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
 			},
+			SetupStore:         func(s *mock_plugin.Store) {},
 			ExpectedStatusCode: http.StatusBadRequest,
 		},
-		"failed to create post": {
+		"failed to queue the notification": {
 			RequestBody: "{}",
 			SetupAPI: func(api *plugintest.API) {
-				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, testutils.GetBadRequestAppError())
 				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
 			},
-			ExpectedStatusCode: http.StatusOK,
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("EnqueueNotification", mock.AnythingOfType("*serializer.QueuedNotification")).Return(errors.New("kv error"))
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
 		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			assert := assert.New(t)
 
-			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
 			test.SetupAPI(api)
 			defer api.AssertExpectations(t)
 
@@ -1068,6 +1512,7 @@ func TestCreateSubscription(t *testing.T) {
 		RequestBody          string
 		SetupAPI             func(*plugintest.API)
 		SetupClient          func(client *mock_plugin.Client)
+		SetupStore           func(store *mock_plugin.Store)
 		SetupPlugin          func(p *Plugin)
 		ExpectedStatusCode   int
 		ExpectedErrorMessage string
@@ -1078,8 +1523,8 @@ func TestCreateSubscription(t *testing.T) {
 				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, nil)
 			},
 			SetupClient: func(client *mock_plugin.Client) {
-				client.On("CheckForDuplicateSubscription", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
-					false, http.StatusOK, nil,
+				client.On("GetDuplicateSubscriptions", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
+					nil, http.StatusOK, nil,
 				)
 
 				client.On("CreateSubscription", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
@@ -1088,7 +1533,7 @@ func TestCreateSubscription(t *testing.T) {
 			},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1116,7 +1561,7 @@ func TestCreateSubscription(t *testing.T) {
 			SetupClient: func(client *mock_plugin.Client) {},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return fmt.Errorf("new error")
 				})
 			},
@@ -1134,7 +1579,7 @@ func TestCreateSubscription(t *testing.T) {
 			SetupClient: func(client *mock_plugin.Client) {},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1151,7 +1596,7 @@ func TestCreateSubscription(t *testing.T) {
 			SetupClient: func(client *mock_plugin.Client) {},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1168,7 +1613,7 @@ func TestCreateSubscription(t *testing.T) {
 			SetupClient: func(client *mock_plugin.Client) {},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1179,19 +1624,29 @@ func TestCreateSubscription(t *testing.T) {
 			ExpectedStatusCode:   http.StatusBadRequest,
 			ExpectedErrorMessage: constants.ErrorInsufficientPermissions,
 		},
+		"assignment group filter with a query appended": {
+			RequestBody: fmt.Sprintf(`{"user_id": "%s", "channel_id": "%s", "type": "%s", "filters": {"assignment_group": "%s^ORactive=true"}}`, testutils.GetID(), testutils.GetChannelID(), constants.SubscriptionTypeBulk, testutils.GetServiceNowSysID()),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			SetupPlugin:          func(p *Plugin) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: "assignment group filter is not valid",
+		},
 		"failed to check duplicate subscription": {
 			RequestBody: testutils.GetTestUserAndChannelRequestBody(),
 			SetupAPI: func(api *plugintest.API) {
 				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
 			},
 			SetupClient: func(client *mock_plugin.Client) {
-				client.On("CheckForDuplicateSubscription", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
-					false, http.StatusForbidden, fmt.Errorf("duplicate subscription error"),
+				client.On("GetDuplicateSubscriptions", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
+					nil, http.StatusForbidden, fmt.Errorf("duplicate subscription error"),
 				)
 			},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1206,13 +1661,16 @@ func TestCreateSubscription(t *testing.T) {
 			RequestBody: testutils.GetTestUserAndChannelRequestBody(),
 			SetupAPI:    func(api *plugintest.API) {},
 			SetupClient: func(client *mock_plugin.Client) {
-				client.On("CheckForDuplicateSubscription", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
-					true, http.StatusOK, nil,
+				client.On("GetDuplicateSubscriptions", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
+					[]*serializer.SubscriptionResponse{testutils.GetSubscription(constants.SubscriptionTypeRecord)}, http.StatusOK, nil,
 				)
 			},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+			},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1229,8 +1687,8 @@ func TestCreateSubscription(t *testing.T) {
 				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
 			},
 			SetupClient: func(client *mock_plugin.Client) {
-				client.On("CheckForDuplicateSubscription", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
-					false, http.StatusOK, nil,
+				client.On("GetDuplicateSubscriptions", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
+					nil, http.StatusOK, nil,
 				)
 
 				client.On("CreateSubscription", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
@@ -1239,7 +1697,7 @@ func TestCreateSubscription(t *testing.T) {
 			},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1257,8 +1715,8 @@ func TestCreateSubscription(t *testing.T) {
 				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, testutils.GetInternalServerAppError())
 			},
 			SetupClient: func(client *mock_plugin.Client) {
-				client.On("CheckForDuplicateSubscription", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
-					false, http.StatusOK, nil,
+				client.On("GetDuplicateSubscriptions", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
+					nil, http.StatusOK, nil,
 				)
 
 				client.On("CreateSubscription", mock.AnythingOfType("*serializer.SubscriptionPayload")).Return(
@@ -1267,7 +1725,7 @@ func TestCreateSubscription(t *testing.T) {
 			},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForCreation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1282,7 +1740,12 @@ func TestCreateSubscription(t *testing.T) {
 			assert := assert.New(t)
 			defer monkey.UnpatchAll()
 
-			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			store := mock_plugin.NewStore(t)
+			if test.SetupStore != nil {
+				test.SetupStore(store)
+			}
+
+			p, api := setupTestPlugin(&plugintest.API{}, store)
 			client := setupPluginForSubscriptionsConfiguredMiddleware(p, t)
 			test.SetupClient(client)
 			test.SetupAPI(api)
@@ -1319,6 +1782,7 @@ func TestGetAllSubscriptions(t *testing.T) {
 		SubscriptionType     string
 		SetupAPI             func(*plugintest.API)
 		SetupClient          func(client *mock_plugin.Client)
+		SetupStore           func(store *mock_plugin.Store)
 		SetupPlugin          func(p *Plugin)
 		ExpectedStatusCode   int
 		ExpectedErrorMessage string
@@ -1335,6 +1799,10 @@ func TestGetAllSubscriptions(t *testing.T) {
 					testutils.GetServiceNowRecord(), http.StatusOK, nil,
 				)
 			},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadSubscriptionFilters", mock.AnythingOfType("string")).Return(nil, ErrNotFound)
+				store.On("LoadSubscriptionDeliveryMode", mock.AnythingOfType("string")).Return("", ErrNotFound)
+			},
 			SetupPlugin: func(p *Plugin) {
 				monkey.PatchInstanceMethod(reflect.TypeOf(p), "HasPublicOrPrivateChannelPermissions", func(_ *Plugin, _, _ string) (int, error) {
 					return http.StatusOK, nil
@@ -1349,6 +1817,7 @@ func TestGetAllSubscriptions(t *testing.T) {
 				api.On("LogError", mock.AnythingOfType("string"), "Query param", constants.QueryParamChannelID).Return()
 			},
 			SetupClient:          func(client *mock_plugin.Client) {},
+			SetupStore:           func(store *mock_plugin.Store) {},
 			SetupPlugin:          func(p *Plugin) {},
 			ExpectedStatusCode:   http.StatusBadRequest,
 			ExpectedCount:        -1,
@@ -1360,6 +1829,7 @@ func TestGetAllSubscriptions(t *testing.T) {
 				api.On("LogError", mock.AnythingOfType("string"), "Query param", constants.QueryParamUserID).Return()
 			},
 			SetupClient:          func(client *mock_plugin.Client) {},
+			SetupStore:           func(store *mock_plugin.Store) {},
 			SetupPlugin:          func(p *Plugin) {},
 			ExpectedStatusCode:   http.StatusBadRequest,
 			ExpectedCount:        -1,
@@ -1371,6 +1841,7 @@ func TestGetAllSubscriptions(t *testing.T) {
 				api.On("LogError", mock.AnythingOfType("string"), "Query param", constants.QueryParamSubscriptionType).Return()
 			},
 			SetupClient:          func(client *mock_plugin.Client) {},
+			SetupStore:           func(store *mock_plugin.Store) {},
 			SetupPlugin:          func(p *Plugin) {},
 			ExpectedStatusCode:   http.StatusBadRequest,
 			ExpectedCount:        -1,
@@ -1385,6 +1856,7 @@ func TestGetAllSubscriptions(t *testing.T) {
 					nil, http.StatusForbidden, fmt.Errorf("get subscriptions error"),
 				)
 			},
+			SetupStore:           func(store *mock_plugin.Store) {},
 			SetupPlugin:          func(p *Plugin) {},
 			ExpectedStatusCode:   http.StatusForbidden,
 			ExpectedCount:        -1,
@@ -1397,6 +1869,7 @@ func TestGetAllSubscriptions(t *testing.T) {
 					testutils.GetSubscriptions(4), http.StatusOK, nil,
 				)
 			},
+			SetupStore: func(store *mock_plugin.Store) {},
 			SetupPlugin: func(p *Plugin) {
 				monkey.PatchInstanceMethod(reflect.TypeOf(p), "HasPublicOrPrivateChannelPermissions", func(_ *Plugin, _, _ string) (int, error) {
 					return http.StatusInternalServerError, fmt.Errorf(constants.ErrorChannelPermissionsForUser)
@@ -1412,6 +1885,7 @@ func TestGetAllSubscriptions(t *testing.T) {
 					testutils.GetSubscriptions(4), http.StatusOK, nil,
 				)
 			},
+			SetupStore: func(store *mock_plugin.Store) {},
 			SetupPlugin: func(p *Plugin) {
 				monkey.PatchInstanceMethod(reflect.TypeOf(p), "HasPublicOrPrivateChannelPermissions", func(_ *Plugin, _, _ string) (int, error) {
 					return http.StatusBadRequest, fmt.Errorf(constants.ErrorInsufficientPermissions)
@@ -1425,7 +1899,9 @@ func TestGetAllSubscriptions(t *testing.T) {
 			assert := assert.New(t)
 			defer monkey.UnpatchAll()
 
-			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
 			client := setupPluginForSubscriptionsConfiguredMiddleware(p, t)
 			test.SetupClient(client)
 			test.SetupAPI(api)
@@ -1473,6 +1949,7 @@ func TestDeleteSubscription(t *testing.T) {
 	for name, test := range map[string]struct {
 		SetupAPI             func(*plugintest.API)
 		SetupClient          func(client *mock_plugin.Client)
+		SetupStore           func(*mock_plugin.Store)
 		ExpectedStatusCode   int
 		ExpectedErrorMessage string
 	}{
@@ -1483,6 +1960,33 @@ func TestDeleteSubscription(t *testing.T) {
 					http.StatusOK, nil,
 				)
 			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("DeleteSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("DeleteSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("LoadNotificationDigests").Return([]*serializer.NotificationDigest{
+					{ID: "digest1", SubscriptionID: testutils.GetServiceNowSysID()},
+					{ID: "digest2", SubscriptionID: "otherSubscriptionID"},
+				}, nil)
+				s.On("DeleteNotificationDigest", "digest1").Return(nil)
+				s.On("DeleteCachedSubscription", testutils.GetServiceNowSysID()).Return(nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"failed to delete subscription filters": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogWarn", mock.AnythingOfType("string"), "SubscriptionID", testutils.GetServiceNowSysID(), "Error", "delete filters error").Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("DeleteSubscription", testutils.GetServiceNowSysID()).Return(
+					http.StatusOK, nil,
+				)
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("DeleteSubscriptionFilters", testutils.GetServiceNowSysID()).Return(fmt.Errorf("delete filters error"))
+				s.On("DeleteSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("LoadNotificationDigests").Return([]*serializer.NotificationDigest{}, nil)
+				s.On("DeleteCachedSubscription", testutils.GetServiceNowSysID()).Return(nil)
+			},
 			ExpectedStatusCode: http.StatusOK,
 		},
 		"failed to delete subscription": {
@@ -1494,6 +1998,7 @@ func TestDeleteSubscription(t *testing.T) {
 					http.StatusBadRequest, fmt.Errorf("delete subscription error"),
 				)
 			},
+			SetupStore:           func(s *mock_plugin.Store) {},
 			ExpectedStatusCode:   http.StatusBadRequest,
 			ExpectedErrorMessage: "delete subscription error",
 		},
@@ -1502,7 +2007,9 @@ func TestDeleteSubscription(t *testing.T) {
 			assert := assert.New(t)
 			defer monkey.UnpatchAll()
 
-			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
 			client := setupPluginForSubscriptionsConfiguredMiddleware(p, t)
 			test.SetupClient(client)
 			test.SetupAPI(api)
@@ -1536,6 +2043,7 @@ func TestEditSubscription(t *testing.T) {
 		SetupAPI             func(*plugintest.API)
 		SetupClient          func(client *mock_plugin.Client)
 		SetupPlugin          func(p *Plugin)
+		SetupStore           func(s *mock_plugin.Store)
 		ExpectedStatusCode   int
 		ExpectedErrorMessage string
 	}{
@@ -1551,7 +2059,7 @@ func TestEditSubscription(t *testing.T) {
 			},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1559,6 +2067,11 @@ func TestEditSubscription(t *testing.T) {
 					return http.StatusOK, nil
 				})
 			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("DeleteSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("DeleteSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("DeleteCachedSubscription", testutils.GetServiceNowSysID()).Return(nil)
+			},
 			ExpectedStatusCode: http.StatusOK,
 		},
 		"invalid request body": {
@@ -1568,6 +2081,7 @@ func TestEditSubscription(t *testing.T) {
 			},
 			SetupClient:          func(client *mock_plugin.Client) {},
 			SetupPlugin:          func(p *Plugin) {},
+			SetupStore:           func(s *mock_plugin.Store) {},
 			ExpectedErrorMessage: constants.ErrorUnmarshallingRequestBody,
 			ExpectedStatusCode:   http.StatusBadRequest,
 		},
@@ -1579,10 +2093,11 @@ func TestEditSubscription(t *testing.T) {
 			SetupClient: func(client *mock_plugin.Client) {},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return fmt.Errorf("new error")
 				})
 			},
+			SetupStore:           func(s *mock_plugin.Store) {},
 			ExpectedStatusCode:   http.StatusBadRequest,
 			ExpectedErrorMessage: "new error",
 		},
@@ -1592,7 +2107,7 @@ func TestEditSubscription(t *testing.T) {
 			SetupClient: func(client *mock_plugin.Client) {},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1600,6 +2115,7 @@ func TestEditSubscription(t *testing.T) {
 					return http.StatusInternalServerError, fmt.Errorf(constants.ErrorChannelPermissionsForUser)
 				})
 			},
+			SetupStore:           func(s *mock_plugin.Store) {},
 			ExpectedStatusCode:   http.StatusInternalServerError,
 			ExpectedErrorMessage: constants.ErrorChannelPermissionsForUser,
 		},
@@ -1609,7 +2125,7 @@ func TestEditSubscription(t *testing.T) {
 			SetupClient: func(client *mock_plugin.Client) {},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1617,6 +2133,7 @@ func TestEditSubscription(t *testing.T) {
 					return http.StatusBadRequest, fmt.Errorf(constants.ErrorInsufficientPermissions)
 				})
 			},
+			SetupStore:           func(s *mock_plugin.Store) {},
 			ExpectedStatusCode:   http.StatusBadRequest,
 			ExpectedErrorMessage: constants.ErrorInsufficientPermissions,
 		},
@@ -1632,7 +2149,7 @@ func TestEditSubscription(t *testing.T) {
 			},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1640,6 +2157,7 @@ func TestEditSubscription(t *testing.T) {
 					return http.StatusOK, nil
 				})
 			},
+			SetupStore:           func(s *mock_plugin.Store) {},
 			ExpectedStatusCode:   http.StatusForbidden,
 			ExpectedErrorMessage: "edit subscription error",
 		},
@@ -1656,7 +2174,7 @@ func TestEditSubscription(t *testing.T) {
 			},
 			SetupPlugin: func(p *Plugin) {
 				var s *serializer.SubscriptionPayload
-				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string) error {
+				monkey.PatchInstanceMethod(reflect.TypeOf(s), "IsValidForUpdation", func(_ *serializer.SubscriptionPayload, _ string, _ *serializer.RecordTypeRegistry) error {
 					return nil
 				})
 
@@ -1664,6 +2182,11 @@ func TestEditSubscription(t *testing.T) {
 					return http.StatusOK, nil
 				})
 			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("DeleteSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("DeleteSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("DeleteCachedSubscription", testutils.GetServiceNowSysID()).Return(nil)
+			},
 			ExpectedStatusCode: http.StatusOK,
 		},
 	} {
@@ -1671,11 +2194,13 @@ func TestEditSubscription(t *testing.T) {
 			assert := assert.New(t)
 			defer monkey.UnpatchAll()
 
-			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			store := mock_plugin.NewStore(t)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
 			client := setupPluginForSubscriptionsConfiguredMiddleware(p, t)
 			test.SetupClient(client)
 			test.SetupAPI(api)
 			test.SetupPlugin(p)
+			test.SetupStore(store)
 			defer api.AssertExpectations(t)
 
 			w := httptest.NewRecorder()
@@ -1891,7 +2416,7 @@ func TestCreateIncident(t *testing.T) {
 					return nil
 				})
 
-				monkey.PatchInstanceMethod(reflect.TypeOf(record), "CreateSharingPost", func(_ *serializer.ServiceNowRecord, _, _, _, _, _ string) *model.Post {
+				monkey.PatchInstanceMethod(reflect.TypeOf(record), "CreateSharingPost", func(_ *serializer.ServiceNowRecord, _, _, _, _, _ string, _ *serializer.RecordTypeRegistry) *model.Post {
 					return &model.Post{}
 				})
 			},
@@ -1918,6 +2443,44 @@ func TestCreateIncident(t *testing.T) {
 			SetupClient:        func(client *mock_plugin.Client) {},
 			ExpectedStatusCode: http.StatusBadRequest,
 		},
+		"invalid impact": {
+			RequestBody: `{"short_description": "mockShortDescription", "impact": "5"}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupPlugin:        func(p *Plugin) {},
+			SetupClient:        func(client *mock_plugin.Client) {},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"subcategory without category": {
+			RequestBody: `{"short_description": "mockShortDescription", "subcategory": "dns"}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupPlugin:        func(p *Plugin) {},
+			SetupClient:        func(client *mock_plugin.Client) {},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"assignment group with a query appended": {
+			RequestBody: fmt.Sprintf(`{"short_description": "mockShortDescription", "assignment_group": "%s^ORpriority=1"}`, testutils.GetServiceNowSysID()),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupPlugin:          func(p *Plugin) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorInvalidAssignmentGroup,
+		},
+		"configuration item with a query appended": {
+			RequestBody: fmt.Sprintf(`{"short_description": "mockShortDescription", "cmdb_ci": "%s^NQactive=true"}`, testutils.GetServiceNowSysID()),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupPlugin:          func(p *Plugin) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorInvalidConfigurationItem,
+		},
 		"does not have permission to access the channel": {
 			RequestBody: testutils.GetCreateIncidentPayload(),
 			SetupAPI: func(api *plugintest.API) {
@@ -1993,3 +2556,1103 @@ func TestCreateIncident(t *testing.T) {
 		})
 	}
 }
+
+func TestGetIncidentFieldChoices(t *testing.T) {
+	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathGetIncidentChoices)
+	for name, test := range map[string]struct {
+		Field                string
+		DependentValue       string
+		SetupAPI             func(*plugintest.API)
+		SetupClient          func(client *mock_plugin.Client)
+		ExpectedStatusCode   int
+		ExpectedCount        int
+		ExpectedErrorMessage string
+	}{
+		"success": {
+			Field:    constants.FieldUrgency,
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldUrgency, "").Return(
+					[]*serializer.ServiceNowChoice{{Label: "1 - High", Value: "1"}, {Label: "2 - Medium", Value: "2"}}, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      2,
+		},
+		"success with dependent value": {
+			Field:          constants.FieldSubcategory,
+			DependentValue: "network",
+			SetupAPI:       func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldSubcategory, "network").Return(
+					[]*serializer.ServiceNowChoice{{Label: "DNS", Value: "dns", DependentValue: "network"}}, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      1,
+		},
+		"invalid field": {
+			Field:                "short_description",
+			SetupAPI:             func(api *plugintest.API) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: constants.ErrorInvalidIncidentField,
+		},
+		"failed to get choices": {
+			Field: constants.FieldImpact,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetChoices", constants.RecordTypeIncident, constants.FieldImpact, "").Return(
+					nil, http.StatusInternalServerError, fmt.Errorf("get choices error"),
+				)
+			},
+			ExpectedStatusCode:   http.StatusInternalServerError,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: "Error in getting the choices. Error: get choices error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupClient(client)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, strings.Replace(requestURL, "{field}", test.Field, 1), nil)
+			if test.DependentValue != "" {
+				r.URL.RawQuery = url.Values{constants.QueryParamDependentValue: {test.DependentValue}}.Encode()
+			}
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(test.ExpectedStatusCode, result.StatusCode)
+
+			if test.ExpectedCount != -1 {
+				var choices []*serializer.ServiceNowChoice
+				err := json.NewDecoder(result.Body).Decode(&choices)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedCount, len(choices))
+			}
+
+			if test.ExpectedErrorMessage != "" {
+				var resp *serializer.APIErrorResponse
+				err := json.NewDecoder(result.Body).Decode(&resp)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedErrorMessage, resp.Message)
+			}
+		})
+	}
+}
+
+func TestSearchReferenceRecords(t *testing.T) {
+	limit, offset := testutils.GetLimitAndOffset()
+	for name, test := range map[string]struct {
+		Path                 string
+		SearchTerm           string
+		SetupAPI             func(*plugintest.API)
+		SetupClient          func(client *mock_plugin.Client)
+		ExpectedStatusCode   int
+		ExpectedCount        int
+		ExpectedErrorMessage string
+	}{
+		"success for assignment groups": {
+			Path:       constants.PathSearchAssignmentGroups,
+			SearchTerm: testutils.GetSearchTerm(true),
+			SetupAPI:   func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchReferenceRecords", constants.TableUserGroup, testutils.GetSearchTerm(true), limit, offset).Return(
+					[]*serializer.ServiceNowReference{{SysID: testutils.GetServiceNowSysID(), Name: "Network"}}, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      1,
+		},
+		"success for configuration items": {
+			Path:       constants.PathSearchCIs,
+			SearchTerm: testutils.GetSearchTerm(true),
+			SetupAPI:   func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchReferenceRecords", constants.TableConfigurationItem, testutils.GetSearchTerm(true), limit, offset).Return(
+					[]*serializer.ServiceNowReference{{SysID: testutils.GetServiceNowSysID(), Name: "Email server"}}, http.StatusOK, nil,
+				)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedCount:      1,
+		},
+		"invalid search term": {
+			Path:                 constants.PathSearchAssignmentGroups,
+			SearchTerm:           testutils.GetSearchTerm(false),
+			SetupAPI:             func(api *plugintest.API) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: fmt.Sprintf("The search term must be at least %d characters long.", constants.CharacterThresholdForSearchingRecords),
+		},
+		"failed to search configuration items": {
+			Path:       constants.PathSearchCIs,
+			SearchTerm: testutils.GetSearchTerm(true),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchReferenceRecords", constants.TableConfigurationItem, testutils.GetSearchTerm(true), limit, offset).Return(
+					nil, http.StatusInternalServerError, fmt.Errorf("search error"),
+				)
+			},
+			ExpectedStatusCode:   http.StatusInternalServerError,
+			ExpectedCount:        -1,
+			ExpectedErrorMessage: "Error in searching for configuration items in ServiceNow. Error: search error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupClient(client)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", constants.PathPrefix, test.Path), nil)
+			r.URL.RawQuery = url.Values{constants.QueryParamSearchTerm: {test.SearchTerm}}.Encode()
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(test.ExpectedStatusCode, result.StatusCode)
+
+			if test.ExpectedCount != -1 {
+				var records []*serializer.ServiceNowReference
+				err := json.NewDecoder(result.Body).Decode(&records)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedCount, len(records))
+			}
+
+			if test.ExpectedErrorMessage != "" {
+				var resp *serializer.APIErrorResponse
+				err := json.NewDecoder(result.Body).Decode(&resp)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedErrorMessage, resp.Message)
+			}
+		})
+	}
+}
+
+func TestCreateRecordFromPost(t *testing.T) {
+	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathCreateRecordFromPost)
+	post := &model.Post{Id: testutils.GetID(), ChannelId: testutils.GetChannelID(), Message: "mockMessage"}
+	requestBody := fmt.Sprintf(`{"post_id": "%s", "record_type": "%s"}`, testutils.GetID(), constants.RecordTypeProblem)
+	for name, test := range map[string]struct {
+		RequestBody          string
+		SetupAPI             func(*plugintest.API)
+		SetupPlugin          func(p *Plugin)
+		SetupClient          func(client *mock_plugin.Client)
+		ExpectedStatusCode   int
+		ExpectedErrorMessage string
+	}{
+		"record created successfully": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", testutils.GetID()).Return(post, nil)
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+			},
+			SetupPlugin: func(p *Plugin) {
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetRecordDetailsFromPost", func(_ *Plugin, _ *model.Post) (string, string, error) {
+					return "mockShortDescription", "mockDescription", nil
+				})
+
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "ShareCreatedRecord", func(_ *Plugin, channelID, rootID string, record *serializer.ServiceNowRecord) error {
+					require.Equal(t, testutils.GetChannelID(), channelID)
+					require.Equal(t, testutils.GetID(), rootID)
+					require.Equal(t, constants.RecordTypeProblem, record.RecordType)
+					return nil
+				})
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("CreateRecord", constants.RecordTypeProblem, &serializer.ServiceNowRecordPayload{
+					ShortDescription: "mockShortDescription",
+					Description:      "mockDescription",
+				}).Return(testutils.GetServiceNowRecord(), http.StatusOK, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"incident is created with the user as the caller and the provided short description": {
+			RequestBody: fmt.Sprintf(`{"post_id": "%s", "record_type": "%s", "short_description": "custom"}`, testutils.GetID(), constants.RecordTypeIncident),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", testutils.GetID()).Return(post, nil)
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+			},
+			SetupPlugin: func(p *Plugin) {
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetRecordDetailsFromPost", func(_ *Plugin, _ *model.Post) (string, string, error) {
+					return "mockShortDescription", "mockDescription", nil
+				})
+
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "ShareCreatedRecord", func(_ *Plugin, _, _ string, _ *serializer.ServiceNowRecord) error {
+					return nil
+				})
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("CreateRecord", constants.RecordTypeIncident, &serializer.ServiceNowRecordPayload{
+					ShortDescription: "custom",
+					Description:      "mockDescription",
+					Caller:           testutils.GetServiceNowSysID(),
+				}).Return(testutils.GetServiceNowRecord(), http.StatusOK, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"invalid body": {
+			RequestBody: "",
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupPlugin:        func(p *Plugin) {},
+			SetupClient:        func(client *mock_plugin.Client) {},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"record type can't be created": {
+			RequestBody: fmt.Sprintf(`{"post_id": "%s", "record_type": "%s"}`, testutils.GetID(), constants.RecordTypeKnowledge),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupPlugin:          func(p *Plugin) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: fmt.Sprintf("%s. Error: %s", constants.ErrorValidatingRequestBody, constants.ErrorRecordTypeNotCreatable),
+		},
+		"failed to get the post": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", testutils.GetID()).Return(nil, testutils.GetNotFoundAppError())
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupPlugin:        func(p *Plugin) {},
+			SetupClient:        func(client *mock_plugin.Client) {},
+			ExpectedStatusCode: http.StatusNotFound,
+		},
+		"does not have permission to access the channel": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", testutils.GetID()).Return(post, nil)
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(false)
+			},
+			SetupPlugin:          func(p *Plugin) {},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusForbidden,
+			ExpectedErrorMessage: constants.ErrorInsufficientPermissions,
+		},
+		"post without any text": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", testutils.GetID()).Return(post, nil)
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+			},
+			SetupPlugin: func(p *Plugin) {
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetRecordDetailsFromPost", func(_ *Plugin, _ *model.Post) (string, string, error) {
+					return "", "", nil
+				})
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorEmptyMessage,
+		},
+		"failed to create the record": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", testutils.GetID()).Return(post, nil)
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupPlugin: func(p *Plugin) {
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetRecordDetailsFromPost", func(_ *Plugin, _ *model.Post) (string, string, error) {
+					return "mockShortDescription", "mockDescription", nil
+				})
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("CreateRecord", constants.RecordTypeProblem, mock.AnythingOfType("*serializer.ServiceNowRecordPayload")).Return(nil, http.StatusInternalServerError, errors.New("create record error"))
+			},
+			ExpectedStatusCode:   http.StatusInternalServerError,
+			ExpectedErrorMessage: "Error in creating the record. Error: create record error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupAPI(api)
+			test.SetupPlugin(p)
+			test.SetupClient(client)
+			defer api.AssertExpectations(t)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, requestURL, bytes.NewBufferString(test.RequestBody))
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(test.ExpectedStatusCode, result.StatusCode)
+
+			if test.ExpectedErrorMessage != "" {
+				var resp *serializer.APIErrorResponse
+				err := json.NewDecoder(result.Body).Decode(&resp)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedErrorMessage, resp.Message)
+			}
+		})
+	}
+}
+
+func TestGetCatalogItem(t *testing.T) {
+	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathGetCatalogItem)
+	for name, test := range map[string]struct {
+		SetupAPI             func(*plugintest.API)
+		SetupClient          func(client *mock_plugin.Client)
+		ExpectedStatusCode   int
+		ExpectedFields       []*serializer.CatalogItemFormField
+		ExpectedErrorMessage string
+	}{
+		"success": {
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetCatalogItem", testutils.GetServiceNowSysID()).Return(&serializer.ServiceNowCatalogItemDetails{
+					SysID: testutils.GetServiceNowSysID(),
+					Name:  "Laptop",
+					Variables: []*serializer.ServiceNowCatalogVariable{
+						{Name: "model", Label: "Model", FriendlyType: "select_box", Mandatory: true, Choices: []*serializer.ServiceNowCatalogVariableChoice{{Label: "Pro", Value: "pro"}}},
+						{Name: "container", FriendlyType: "container_start"},
+						{Name: "notes", Label: "Notes", FriendlyType: "multi_line_text"},
+					},
+				}, http.StatusOK, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedFields: []*serializer.CatalogItemFormField{
+				{Name: "model", Label: "Model", Type: constants.FormFieldTypeSelect, Required: true, Options: []*serializer.ServiceNowCatalogVariableChoice{{Label: "Pro", Value: "pro"}}},
+				{Name: "notes", Label: "Notes", Type: constants.FormFieldTypeTextarea},
+			},
+		},
+		"failed to get the catalog item": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetCatalogItem", testutils.GetServiceNowSysID()).Return(nil, http.StatusInternalServerError, errors.New("get catalog item error"))
+			},
+			ExpectedStatusCode:   http.StatusInternalServerError,
+			ExpectedErrorMessage: "Error in getting the catalog item from ServiceNow. Error: get catalog item error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupAPI(api)
+			test.SetupClient(client)
+			defer api.AssertExpectations(t)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, strings.Replace(requestURL, "{item_id:"+constants.ServiceNowSysIDRegex+"}", testutils.GetServiceNowSysID(), 1), nil)
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(test.ExpectedStatusCode, result.StatusCode)
+
+			if test.ExpectedFields != nil {
+				var form *serializer.CatalogItemForm
+				err := json.NewDecoder(result.Body).Decode(&form)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedFields, form.Fields)
+			}
+
+			if test.ExpectedErrorMessage != "" {
+				var resp *serializer.APIErrorResponse
+				err := json.NewDecoder(result.Body).Decode(&resp)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedErrorMessage, resp.Message)
+			}
+		})
+	}
+}
+
+func TestOrderCatalogItem(t *testing.T) {
+	requestURL := strings.Replace(fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathOrderCatalogItem), "{item_id:"+constants.ServiceNowSysIDRegex+"}", testutils.GetServiceNowSysID(), 1)
+	requestBody := fmt.Sprintf(`{"channel_id": "%s", "variables": {"model": "pro"}}`, testutils.GetChannelID())
+	item := &serializer.ServiceNowCatalogItemDetails{
+		SysID: testutils.GetServiceNowSysID(),
+		Name:  "Laptop",
+		Variables: []*serializer.ServiceNowCatalogVariable{
+			{Name: "model", Label: "Model", FriendlyType: "select_box", Mandatory: true},
+		},
+	}
+	orderResponse := &serializer.ServiceNowCatalogOrderResponse{RequestNumber: "REQ0010001", RequestID: "mockRequestID"}
+	for name, test := range map[string]struct {
+		RequestBody          string
+		SetupAPI             func(*plugintest.API)
+		SetupClient          func(client *mock_plugin.Client)
+		ExpectedStatusCode   int
+		ExpectedItemsCount   int
+		ExpectedErrorMessage string
+	}{
+		"success": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+				api.On("GetUser", testutils.GetID()).Return(testutils.GetUser(model.SystemUserRoleId), nil)
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetCatalogItem", testutils.GetServiceNowSysID()).Return(item, http.StatusOK, nil)
+				client.On("OrderCatalogItem", testutils.GetServiceNowSysID(), &serializer.ServiceNowCatalogOrderRequest{
+					Quantity:  "1",
+					Variables: map[string]string{"model": "pro"},
+				}).Return(orderResponse, http.StatusOK, nil)
+				client.On("GetRequestedItems", "mockRequestID").Return(testutils.GetServiceNowPartialRecords(1), http.StatusOK, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedItemsCount: 1,
+		},
+		"order is posted even if the requested items can't be fetched": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+				api.On("GetUser", testutils.GetID()).Return(testutils.GetUser(model.SystemUserRoleId), nil)
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{}, nil)
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetCatalogItem", testutils.GetServiceNowSysID()).Return(item, http.StatusOK, nil)
+				client.On("OrderCatalogItem", testutils.GetServiceNowSysID(), mock.AnythingOfType("*serializer.ServiceNowCatalogOrderRequest")).Return(orderResponse, http.StatusOK, nil)
+				client.On("GetRequestedItems", "mockRequestID").Return(nil, http.StatusInternalServerError, errors.New("get requested items error"))
+			},
+			ExpectedStatusCode: http.StatusOK,
+			ExpectedItemsCount: 0,
+		},
+		"invalid channel ID": {
+			RequestBody: `{"channel_id": "invalid"}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:        func(client *mock_plugin.Client) {},
+			ExpectedStatusCode: http.StatusBadRequest,
+			ExpectedItemsCount: -1,
+		},
+		"does not have permission to access the channel": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(false)
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusForbidden,
+			ExpectedItemsCount:   -1,
+			ExpectedErrorMessage: constants.ErrorInsufficientPermissions,
+		},
+		"required variable is missing": {
+			RequestBody: fmt.Sprintf(`{"channel_id": "%s"}`, testutils.GetChannelID()),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetCatalogItem", testutils.GetServiceNowSysID()).Return(item, http.StatusOK, nil)
+			},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedItemsCount:   -1,
+			ExpectedErrorMessage: "Error in validating the request body. Error: Model is required",
+		},
+		"failed to order the catalog item": {
+			RequestBody: requestBody,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetCatalogItem", testutils.GetServiceNowSysID()).Return(item, http.StatusOK, nil)
+				client.On("OrderCatalogItem", testutils.GetServiceNowSysID(), mock.AnythingOfType("*serializer.ServiceNowCatalogOrderRequest")).Return(nil, http.StatusInternalServerError, errors.New("order error"))
+			},
+			ExpectedStatusCode:   http.StatusInternalServerError,
+			ExpectedItemsCount:   -1,
+			ExpectedErrorMessage: "Error in ordering the catalog item. Error: order error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupAPI(api)
+			test.SetupClient(client)
+			defer api.AssertExpectations(t)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, requestURL, bytes.NewBufferString(test.RequestBody))
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(test.ExpectedStatusCode, result.StatusCode)
+
+			if test.ExpectedItemsCount != -1 {
+				var order *serializer.CatalogItemOrder
+				err := json.NewDecoder(result.Body).Decode(&order)
+				require.Nil(t, err)
+
+				assert.Equal("REQ0010001", order.RequestNumber)
+				assert.Equal(test.ExpectedItemsCount, len(order.RequestedItems))
+			}
+
+			if test.ExpectedErrorMessage != "" {
+				var resp *serializer.APIErrorResponse
+				err := json.NewDecoder(result.Body).Decode(&resp)
+				require.Nil(t, err)
+
+				assert.Equal(test.ExpectedErrorMessage, resp.Message)
+			}
+		})
+	}
+}
+
+func TestUpdateAssignmentOfRecord(t *testing.T) {
+	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathUpdateAssignment)
+	requestURL = strings.Replace(requestURL, "{record_id:[0-9a-f]{32}}", testutils.GetServiceNowSysID(), 1)
+	for name, test := range map[string]struct {
+		RecordType           string
+		RequestBody          string
+		SetupAPI             func(*plugintest.API)
+		SetupClient          func(client *mock_plugin.Client)
+		ExpectedStatusCode   int
+		ExpectedErrorMessage string
+	}{
+		"success": {
+			RecordType:  constants.RecordTypeIncident,
+			RequestBody: fmt.Sprintf(`{"assigned_to": "%s", "assignment_group": "%s"}`, testutils.GetServiceNowSysID(), testutils.GetServiceNowSysID()),
+			SetupAPI:    func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("AssignRecord", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), &serializer.ServiceNowAssignmentPayload{
+					AssignedTo:      testutils.GetServiceNowSysID(),
+					AssignmentGroup: testutils.GetServiceNowSysID(),
+				}).Return(http.StatusOK, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"record type not supporting assignment": {
+			RecordType: constants.RecordTypeKnowledge,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", mock.AnythingOfType("string"), "Record type", constants.RecordTypeKnowledge).Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorInvalidRecordType,
+		},
+		"invalid request body": {
+			RecordType:  constants.RecordTypeIncident,
+			RequestBody: "",
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:        func(client *mock_plugin.Client) {},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"empty assignment": {
+			RecordType:  constants.RecordTypeIncident,
+			RequestBody: `{}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorEmptyAssignment,
+		},
+		"invalid assignment group": {
+			RecordType:  constants.RecordTypeIncident,
+			RequestBody: `{"assignment_group": "mockGroup"}`,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorInvalidAssignmentGroup,
+		},
+		"assigned user with a query appended": {
+			RecordType:  constants.RecordTypeIncident,
+			RequestBody: fmt.Sprintf(`{"assigned_to": "%s^NQactive=true"}`, testutils.GetServiceNowSysID()),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorInvalidAssignedTo,
+		},
+		"assignment group with a query appended": {
+			RecordType:  constants.RecordTypeIncident,
+			RequestBody: fmt.Sprintf(`{"assignment_group": "%s^ORpriority=1"}`, testutils.GetServiceNowSysID()),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupClient:          func(client *mock_plugin.Client) {},
+			ExpectedStatusCode:   http.StatusBadRequest,
+			ExpectedErrorMessage: constants.ErrorInvalidAssignmentGroup,
+		},
+		"failed to assign the record": {
+			RecordType:  constants.RecordTypeIncident,
+			RequestBody: fmt.Sprintf(`{"assigned_to": "%s"}`, testutils.GetServiceNowSysID()),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("AssignRecord", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("*serializer.ServiceNowAssignmentPayload")).Return(
+					http.StatusInternalServerError, fmt.Errorf("assign record error"),
+				)
+			},
+			ExpectedStatusCode:   http.StatusInternalServerError,
+			ExpectedErrorMessage: "assign record error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupClient(client)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPatch, strings.Replace(requestURL, "{record_type}", test.RecordType, 1), bytes.NewBufferString(test.RequestBody))
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(test.ExpectedStatusCode, result.StatusCode)
+			if test.ExpectedErrorMessage != "" {
+				var resp *serializer.APIErrorResponse
+				err := json.NewDecoder(result.Body).Decode(&resp)
+				require.Nil(t, err)
+
+				assert.Contains(resp.Message, test.ExpectedErrorMessage)
+			}
+		})
+	}
+}
+
+func TestHandleAssignToMe(t *testing.T) {
+	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathAssignToMe)
+	for name, test := range map[string]struct {
+		RecordType            string
+		RecordID              string
+		SetupAPI              func(*plugintest.API)
+		SetupClient           func(client *mock_plugin.Client)
+		ExpectedEphemeralText string
+	}{
+		"success": {
+			RecordType: constants.RecordTypeIncident,
+			RecordID:   testutils.GetServiceNowSysID(),
+			SetupAPI:   func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("AssignRecord", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), &serializer.ServiceNowAssignmentPayload{
+					AssignedTo: testutils.GetServiceNowSysID(),
+				}).Return(http.StatusOK, nil)
+			},
+			ExpectedEphemeralText: constants.RecordAssignedToMe,
+		},
+		"record type not supporting assignment": {
+			RecordType:            constants.RecordTypeKnowledge,
+			RecordID:              testutils.GetServiceNowSysID(),
+			SetupAPI:              func(api *plugintest.API) {},
+			SetupClient:           func(client *mock_plugin.Client) {},
+			ExpectedEphemeralText: constants.ErrorAssignmentNotSupported,
+		},
+		"invalid record ID": {
+			RecordType:            constants.RecordTypeIncident,
+			RecordID:              testutils.GetServiceNowSysID() + "/../mockPath",
+			SetupAPI:              func(api *plugintest.API) {},
+			SetupClient:           func(client *mock_plugin.Client) {},
+			ExpectedEphemeralText: constants.ErrorInvalidRecordID,
+		},
+		"failed to assign the record": {
+			RecordType: constants.RecordTypeIncident,
+			RecordID:   testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("AssignRecord", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), mock.AnythingOfType("*serializer.ServiceNowAssignmentPayload")).Return(
+					http.StatusForbidden, fmt.Errorf("assign record error"),
+				)
+			},
+			ExpectedEphemeralText: fmt.Sprintf("%s. Error: %s", constants.ErrorAssignRecord, "assign record error"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupClient(client)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			body, err := json.Marshal(&model.PostActionIntegrationRequest{
+				UserId: testutils.GetID(),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordType: test.RecordType,
+					constants.ContextNameRecordID:   test.RecordID,
+				},
+			})
+			require.Nil(t, err)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, requestURL, bytes.NewBuffer(body))
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(http.StatusOK, result.StatusCode)
+			var resp *model.PostActionIntegrationResponse
+			require.Nil(t, json.NewDecoder(result.Body).Decode(&resp))
+			assert.Equal(test.ExpectedEphemeralText, resp.EphemeralText)
+		})
+	}
+}
+
+func TestHandleApproveRecord(t *testing.T) {
+	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathApproveRecord)
+	approvalPost := &model.Post{Id: "mockPostID"}
+	model.ParseSlackAttachment(approvalPost, []*model.SlackAttachment{{Title: "mockTitle", Actions: []*model.PostAction{{Name: "Approve"}}}})
+	for name, test := range map[string]struct {
+		ApprovalID            string
+		SetupAPI              func(*plugintest.API)
+		SetupClient           func(client *mock_plugin.Client)
+		ExpectedEphemeralText string
+		ExpectedUpdate        bool
+	}{
+		"success": {
+			ApprovalID: testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", "mockPostID").Return(approvalPost, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("UpdateApproval", testutils.GetServiceNowSysID(), &serializer.ServiceNowApprovalPayload{
+					State: constants.ApprovalStateApproved,
+				}).Return(http.StatusOK, nil)
+			},
+			ExpectedUpdate: true,
+		},
+		"invalid approval ID": {
+			ApprovalID:            "invalidID",
+			SetupAPI:              func(api *plugintest.API) {},
+			SetupClient:           func(client *mock_plugin.Client) {},
+			ExpectedEphemeralText: constants.ErrorInvalidApprovalID,
+		},
+		"failed to update the approval": {
+			ApprovalID: testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("UpdateApproval", testutils.GetServiceNowSysID(), mock.AnythingOfType("*serializer.ServiceNowApprovalPayload")).Return(
+					http.StatusForbidden, fmt.Errorf("update approval error"),
+				)
+			},
+			ExpectedEphemeralText: fmt.Sprintf("%s. Error: %s", constants.ErrorUpdateApproval, "update approval error"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupClient(client)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			body, err := json.Marshal(&model.PostActionIntegrationRequest{
+				UserId: testutils.GetID(),
+				PostId: "mockPostID",
+				Context: map[string]interface{}{
+					constants.ContextNameApprovalID: test.ApprovalID,
+				},
+			})
+			require.Nil(t, err)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, requestURL, bytes.NewBuffer(body))
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(http.StatusOK, result.StatusCode)
+			var resp *model.PostActionIntegrationResponse
+			require.Nil(t, json.NewDecoder(result.Body).Decode(&resp))
+			assert.Equal(test.ExpectedEphemeralText, resp.EphemeralText)
+			if test.ExpectedUpdate {
+				require.NotNil(t, resp.Update)
+				assert.Empty(resp.Update.Attachments()[0].Actions)
+			} else {
+				assert.Nil(resp.Update)
+			}
+		})
+	}
+}
+
+func TestHandleRejectRecord(t *testing.T) {
+	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathRejectRecord)
+	approvalPost := &model.Post{Id: "mockPostID"}
+	model.ParseSlackAttachment(approvalPost, []*model.SlackAttachment{{Title: "mockTitle", Actions: []*model.PostAction{{Name: "Reject"}}}})
+	for name, test := range map[string]struct {
+		ApprovalID     string
+		Comments       string
+		Cancelled      bool
+		SetupAPI       func(*plugintest.API)
+		SetupClient    func(client *mock_plugin.Client)
+		ExpectedError  string
+		ExpectedErrors map[string]string
+	}{
+		"success": {
+			ApprovalID: testutils.GetServiceNowSysID(),
+			Comments:   " mockComment ",
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", "mockPostID").Return(approvalPost, nil)
+				api.On("UpdatePost", mock.MatchedBy(func(post *model.Post) bool {
+					return len(post.Attachments()[0].Actions) == 0
+				})).Return(approvalPost, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("UpdateApproval", testutils.GetServiceNowSysID(), &serializer.ServiceNowApprovalPayload{
+					State:    constants.ApprovalStateRejected,
+					Comments: "mockComment",
+				}).Return(http.StatusOK, nil)
+			},
+		},
+		"dialog cancelled": {
+			Cancelled:   true,
+			SetupAPI:    func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {},
+		},
+		"invalid approval ID": {
+			ApprovalID:    "invalidID",
+			Comments:      "mockComment",
+			SetupAPI:      func(api *plugintest.API) {},
+			SetupClient:   func(client *mock_plugin.Client) {},
+			ExpectedError: constants.ErrorInvalidApprovalID,
+		},
+		"empty comment": {
+			ApprovalID:     testutils.GetServiceNowSysID(),
+			Comments:       "  ",
+			SetupAPI:       func(api *plugintest.API) {},
+			SetupClient:    func(client *mock_plugin.Client) {},
+			ExpectedErrors: map[string]string{constants.DialogElementNameComments: constants.ErrorEmptyRejectionComment},
+		},
+		"failed to update the approval": {
+			ApprovalID: testutils.GetServiceNowSysID(),
+			Comments:   "mockComment",
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("UpdateApproval", testutils.GetServiceNowSysID(), mock.AnythingOfType("*serializer.ServiceNowApprovalPayload")).Return(
+					http.StatusForbidden, fmt.Errorf("update approval error"),
+				)
+			},
+			ExpectedError: fmt.Sprintf("%s. Error: %s", constants.ErrorUpdateApproval, "update approval error"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupClient(client)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			body, err := json.Marshal(&model.SubmitDialogRequest{
+				UserId:     testutils.GetID(),
+				CallbackId: test.ApprovalID,
+				State:      "mockPostID",
+				Cancelled:  test.Cancelled,
+				Submission: map[string]interface{}{
+					constants.DialogElementNameComments: test.Comments,
+				},
+			})
+			require.Nil(t, err)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, requestURL, bytes.NewBuffer(body))
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(http.StatusOK, result.StatusCode)
+			var resp *model.SubmitDialogResponse
+			require.Nil(t, json.NewDecoder(result.Body).Decode(&resp))
+			assert.Equal(test.ExpectedError, resp.Error)
+			assert.Equal(test.ExpectedErrors, resp.Errors)
+		})
+	}
+}
+
+func TestHandlePostKnowledgeArticle(t *testing.T) {
+	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathPostKnowledgeArticle)
+	for name, test := range map[string]struct {
+		ArticleID             string
+		SetupAPI              func(*plugintest.API)
+		SetupClient           func(client *mock_plugin.Client)
+		ExpectedEphemeralText string
+	}{
+		"success": {
+			ArticleID: testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+				api.On("GetUser", testutils.GetID()).Return(testutils.GetUser(model.SystemUserRoleId), nil)
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					post := args.Get(0).(*model.Post)
+					require.Len(t, post.Attachments(), 1)
+					assert.Equal(t, "## Steps\n\n1. Open the [portal](https://test.service-now.com/sp)\n1. Click **Reset**", post.Attachments()[0].Text)
+				}).Return(&model.Post{}, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordFromServiceNow", constants.RecordTypeKnowledge, testutils.GetServiceNowSysID()).Return(&serializer.ServiceNowRecord{
+					SysID:    testutils.GetServiceNowSysID(),
+					Number:   "KB0000001",
+					Workflow: "Published",
+					Text:     `<h2>Steps</h2><ol><li>Open the <a href="/sp">portal</a></li><li>Click <b>Reset </b></li></ol>`,
+				}, http.StatusOK, nil)
+			},
+		},
+		"invalid article ID": {
+			ArticleID:             "invalidID",
+			SetupAPI:              func(api *plugintest.API) {},
+			SetupClient:           func(client *mock_plugin.Client) {},
+			ExpectedEphemeralText: constants.ErrorInvalidArticleID,
+		},
+		"article ID containing a valid sys_id": {
+			ArticleID:             testutils.GetServiceNowSysID() + "/../mockPath",
+			SetupAPI:              func(api *plugintest.API) {},
+			SetupClient:           func(client *mock_plugin.Client) {},
+			ExpectedEphemeralText: constants.ErrorInvalidArticleID,
+		},
+		"insufficient permissions": {
+			ArticleID: testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(false)
+			},
+			SetupClient:           func(client *mock_plugin.Client) {},
+			ExpectedEphemeralText: constants.ErrorInsufficientPermissions,
+		},
+		"article not published": {
+			ArticleID: testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+				api.On("GetUser", testutils.GetID()).Return(testutils.GetUser(model.SystemUserRoleId), nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordFromServiceNow", constants.RecordTypeKnowledge, testutils.GetServiceNowSysID()).Return(&serializer.ServiceNowRecord{
+					Workflow: "Draft",
+				}, http.StatusOK, nil)
+			},
+			ExpectedEphemeralText: constants.ErrorKnowledgeArticleNotPublished,
+		},
+		"failed to get the article": {
+			ArticleID: testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+				api.On("GetUser", testutils.GetID()).Return(testutils.GetUser(model.SystemUserRoleId), nil)
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordFromServiceNow", constants.RecordTypeKnowledge, testutils.GetServiceNowSysID()).Return(
+					nil, http.StatusForbidden, fmt.Errorf("get record error"),
+				)
+			},
+			ExpectedEphemeralText: fmt.Sprintf("%s. Error: %s", constants.ErrorGetRecord, "get record error"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			p.setConfiguration(&configuration{ServiceNowBaseURL: "https://test.service-now.com"})
+			client := setupPluginForCheckOAuthMiddleware(p, t)
+			test.SetupClient(client)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			body, err := json.Marshal(&model.PostActionIntegrationRequest{
+				UserId:    testutils.GetID(),
+				ChannelId: testutils.GetChannelID(),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordID: test.ArticleID,
+				},
+			})
+			require.Nil(t, err)
+
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, requestURL, bytes.NewBuffer(body))
+			r.Header.Add(constants.HeaderMattermostUserID, testutils.GetID())
+			p.ServeHTTP(nil, w, r)
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(http.StatusOK, result.StatusCode)
+			var resp *model.PostActionIntegrationResponse
+			require.Nil(t, json.NewDecoder(result.Body).Decode(&resp))
+			assert.Equal(test.ExpectedEphemeralText, resp.EphemeralText)
+		})
+	}
+}
@@ -4,12 +4,14 @@
 package plugin
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
 	"io"
+	"sync"
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
@@ -19,6 +21,71 @@ type AuthToken struct {
 	Token *oauth2.Token `json:"token,omitempty"`
 }
 
+// refreshTokenSource is an oauth2.TokenSource which stores the token of a user
+// back in the KV store whenever it gets refreshed by the underlying token source.
+type refreshTokenSource struct {
+	plugin           *Plugin
+	mattermostUserID string
+	source           oauth2.TokenSource
+
+	lock  sync.Mutex
+	token *oauth2.Token
+}
+
+func (p *Plugin) NewRefreshTokenSource(ctx context.Context, token *oauth2.Token, mattermostUserID string) oauth2.TokenSource {
+	return &refreshTokenSource{
+		plugin:           p,
+		mattermostUserID: mattermostUserID,
+		source:           p.NewOAuth2Config().TokenSource(ctx, token),
+		token:            token,
+	}
+}
+
+func (s *refreshTokenSource) Token() (*oauth2.Token, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.token != nil && s.token.AccessToken == token.AccessToken && s.token.RefreshToken == token.RefreshToken {
+		return token, nil
+	}
+
+	// Failing to store the refreshed token should not fail the current request, as the token itself is valid
+	if err = s.plugin.StoreRefreshedToken(s.mattermostUserID, s.token, token); err != nil {
+		s.plugin.API.LogError("Unable to store the refreshed OAuth token", "UserID", s.mattermostUserID, "Error", err.Error())
+	}
+
+	s.token = token
+	return token, nil
+}
+
+// StoreRefreshedToken replaces the stored token of a user with the refreshed token.
+// The stored token is only replaced if it is still the token which got refreshed, so that a token
+// refreshed concurrently on another server or a token received by reconnecting the account is not overwritten.
+func (p *Plugin) StoreRefreshedToken(mattermostUserID string, previousToken, refreshedToken *oauth2.Token) error {
+	encodedToken, err := p.NewEncodedAuthToken(refreshedToken)
+	if err != nil {
+		return err
+	}
+
+	return p.store.UpdateUserToken(mattermostUserID, encodedToken, func(storedToken string) bool {
+		if previousToken == nil {
+			return true
+		}
+
+		token, parseErr := p.ParseAuthToken(storedToken)
+		if parseErr != nil || token == nil {
+			return false
+		}
+
+		return token.AccessToken == previousToken.AccessToken && token.RefreshToken == previousToken.RefreshToken
+	})
+}
+
 func (p *Plugin) NewEncodedAuthToken(token *oauth2.Token) (returnToken string, returnErr error) {
 	defer func() {
 		if returnErr == nil {
@@ -6,13 +6,28 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"reflect"
 	"testing"
 
 	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest/mock"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/oauth2"
+
+	mock_plugin "github.com/mattermost/mattermost-plugin-servicenow/server/mocks"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/testutils"
 )
 
+type mockTokenSource struct {
+	token *oauth2.Token
+	err   error
+}
+
+func (s *mockTokenSource) Token() (*oauth2.Token, error) {
+	return s.token, s.err
+}
+
 type mockBLock struct{}
 
 func (b *mockBLock) BlockSize() int { return 0 }
@@ -203,3 +218,128 @@ func Test_ParseAuthToken(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshTokenSource(t *testing.T) {
+	defer monkey.UnpatchAll()
+	currentToken := &oauth2.Token{AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken"}
+	refreshedToken := &oauth2.Token{AccessToken: "mockNewAccessToken", RefreshToken: "mockNewRefreshToken"}
+	for _, testCase := range []struct {
+		description   string
+		sourceToken   *oauth2.Token
+		sourceError   error
+		setupStore    func(*mock_plugin.Store)
+		setupAPI      func(*plugintest.API)
+		expectedError string
+	}{
+		{
+			description: "RefreshTokenSource: token is not refreshed",
+			sourceToken: currentToken,
+			setupStore:  func(s *mock_plugin.Store) {},
+			setupAPI:    func(a *plugintest.API) {},
+		},
+		{
+			description: "RefreshTokenSource: refreshed token is stored",
+			sourceToken: refreshedToken,
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("UpdateUserToken", testutils.GetID(), "mockEncodedToken", mock.AnythingOfType("func(string) bool")).Return(nil)
+			},
+			setupAPI: func(a *plugintest.API) {},
+		},
+		{
+			description: "RefreshTokenSource: failed to store the refreshed token",
+			sourceToken: refreshedToken,
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("UpdateUserToken", testutils.GetID(), "mockEncodedToken", mock.AnythingOfType("func(string) bool")).Return(errors.New("mockError"))
+			},
+			setupAPI: func(a *plugintest.API) {
+				a.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+		},
+		{
+			description:   "RefreshTokenSource: failed to refresh the token",
+			sourceError:   errors.New("oauth2: cannot fetch token: 401 Unauthorized"),
+			setupStore:    func(s *mock_plugin.Store) {},
+			setupAPI:      func(a *plugintest.API) {},
+			expectedError: "oauth2: cannot fetch token: 401 Unauthorized",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p := &Plugin{}
+			api := &plugintest.API{}
+			testCase.setupAPI(api)
+			defer api.AssertExpectations(t)
+			p.SetAPI(api)
+
+			store := mock_plugin.NewStore(t)
+			testCase.setupStore(store)
+			p.store = store
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewEncodedAuthToken", func(_ *Plugin, _ *oauth2.Token) (string, error) {
+				return "mockEncodedToken", nil
+			})
+
+			source := &refreshTokenSource{
+				plugin:           p,
+				mattermostUserID: testutils.GetID(),
+				source:           &mockTokenSource{token: testCase.sourceToken, err: testCase.sourceError},
+				token:            currentToken,
+			}
+
+			token, err := source.Token()
+			if testCase.expectedError != "" {
+				assert.EqualError(t, err, testCase.expectedError)
+				assert.Nil(t, token)
+				return
+			}
+
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.sourceToken, token)
+			assert.Equal(t, testCase.sourceToken, source.token)
+		})
+	}
+}
+
+func TestStoreRefreshedToken(t *testing.T) {
+	defer monkey.UnpatchAll()
+	previousToken := &oauth2.Token{AccessToken: "mockAccessToken", RefreshToken: "mockRefreshToken"}
+	for _, testCase := range []struct {
+		description          string
+		storedToken          *oauth2.Token
+		parseError           error
+		expectedShouldUpdate bool
+	}{
+		{
+			description:          "StoreRefreshedToken: stored token is the one which got refreshed",
+			storedToken:          previousToken,
+			expectedShouldUpdate: true,
+		},
+		{
+			description: "StoreRefreshedToken: stored token is already replaced",
+			storedToken: &oauth2.Token{AccessToken: "mockOtherAccessToken", RefreshToken: "mockOtherRefreshToken"},
+		},
+		{
+			description: "StoreRefreshedToken: stored token can not be parsed",
+			parseError:  errors.New("mockError"),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			p := &Plugin{}
+			store := mock_plugin.NewStore(t)
+			store.On("UpdateUserToken", testutils.GetID(), "mockEncodedToken", mock.AnythingOfType("func(string) bool")).Run(func(args mock.Arguments) {
+				shouldUpdate := args.Get(2).(func(string) bool)
+				assert.Equal(t, testCase.expectedShouldUpdate, shouldUpdate("mockStoredToken"))
+			}).Return(nil)
+			p.store = store
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewEncodedAuthToken", func(_ *Plugin, _ *oauth2.Token) (string, error) {
+				return "mockEncodedToken", nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "ParseAuthToken", func(_ *Plugin, _ string) (*oauth2.Token, error) {
+				return testCase.storedToken, testCase.parseError
+			})
+
+			err := p.StoreRefreshedToken(testutils.GetID(), previousToken, &oauth2.Token{})
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -21,16 +21,29 @@ type Client interface {
 	GetAllSubscriptions(channelID, userID, subscriptionType, limit, offset string) ([]*serializer.SubscriptionResponse, int, error)
 	DeleteSubscription(subscriptionID string) (int, error)
 	EditSubscription(subscriptionID string, subscription *serializer.SubscriptionPayload) (*serializer.SubscriptionResponse, int, error)
-	CheckForDuplicateSubscription(*serializer.SubscriptionPayload) (bool, int, error)
-	SearchRecordsInServiceNow(tableName, searchTerm, limit, offset string) ([]*serializer.ServiceNowPartialRecord, int, error)
+	GetDuplicateSubscriptions(*serializer.SubscriptionPayload) ([]*serializer.SubscriptionResponse, int, error)
+	CheckRecordMatchesQuery(recordType, recordID, query string) (bool, int, error)
+	SearchRecordsInServiceNow(tableName, searchTerm, limit, offset string, filters *serializer.RecordSearchFilters) ([]*serializer.ServiceNowPartialRecord, int, error)
 	GetRecordFromServiceNow(tableName, sysID string) (*serializer.ServiceNowRecord, int, error)
+	GetRecordByNumber(tableName, number string) (*serializer.ServiceNowRecord, int, error)
 	GetAllComments(recordType, recordID string) (*serializer.ServiceNowComment, int, error)
+	GetJournalEntries(recordType, recordID, limit, offset string) ([]*serializer.ServiceNowJournalEntry, int, error)
 	AddComment(recordType, recordID string, payload *serializer.ServiceNowCommentPayload) (int, error)
+	GetGroupMembers(groupID string) ([]string, int, error)
 	GetStatesFromServiceNow(recordType string) ([]*serializer.ServiceNowState, int, error)
 	UpdateStateOfRecordInServiceNow(recordType, recordID string, payload *serializer.ServiceNowUpdateStatePayload) (int, error)
+	AssignRecord(recordType, recordID string, payload *serializer.ServiceNowAssignmentPayload) (int, error)
+	UpdateApproval(approvalID string, payload *serializer.ServiceNowApprovalPayload) (int, error)
+	GetRecordState(recordType, recordID string) (string, int, error)
 	GetMe(userEmail string) (*serializer.ServiceNowUser, int, error)
 	CreateIncident(*serializer.IncidentPayload) (*serializer.IncidentResponse, int, error)
+	CreateRecord(tableName string, record *serializer.ServiceNowRecordPayload) (*serializer.ServiceNowRecord, int, error)
 	SearchCatalogItemsInServiceNow(searchTerm, limit, offset string) ([]*serializer.ServiceNowCatalogItem, int, error)
+	GetCatalogItem(sysID string) (*serializer.ServiceNowCatalogItemDetails, int, error)
+	OrderCatalogItem(sysID string, order *serializer.ServiceNowCatalogOrderRequest) (*serializer.ServiceNowCatalogOrderResponse, int, error)
+	GetRequestedItems(requestID string) ([]*serializer.ServiceNowPartialRecord, int, error)
+	GetChoices(tableName, field, dependentValue string) ([]*serializer.ServiceNowChoice, int, error)
+	SearchReferenceRecords(tableName, searchTerm, limit, offset string) ([]*serializer.ServiceNowReference, int, error)
 }
 
 type client struct {
@@ -39,8 +52,16 @@ type client struct {
 	plugin     *Plugin
 }
 
-func (p *Plugin) NewClient(ctx context.Context, token *oauth2.Token) Client {
-	httpClient := p.NewOAuth2Config().Client(ctx, token)
+// NewClient returns a ServiceNow client authenticated with the given token.
+// If mattermostUserID is not empty, tokens refreshed by the client are stored back for that user.
+func (p *Plugin) NewClient(ctx context.Context, token *oauth2.Token, mattermostUserID string) Client {
+	var httpClient *http.Client
+	if mattermostUserID == "" {
+		httpClient = p.NewOAuth2Config().Client(ctx, token)
+	} else {
+		httpClient = oauth2.NewClient(ctx, p.NewRefreshTokenSource(ctx, token, mattermostUserID))
+	}
+
 	return &client{
 		ctx:        ctx,
 		httpClient: httpClient,
@@ -51,7 +72,7 @@ func (p *Plugin) NewClient(ctx context.Context, token *oauth2.Token) Client {
 func (c *client) ActivateSubscriptions() (int, error) {
 	pluginConfig := c.plugin.getConfiguration()
 	subscriptionAuthDetails := &serializer.SubscriptionAuthDetails{}
-	query := fmt.Sprintf("server_url=%s^api_secret=%s", pluginConfig.MattermostSiteURL, pluginConfig.WebhookSecret)
+	query := fmt.Sprintf("server_url=%s", pluginConfig.MattermostSiteURL)
 	queryParams := url.Values{
 		constants.SysQueryParam: {query},
 	}
@@ -68,8 +89,10 @@ func (c *client) ActivateSubscriptions() (int, error) {
 		return statusCode, errors.Wrap(err, "failed to get subscription auth details")
 	}
 
-	if len(subscriptionAuthDetails.Result) > 0 {
-		return http.StatusOK, nil
+	for _, subscriptionAuth := range subscriptionAuthDetails.Result {
+		if subscriptionAuth.APISecret == pluginConfig.WebhookSecret {
+			return http.StatusOK, nil
+		}
 	}
 
 	payload := serializer.SubscriptionAuthPayload{
@@ -77,6 +100,15 @@ func (c *client) ActivateSubscriptions() (int, error) {
 		APISecret: pluginConfig.WebhookSecret,
 	}
 
+	// The webhook secret has been regenerated since this server was activated, so the secret in ServiceNow is replaced
+	if len(subscriptionAuthDetails.Result) > 0 {
+		if _, statusCode, err := c.CallJSON(http.MethodPatch, fmt.Sprintf("%s/%s", constants.PathActivateSubscriptions, subscriptionAuthDetails.Result[0].SysID), payload, nil, nil); err != nil {
+			return statusCode, errors.Wrap(err, "failed to update the webhook secret for this server")
+		}
+
+		return http.StatusOK, nil
+	}
+
 	if _, statusCode, err := c.CallJSON(http.MethodPost, constants.PathActivateSubscriptions, payload, nil, nil); err != nil {
 		return statusCode, errors.Wrap(err, "failed to activate subscriptions for this server")
 	}
@@ -155,9 +187,8 @@ func (c *client) EditSubscription(subscriptionID string, subscription *serialize
 	return subscriptionResult.Result, statusCode, nil
 }
 
-// CheckForDuplicateSubscription returns true and an error if a duplicate subscription exists in ServiceNow
-// The boolean return type value should be checked only if the error being returned is nil
-func (c *client) CheckForDuplicateSubscription(subscription *serializer.SubscriptionPayload) (bool, int, error) {
+// GetDuplicateSubscriptions returns the active subscriptions in ServiceNow for the same channel, type and record as the given subscription
+func (c *client) GetDuplicateSubscriptions(subscription *serializer.SubscriptionPayload) ([]*serializer.SubscriptionResponse, int, error) {
 	query := fmt.Sprintf("channel_id=%s^is_active=true^type=%s^record_type=%s^record_id=%s^server_url=%s", *subscription.ChannelID, *subscription.Type, *subscription.RecordType, *subscription.RecordID, *subscription.ServerURL)
 	queryParams := url.Values{
 		constants.SysQueryParam:      {query},
@@ -167,19 +198,53 @@ func (c *client) CheckForDuplicateSubscription(subscription *serializer.Subscrip
 	subscriptions := &serializer.SubscriptionsResult{}
 	_, statusCode, err := c.CallJSON(http.MethodGet, constants.PathSubscriptionCRUD, nil, subscriptions, queryParams)
 	if err != nil {
-		return false, statusCode, errors.Wrap(err, "failed to get subscriptions from ServiceNow")
+		return nil, statusCode, errors.Wrap(err, "failed to get subscriptions from ServiceNow")
+	}
+
+	return subscriptions.Result, statusCode, nil
+}
+
+// CheckRecordMatchesQuery returns true if the given record matches the given encoded query
+func (c *client) CheckRecordMatchesQuery(recordType, recordID, query string) (bool, int, error) {
+	queryParams := url.Values{
+		constants.SysQueryParam:       {fmt.Sprintf("%s=%s^%s", constants.FieldSysID, recordID, query)},
+		constants.SysQueryParamLimit:  {"1"},
+		constants.SysQueryParamFields: {constants.FieldSysID},
+	}
+
+	records := &serializer.ServiceNowPartialRecordsResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", recordType, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, url, nil, records, queryParams)
+	if err != nil {
+		return false, statusCode, errors.Wrap(err, "failed to check if the record matches the query")
 	}
 
-	return len(subscriptions.Result) > 0, statusCode, nil
+	return len(records.Result) > 0, statusCode, nil
+}
+
+// sanitizeQueryValue replaces the carets in a value which is added to an encoded query,
+// as they would add conditions to the query
+func sanitizeQueryValue(value string) string {
+	return strings.ReplaceAll(value, "^", " ")
 }
 
-func (c *client) SearchRecordsInServiceNow(tableName, searchTerm, limit, offset string) ([]*serializer.ServiceNowPartialRecord, int, error) {
+func (c *client) SearchRecordsInServiceNow(tableName, searchTerm, limit, offset string, filters *serializer.RecordSearchFilters) ([]*serializer.ServiceNowPartialRecord, int, error) {
+	// The search term can come from the text of a record, like the short description of an incident
+	searchTerm = sanitizeQueryValue(searchTerm)
 	query := fmt.Sprintf("%s LIKE%s ^OR %s STARTSWITH%s", constants.FieldShortDescription, searchTerm, constants.FieldNumber, searchTerm)
+	if filters != nil && filters.MatchKeywords {
+		query = fmt.Sprintf("%s=%s", constants.QueryTextSearch, searchTerm)
+	}
+
+	if filtersQuery := filters.Query(); filtersQuery != "" {
+		query = fmt.Sprintf("%s^%s", query, filtersQuery)
+	}
+
 	queryParams := url.Values{
 		constants.SysQueryParam:       {query},
 		constants.SysQueryParamLimit:  {limit},
 		constants.SysQueryParamOffset: {offset},
-		constants.SysQueryParamFields: {fmt.Sprintf("%s,%s,%s", constants.FieldSysID, constants.FieldNumber, constants.FieldShortDescription)},
+		constants.SysQueryParamFields: {fmt.Sprintf("%s,%s,%s,%s", constants.FieldSysID, constants.FieldNumber, constants.FieldShortDescription, constants.FieldSysUpdatedOn)},
 	}
 
 	records := &serializer.ServiceNowPartialRecordsResult{}
@@ -207,6 +272,28 @@ func (c *client) GetRecordFromServiceNow(tableName, sysID string) (*serializer.S
 	return record.Result, statusCode, nil
 }
 
+// GetRecordByNumber returns the record having the given number, or nil if no such record is visible to the user
+func (c *client) GetRecordByNumber(tableName, number string) (*serializer.ServiceNowRecord, int, error) {
+	queryParams := url.Values{
+		constants.SysQueryParam:             {fmt.Sprintf("%s=%s", constants.FieldNumber, number)},
+		constants.SysQueryParamLimit:        {"1"},
+		constants.SysQueryParamDisplayValue: {"true"},
+	}
+
+	records := &serializer.ServiceNowRecordsResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", tableName, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, url, nil, records, queryParams)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	if len(records.Result) == 0 {
+		return nil, statusCode, nil
+	}
+
+	return records.Result[0], statusCode, nil
+}
+
 func (c *client) GetAllComments(recordType, recordID string) (*serializer.ServiceNowComment, int, error) {
 	queryParams := url.Values{
 		constants.SysQueryParamDisplayValue: {"true"},
@@ -223,12 +310,55 @@ func (c *client) GetAllComments(recordType, recordID string) (*serializer.Servic
 	return comments.Result, statusCode, nil
 }
 
+// GetJournalEntries returns the comments and work notes of a record, latest first
+func (c *client) GetJournalEntries(recordType, recordID, limit, offset string) ([]*serializer.ServiceNowJournalEntry, int, error) {
+	queryParams := url.Values{
+		constants.SysQueryParam:       {fmt.Sprintf("%s=%s^%s=%s^%sIN%s,%s^ORDERBYDESC%s", constants.FieldName, recordType, constants.FieldElementID, recordID, constants.FieldElement, constants.FieldComments, constants.FieldWorkNotes, constants.FieldSysCreatedOn)},
+		constants.SysQueryParamFields: {strings.Join([]string{constants.FieldElement, constants.FieldValue, constants.FieldSysCreatedBy, constants.FieldSysCreatedOn}, ",")},
+		constants.SysQueryParamLimit:  {limit},
+		constants.SysQueryParamOffset: {offset},
+	}
+
+	entries := &serializer.ServiceNowJournalEntriesResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", constants.TableJournalField, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, url, nil, entries, queryParams)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	return entries.Result, statusCode, nil
+}
+
 func (c *client) AddComment(recordType, recordID string, payload *serializer.ServiceNowCommentPayload) (int, error) {
 	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", recordType, 1)
 	_, statusCode, err := c.CallJSON(http.MethodPatch, fmt.Sprintf("%s/%s", url, recordID), payload, nil, nil)
 	return statusCode, err
 }
 
+// GetGroupMembers returns the sys IDs of the members of a ServiceNow group
+func (c *client) GetGroupMembers(groupID string) ([]string, int, error) {
+	queryParams := url.Values{
+		constants.SysQueryParam:                     {fmt.Sprintf("%s=%s", constants.FieldGroup, groupID)},
+		constants.SysQueryParamFields:               {constants.FieldUser},
+		constants.SysQueryParamLimit:                {fmt.Sprint(constants.MaxWarRoomGroupMembers)},
+		constants.SysQueryParamExcludeReferenceLink: {"true"},
+	}
+
+	members := &serializer.ServiceNowGroupMembersResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", constants.TableGroupMember, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, url, nil, members, queryParams)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the members of the group")
+	}
+
+	memberIDs := make([]string, 0, len(members.Result))
+	for _, member := range members.Result {
+		memberIDs = append(memberIDs, member.User)
+	}
+
+	return memberIDs, statusCode, nil
+}
+
 func (c *client) GetStatesFromServiceNow(recordType string) ([]*serializer.ServiceNowState, int, error) {
 	states := &serializer.ServiceNowStatesResult{}
 	url := strings.Replace(constants.PathGetStatesFromServiceNow, "{record_type}", recordType, 1)
@@ -250,6 +380,34 @@ func (c *client) UpdateStateOfRecordInServiceNow(recordType, recordID string, pa
 	return statusCode, err
 }
 
+// GetRecordState returns the value of the current state of a record
+func (c *client) GetRecordState(recordType, recordID string) (string, int, error) {
+	queryParams := url.Values{
+		constants.SysQueryParamFields: {constants.FieldState},
+	}
+
+	record := &serializer.ServiceNowRecordResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", recordType, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, fmt.Sprintf("%s/%s", url, recordID), nil, record, queryParams)
+	if err != nil {
+		return "", statusCode, err
+	}
+
+	return record.Result.State, statusCode, nil
+}
+
+func (c *client) AssignRecord(recordType, recordID string, payload *serializer.ServiceNowAssignmentPayload) (int, error) {
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", recordType, 1)
+	_, statusCode, err := c.CallJSON(http.MethodPatch, fmt.Sprintf("%s/%s", url, recordID), payload, nil, nil)
+	return statusCode, err
+}
+
+func (c *client) UpdateApproval(approvalID string, payload *serializer.ServiceNowApprovalPayload) (int, error) {
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", constants.TableApproval, 1)
+	_, statusCode, err := c.CallJSON(http.MethodPatch, fmt.Sprintf("%s/%s", url, approvalID), payload, nil, nil)
+	return statusCode, err
+}
+
 func (c *client) GetMe(userEmail string) (*serializer.ServiceNowUser, int, error) {
 	userList := &serializer.UserList{}
 	path := fmt.Sprintf("%s%s", c.plugin.getConfiguration().ServiceNowBaseURL, constants.PathGetUserFromServiceNow)
@@ -287,6 +445,63 @@ func (c *client) CreateIncident(incident *serializer.IncidentPayload) (*serializ
 	return response.Result, statusCode, nil
 }
 
+func (c *client) CreateRecord(tableName string, record *serializer.ServiceNowRecordPayload) (*serializer.ServiceNowRecord, int, error) {
+	queryParams := url.Values{
+		constants.SysQueryParamDisplayValue: {"true"},
+	}
+
+	response := &serializer.ServiceNowRecordResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", tableName, 1)
+	_, statusCode, err := c.CallJSON(http.MethodPost, url, record, response, queryParams)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to create the record in ServiceNow")
+	}
+
+	return response.Result, statusCode, nil
+}
+
+// GetChoices returns the active choices of a field of the given table.
+// If dependentValue is not empty, only the choices depending on that value are returned.
+func (c *client) GetChoices(tableName, field, dependentValue string) ([]*serializer.ServiceNowChoice, int, error) {
+	query := fmt.Sprintf("name=%s^element=%s^inactive=false^language=en", tableName, field)
+	if dependentValue != "" {
+		query += fmt.Sprintf("^%s=%s", constants.FieldDependentValue, sanitizeQueryValue(dependentValue))
+	}
+
+	queryParams := url.Values{
+		constants.SysQueryParam:       {fmt.Sprintf("%s^ORDERBY%s", query, constants.FieldSequence)},
+		constants.SysQueryParamFields: {fmt.Sprintf("%s,%s,%s", constants.FieldLabel, constants.FieldValue, constants.FieldDependentValue)},
+	}
+
+	choices := &serializer.ServiceNowChoicesResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", constants.TableChoice, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, url, nil, choices, queryParams)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to get the choices from ServiceNow")
+	}
+
+	return choices.Result, statusCode, nil
+}
+
+// SearchReferenceRecords searches the records of the given table by name
+func (c *client) SearchReferenceRecords(tableName, searchTerm, limit, offset string) ([]*serializer.ServiceNowReference, int, error) {
+	queryParams := url.Values{
+		constants.SysQueryParam:       {fmt.Sprintf("%sLIKE%s^ORDERBY%s", constants.FieldName, sanitizeQueryValue(searchTerm), constants.FieldName)},
+		constants.SysQueryParamLimit:  {limit},
+		constants.SysQueryParamOffset: {offset},
+		constants.SysQueryParamFields: {fmt.Sprintf("%s,%s", constants.FieldSysID, constants.FieldName)},
+	}
+
+	records := &serializer.ServiceNowReferencesResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", tableName, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, url, nil, records, queryParams)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	return records.Result, statusCode, nil
+}
+
 func (c *client) SearchCatalogItemsInServiceNow(searchTerm, limit, offset string) ([]*serializer.ServiceNowCatalogItem, int, error) {
 	queryParams := url.Values{
 		constants.SysQueryParamText:   {searchTerm},
@@ -302,3 +517,42 @@ func (c *client) SearchCatalogItemsInServiceNow(searchTerm, limit, offset string
 
 	return items.Result, statusCode, nil
 }
+
+func (c *client) GetCatalogItem(sysID string) (*serializer.ServiceNowCatalogItemDetails, int, error) {
+	item := &serializer.ServiceNowCatalogItemDetailsResult{}
+	url := strings.Replace(constants.PathGetCatalogItemFromServiceNow, "{sys_id}", sysID, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, url, nil, item, nil)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	return item.Result, statusCode, nil
+}
+
+func (c *client) OrderCatalogItem(sysID string, order *serializer.ServiceNowCatalogOrderRequest) (*serializer.ServiceNowCatalogOrderResponse, int, error) {
+	response := &serializer.ServiceNowCatalogOrderResult{}
+	url := strings.Replace(constants.PathOrderCatalogItemInServiceNow, "{sys_id}", sysID, 1)
+	_, statusCode, err := c.CallJSON(http.MethodPost, url, order, response, nil)
+	if err != nil {
+		return nil, statusCode, errors.Wrap(err, "failed to order the catalog item in ServiceNow")
+	}
+
+	return response.Result, statusCode, nil
+}
+
+// GetRequestedItems returns the requested items (RITMs) created for a catalog request
+func (c *client) GetRequestedItems(requestID string) ([]*serializer.ServiceNowPartialRecord, int, error) {
+	queryParams := url.Values{
+		constants.SysQueryParam:       {fmt.Sprintf("%s=%s^ORDERBY%s", constants.FieldRequest, requestID, constants.FieldNumber)},
+		constants.SysQueryParamFields: {fmt.Sprintf("%s,%s,%s", constants.FieldSysID, constants.FieldNumber, constants.FieldShortDescription)},
+	}
+
+	items := &serializer.ServiceNowPartialRecordsResult{}
+	url := strings.Replace(constants.PathGetRecordsFromServiceNow, "{tableName}", constants.RecordTypeRequestedItem, 1)
+	_, statusCode, err := c.CallJSON(http.MethodGet, url, nil, items, queryParams)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	return items.Result, statusCode, nil
+}
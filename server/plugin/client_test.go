@@ -68,6 +68,59 @@ func TestActivateSubscriptions(t *testing.T) {
 	}
 }
 
+func TestActivateSubscriptionsWithStoredSecret(t *testing.T) {
+	defer monkey.UnpatchAll()
+	c := new(client)
+	c.plugin = &Plugin{}
+	c.plugin.setConfiguration(&configuration{
+		MattermostSiteURL: "https://test.mattermost.com",
+		WebhookSecret:     "newSecret",
+	})
+	for _, testCase := range []struct {
+		description    string
+		storedSecret   string
+		expectedMethod string
+		expectedPath   string
+	}{
+		{
+			description:  "ActivateSubscriptions: stored secret is the current secret",
+			storedSecret: "newSecret",
+		},
+		{
+			description:    "ActivateSubscriptions: stored secret is the previous secret",
+			storedSecret:   "previousSecret",
+			expectedMethod: http.MethodPatch,
+			expectedPath:   constants.PathActivateSubscriptions + "/mockSysID",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			var method, path string
+			var payload interface{}
+			monkey.PatchInstanceMethod(reflect.TypeOf(c), "CallJSON", func(_ *client, m, p string, in, out interface{}, params url.Values) (_ []byte, _ int, _ error) {
+				if m == http.MethodGet {
+					assert.Equal(t, "server_url=https://test.mattermost.com", params.Get(constants.SysQueryParam))
+					out.(*serializer.SubscriptionAuthDetails).Result = []*serializer.SubscriptionAuthPayload{
+						{SysID: "mockSysID", ServerURL: "https://test.mattermost.com", APISecret: testCase.storedSecret},
+					}
+					return nil, http.StatusOK, nil
+				}
+
+				method, path, payload = m, p, in
+				return nil, http.StatusOK, nil
+			})
+
+			statusCode, err := c.ActivateSubscriptions()
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+			assert.Equal(t, testCase.expectedMethod, method)
+			assert.Equal(t, testCase.expectedPath, path)
+			if testCase.expectedMethod != "" {
+				assert.Equal(t, serializer.SubscriptionAuthPayload{ServerURL: "https://test.mattermost.com", APISecret: "newSecret"}, payload)
+			}
+		})
+	}
+}
+
 func TestCreateSubscriptionClient(t *testing.T) {
 	defer monkey.UnpatchAll()
 	c := new(client)
@@ -266,7 +319,7 @@ func TestEditSubscriptionClient(t *testing.T) {
 	}
 }
 
-func TestCheckForDuplicateSubscription(t *testing.T) {
+func TestGetDuplicateSubscriptions(t *testing.T) {
 	defer monkey.UnpatchAll()
 	c := new(client)
 	for _, testCase := range []struct {
@@ -277,12 +330,12 @@ func TestCheckForDuplicateSubscription(t *testing.T) {
 		expectedErr        string
 	}{
 		{
-			description:        "CheckForDuplicateSubscription: valid",
+			description:        "GetDuplicateSubscriptions: valid",
 			statusCode:         http.StatusOK,
 			expectedStatusCode: http.StatusOK,
 		},
 		{
-			description:        "CheckForDuplicateSubscription: with error",
+			description:        "GetDuplicateSubscriptions: with error",
 			statusCode:         http.StatusInternalServerError,
 			expectedStatusCode: http.StatusInternalServerError,
 			errorMessage:       errors.New("mockError"),
@@ -298,7 +351,7 @@ func TestCheckForDuplicateSubscription(t *testing.T) {
 			mockRecordType := "mockRecordType"
 			mockRecordID := "mockRecordID"
 			mockServerURL := "mockServerURL"
-			_, statusCode, err := c.CheckForDuplicateSubscription(&serializer.SubscriptionPayload{
+			_, statusCode, err := c.GetDuplicateSubscriptions(&serializer.SubscriptionPayload{
 				ChannelID:  &mockChannelID,
 				Type:       &mockType,
 				RecordType: &mockRecordType,
@@ -321,6 +374,8 @@ func TestSearchRecordsInServiceNow(t *testing.T) {
 	c := new(client)
 	for _, testCase := range []struct {
 		description        string
+		searchTerm         string
+		expectedQuery      string
 		statusCode         int
 		expectedStatusCode int
 		errorMessage       error
@@ -328,11 +383,21 @@ func TestSearchRecordsInServiceNow(t *testing.T) {
 	}{
 		{
 			description:        "SearchRecordsInServiceNow: valid",
+			searchTerm:         "mockSearchItem",
+			expectedQuery:      "short_description LIKEmockSearchItem ^OR number STARTSWITHmockSearchItem^active=true",
+			statusCode:         http.StatusOK,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description:        "SearchRecordsInServiceNow: search term containing a caret",
+			searchTerm:         "mock^ORactive=false",
+			expectedQuery:      "short_description LIKEmock ORactive=false ^OR number STARTSWITHmock ORactive=false^active=true",
 			statusCode:         http.StatusOK,
 			expectedStatusCode: http.StatusOK,
 		},
 		{
 			description:        "SearchRecordsInServiceNow: with error",
+			searchTerm:         "mockSearchItem",
 			statusCode:         http.StatusInternalServerError,
 			expectedStatusCode: http.StatusInternalServerError,
 			errorMessage:       errors.New("error in searching the records"),
@@ -340,10 +405,13 @@ func TestSearchRecordsInServiceNow(t *testing.T) {
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
-			monkey.PatchInstanceMethod(reflect.TypeOf(c), "CallJSON", func(_ *client, _, _ string, _, _ interface{}, _ url.Values) (_ []byte, _ int, _ error) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(c), "CallJSON", func(_ *client, _, _ string, _, _ interface{}, queryParams url.Values) (_ []byte, _ int, _ error) {
+				if testCase.expectedQuery != "" {
+					assert.Equal(t, testCase.expectedQuery, queryParams.Get(constants.SysQueryParam))
+				}
 				return nil, testCase.statusCode, testCase.errorMessage
 			})
-			_, statusCode, err := c.SearchRecordsInServiceNow("mockTable", "mockSearchItem", "mockLimit", "mockOffset")
+			_, statusCode, err := c.SearchRecordsInServiceNow("mockTable", testCase.searchTerm, "mockLimit", "mockOffset", &serializer.RecordSearchFilters{ActiveOnly: true})
 			if testCase.expectedErr != "" {
 				assert.EqualError(t, err, testCase.expectedErr)
 			} else {
@@ -355,6 +423,74 @@ func TestSearchRecordsInServiceNow(t *testing.T) {
 	}
 }
 
+func TestGetChoicesClient(t *testing.T) {
+	defer monkey.UnpatchAll()
+	c := new(client)
+	for _, testCase := range []struct {
+		description    string
+		dependentValue string
+		expectedQuery  string
+	}{
+		{
+			description:   "GetChoices: without dependent value",
+			expectedQuery: "name=incident^element=subcategory^inactive=false^language=en^ORDERBYsequence",
+		},
+		{
+			description:    "GetChoices: with dependent value",
+			dependentValue: "network",
+			expectedQuery:  "name=incident^element=subcategory^inactive=false^language=en^dependent_value=network^ORDERBYsequence",
+		},
+		{
+			description:    "GetChoices: dependent value containing a caret",
+			dependentValue: "network^ORinactive=true",
+			expectedQuery:  "name=incident^element=subcategory^inactive=false^language=en^dependent_value=network ORinactive=true^ORDERBYsequence",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(c), "CallJSON", func(_ *client, _, _ string, _, _ interface{}, queryParams url.Values) (_ []byte, _ int, _ error) {
+				assert.Equal(t, testCase.expectedQuery, queryParams.Get(constants.SysQueryParam))
+				return nil, http.StatusOK, nil
+			})
+
+			_, statusCode, err := c.GetChoices(constants.RecordTypeIncident, constants.FieldSubcategory, testCase.dependentValue)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+		})
+	}
+}
+
+func TestSearchReferenceRecordsClient(t *testing.T) {
+	defer monkey.UnpatchAll()
+	c := new(client)
+	for _, testCase := range []struct {
+		description   string
+		searchTerm    string
+		expectedQuery string
+	}{
+		{
+			description:   "SearchReferenceRecords: valid",
+			searchTerm:    "Network",
+			expectedQuery: "nameLIKENetwork^ORDERBYname",
+		},
+		{
+			description:   "SearchReferenceRecords: search term containing a caret",
+			searchTerm:    "Network^ORname!=",
+			expectedQuery: "nameLIKENetwork ORname!=^ORDERBYname",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			monkey.PatchInstanceMethod(reflect.TypeOf(c), "CallJSON", func(_ *client, _, _ string, _, _ interface{}, queryParams url.Values) (_ []byte, _ int, _ error) {
+				assert.Equal(t, testCase.expectedQuery, queryParams.Get(constants.SysQueryParam))
+				return nil, http.StatusOK, nil
+			})
+
+			_, statusCode, err := c.SearchReferenceRecords(constants.TableUserGroup, testCase.searchTerm, "mockLimit", "mockOffset")
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, statusCode)
+		})
+	}
+}
+
 func TestGetRecordFromServiceNowClient(t *testing.T) {
 	defer monkey.UnpatchAll()
 	c := new(client)
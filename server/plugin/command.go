@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"strings"
 	"sync"
 	"unicode"
 
@@ -22,7 +23,11 @@ const (
 * |/servicenow connect| - Connect your Mattermost account to your ServiceNow account
 * |/servicenow disconnect| - Disconnect your Mattermost account from your ServiceNow account
 * |/servicenow subscriptions| - Manage your subscriptions to the record changes in ServiceNow
+* |/servicenow subscriptions add --personal [record type]| - Get notified in your DM with the bot when a record is assigned to you or your groups, or someone comments on a record opened by you. The record type defaults to incident
 * |/servicenow share| - Search a record in ServiceNow and share it in a channel
+* |/servicenow incident create| - Create an incident in ServiceNow. Pass a short description and optional flags, e.g. |/servicenow incident create "DB down" --description "..." --caller @username --urgency 1|, to create it without opening the modal
+* |/servicenow incident warroom [incident number]| - Create a channel for handling the incident with its assignee and assignment group, subscribed to the changes of the incident
+* |/servicenow kb [search term]| - Search the published knowledge articles in ServiceNow and post an article in the channel
 * |/servicenow help| - Know about the features of this plugin
 `
 
@@ -44,6 +49,11 @@ Within ServiceNow user roles, add the "x_830655_mm_std.user" role to any user wh
 - Then, search for the "x_830655_mm_std.user" role and add that role to the user's Roles list and click on "Save".
 
 After that, this user will have the permission to add or manage subscriptions from Mattermost.
+
+##### Failed notifications
+The notifications which could not be posted after being retried are kept as failed notifications.
+* |/servicenow notifications failed| - List the failed notifications
+* |/servicenow notifications replay [notification ID or "all"]| - Post the failed notifications again
 `
 
 	helpCommandHeader                       = "#### Mattermost ServiceNow Plugin - Slash Command Help\n"
@@ -63,6 +73,24 @@ After that, this user will have the permission to add or manage subscriptions fr
 	subscriptionsNotAuthorizedError         = "It seems that you are not authorized to manage subscriptions in ServiceNow."
 	subscriptionsNotAuthorizedErrorForUser  = subscriptionsNotAuthorizedError + " Please contact your system administrator."
 	subscriptionsNotAuthorizedErrorForAdmin = subscriptionsNotAuthorizedError + " Please follow the instructions for setting up user permissions available in the plugin's documentation. The instructions can also be viewed by running the \"/servicenow help\" command."
+	invalidIncidentCommandMessage           = "Unable to create the incident: %s. Please run `/servicenow help` for more information."
+	callerNotFoundMessage                   = "Unable to find the user @%s."
+	callerNotConnectedMessage               = "The user @%s has not connected their Mattermost account to ServiceNow, so they can't be set as the caller."
+	incidentCreatedMessage                  = "Incident %s has been created."
+	incidentCreatedButNotSharedMessage      = "Incident %s has been created, but it could not be shared in the channel."
+	invalidPersonalSubscriptionMessage      = "Unable to create the personal subscription: %s. Please run `/servicenow help` for more information."
+	personalSubscriptionExistsMessage       = "You already have a personal subscription for this record type."
+	personalSubscriptionCreatedMessage      = "Personal subscription created. You will be notified in your DM with the bot when a record is assigned to you or your groups, or someone comments on a record opened by you."
+	personalSubscriptionNotEditableMessage  = "Personal subscriptions can't be edited. Please delete the subscription and add it again."
+	invalidWarRoomCommandMessage            = "Unable to create the war room: %s. Please run `/servicenow help` for more information."
+	knowledgeArticlesFoundMessage           = "Knowledge articles matching \"%s\""
+	noKnowledgeArticlesFoundMessage         = "No published knowledge articles found matching \"%s\"."
+	invalidNotificationsCommandMessage      = "Invalid notifications command. Available commands are 'failed' and 'replay'."
+	notificationsNotPermittedMessage        = "Only system admins can manage the failed notifications."
+	noDeadLetterNotificationsMessage        = "There are no failed notifications."
+	replayNotificationIDMissingMessage      = "Please provide the ID of the failed notification to replay, or \"all\" to replay all of them."
+	deadLetterNotificationNotFoundMessage   = "No failed notification found with the ID %s."
+	notificationsReplayedMessage            = "%d failed notification(s) will be posted again."
 )
 
 type CommandHandleFunc func(c *plugin.Context, args *model.CommandArgs, parameters []string, client Client, isSysAdmin bool) string
@@ -126,6 +154,12 @@ func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 		return &model.CommandResponse{}, nil
 	}
 
+	// The failed notifications are managed without a connected ServiceNow account, as they are not related to one
+	if action == constants.CommandNotifications {
+		p.postCommandResponse(args, p.handleNotifications(parameters, isSysAdmin))
+		return &model.CommandResponse{}, nil
+	}
+
 	if f, ok := p.CommandHandlers[action]; ok {
 		user := p.checkConnected(args)
 		if user == nil {
@@ -133,11 +167,13 @@ func (p *Plugin) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 		}
 
 		var client Client
-		if action == constants.CommandSubscriptions || action == constants.CommandUnsubscribe {
+		if action == constants.CommandSubscriptions || action == constants.CommandUnsubscribe || action == constants.CommandIncident || action == constants.CommandKnowledgeBase {
 			if client = p.GetClientFromUser(args, user); client == nil {
 				return &model.CommandResponse{}, nil
 			}
+		}
 
+		if action == constants.CommandSubscriptions || action == constants.CommandUnsubscribe {
 			if _, err := client.ActivateSubscriptions(); err != nil {
 				p.API.LogError("Unable to check or activate subscriptions in ServiceNow.", "Error", err.Error())
 				p.postCommandResponse(args, p.handleClientError(nil, nil, err, isSysAdmin, 0, args.UserId, ""))
@@ -179,13 +215,64 @@ func (p *Plugin) GetClientFromUser(args *model.CommandArgs, user *serializer.Use
 		return nil
 	}
 
-	return p.NewClient(context.Background(), token)
+	return p.NewClient(context.Background(), token, user.MattermostUserID)
 }
 
 func (p *Plugin) handleHelp(args *model.CommandArgs, isSysAdmin bool) {
 	p.postCommandResponse(args, p.getHelpMessage(helpCommandHeader, isSysAdmin))
 }
 
+func (p *Plugin) handleNotifications(parameters []string, isSysAdmin bool) string {
+	if !isSysAdmin {
+		return notificationsNotPermittedMessage
+	}
+
+	if len(parameters) == 0 {
+		return invalidNotificationsCommandMessage
+	}
+
+	switch parameters[0] {
+	case constants.SubCommandFailed:
+		notifications, err := p.store.LoadDeadLetterNotifications()
+		if err != nil {
+			p.API.LogError(constants.ErrorLoadDeadLetterNotifications, "Error", err.Error())
+			return genericErrorMessage
+		}
+
+		if len(notifications) == 0 {
+			return noDeadLetterNotificationsMessage
+		}
+
+		return ParseDeadLetterNotificationsToCommandResponse(notifications)
+	case constants.SubCommandReplay:
+		if len(parameters) < 2 {
+			return replayNotificationIDMissingMessage
+		}
+
+		notificationID := parameters[1]
+		if notificationID == "all" {
+			notificationID = ""
+		}
+
+		replayed, err := p.replayDeadLetterNotifications(notificationID)
+		if err != nil {
+			p.API.LogError("Unable to replay the failed notifications", "Error", err.Error())
+			return genericErrorMessage
+		}
+
+		if replayed == 0 {
+			if notificationID == "" {
+				return noDeadLetterNotificationsMessage
+			}
+			return fmt.Sprintf(deadLetterNotificationNotFoundMessage, notificationID)
+		}
+
+		return fmt.Sprintf(notificationsReplayedMessage, replayed)
+	default:
+		return invalidNotificationsCommandMessage
+	}
+}
+
 func (p *Plugin) handleDisconnect(_ *plugin.Context, args *model.CommandArgs, _ []string, _ Client, _ bool) string {
 	if err := p.DisconnectUser(args.UserId); err != nil {
 		p.API.LogError("Unable to disconnect user", "Error", err.Error())
@@ -222,16 +309,22 @@ func (p *Plugin) handleSubscriptions(c *plugin.Context, args *model.CommandArgs,
 	}
 }
 
-func (p *Plugin) handleIncident(_ *plugin.Context, args *model.CommandArgs, parameters []string, _ Client, _ bool) string {
+func (p *Plugin) handleIncident(_ *plugin.Context, args *model.CommandArgs, parameters []string, client Client, isSysAdmin bool) string {
 	if len(parameters) == 0 {
-		return "Invalid incident command. Available command is 'create'."
+		return "Invalid incident command. Available commands are 'create' and 'warroom'."
 	}
 
 	command := parameters[0]
+	parameters = parameters[1:]
 
 	switch command {
 	case constants.SubCommandCreate:
-		return p.HandleCreateIncident(args)
+		if len(parameters) == 0 {
+			return p.HandleCreateIncident(args)
+		}
+		return p.handleCreateIncidentInline(args, parameters, client, isSysAdmin)
+	case constants.SubCommandWarRoom:
+		return p.handleWarRoom(args, parameters, client, isSysAdmin)
 	default:
 		return fmt.Sprintf("Unknown subcommand %v", command)
 	}
@@ -247,7 +340,130 @@ func (p *Plugin) HandleCreateIncident(args *model.CommandArgs) string {
 	return ""
 }
 
-func (p *Plugin) handleSubscribe(_ *plugin.Context, args *model.CommandArgs, _ []string, _ Client, _ bool) string {
+func (p *Plugin) handleCreateIncidentInline(args *model.CommandArgs, params []string, client Client, isSysAdmin bool) string {
+	incident, callerUsername, err := parseIncidentParams(params)
+	if err != nil {
+		return fmt.Sprintf(invalidIncidentCommandMessage, err.Error())
+	}
+
+	if callerUsername != "" {
+		callerUsername = strings.TrimPrefix(callerUsername, "@")
+		mattermostUser, appErr := p.API.GetUserByUsername(callerUsername)
+		if appErr != nil {
+			p.API.LogDebug("Unable to get the user by username", "Username", callerUsername, "Error", appErr.Error())
+			return fmt.Sprintf(callerNotFoundMessage, callerUsername)
+		}
+
+		caller, userErr := p.GetUser(mattermostUser.Id)
+		if userErr != nil || caller.ServiceNowUser == nil {
+			return fmt.Sprintf(callerNotConnectedMessage, callerUsername)
+		}
+
+		incident.Caller = caller.ServiceNowUser.UserID
+	}
+
+	incident.ChannelID = args.ChannelId
+	if err = incident.IsValid(); err != nil {
+		return fmt.Sprintf(invalidIncidentCommandMessage, err.Error())
+	}
+
+	go func() {
+		response, _, err := client.CreateIncident(incident)
+		if err != nil {
+			p.API.LogError(constants.APIErrorCreateIncident, "Error", err.Error())
+			p.postCommandResponse(args, p.handleClientError(nil, nil, err, isSysAdmin, 0, args.UserId, ""))
+			return
+		}
+
+		if _, err = p.ShareCreatedIncident(args.ChannelId, response); err != nil {
+			p.API.LogError(constants.ErrorHandlingNestedFields, "Error", err.Error())
+			p.postCommandResponse(args, fmt.Sprintf(incidentCreatedButNotSharedMessage, response.Number))
+			return
+		}
+
+		p.postCommandResponse(args, fmt.Sprintf(incidentCreatedMessage, response.Number))
+		p.SuggestKnowledgeArticles(client, args.UserId, args.ChannelId, response)
+	}()
+
+	return genericWaitMessage
+}
+
+func (p *Plugin) handleWarRoom(args *model.CommandArgs, params []string, client Client, isSysAdmin bool) string {
+	if len(params) != 1 {
+		return fmt.Sprintf(invalidWarRoomCommandMessage, constants.ErrorInvalidIncidentNumber)
+	}
+
+	number := strings.ToUpper(trimQuotes(params[0]))
+	if !incidentNumberRegex.MatchString(number) {
+		return fmt.Sprintf(invalidWarRoomCommandMessage, constants.ErrorInvalidIncidentNumber)
+	}
+
+	go func() {
+		incident, _, err := client.GetRecordByNumber(constants.RecordTypeIncident, number)
+		if err != nil {
+			p.API.LogError(constants.ErrorGetRecord, "Number", number, "Error", err.Error())
+			p.postCommandResponse(args, p.handleClientError(nil, nil, err, isSysAdmin, 0, args.UserId, ""))
+			return
+		}
+
+		if incident == nil {
+			p.postCommandResponse(args, fmt.Sprintf(constants.ErrorIncidentNotFound, number))
+			return
+		}
+
+		p.postCommandResponse(args, p.createWarRoomResponse(client, args.UserId, args.TeamId, incident))
+	}()
+
+	return genericWaitMessage
+}
+
+func (p *Plugin) handleKnowledgeBase(_ *plugin.Context, args *model.CommandArgs, params []string, client Client, isSysAdmin bool) string {
+	searchTerms := make([]string, 0, len(params))
+	for _, param := range params {
+		searchTerms = append(searchTerms, trimQuotes(param))
+	}
+
+	searchTerm := strings.TrimSpace(strings.Join(searchTerms, " "))
+	if len(searchTerm) < constants.CharacterThresholdForSearchingRecords {
+		return fmt.Sprintf(constants.ErrorSearchTermThreshold, constants.CharacterThresholdForSearchingRecords)
+	}
+
+	go func() {
+		articles, _, err := p.SearchKnowledgeArticles(client, searchTerm, constants.MaxKnowledgeSearchResults, false)
+		if err != nil {
+			p.API.LogError(constants.ErrorSearchingRecord, "Error", err.Error())
+			p.postCommandResponse(args, p.handleClientError(nil, nil, err, isSysAdmin, 0, args.UserId, ""))
+			return
+		}
+
+		if len(articles) == 0 {
+			p.postCommandResponse(args, fmt.Sprintf(noKnowledgeArticlesFoundMessage, searchTerm))
+			return
+		}
+
+		attachments := make([]*model.SlackAttachment, 0, len(articles))
+		for _, article := range articles {
+			attachments = append(attachments, article.CreateKnowledgeSearchResultAttachment(p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL()))
+		}
+
+		post := &model.Post{
+			UserId:    p.botID,
+			ChannelId: args.ChannelId,
+			RootId:    args.RootId,
+			Message:   fmt.Sprintf(knowledgeArticlesFoundMessage, searchTerm),
+		}
+		model.ParseSlackAttachment(post, attachments)
+		_ = p.API.SendEphemeralPost(args.UserId, post)
+	}()
+
+	return genericWaitMessage
+}
+
+func (p *Plugin) handleSubscribe(_ *plugin.Context, args *model.CommandArgs, params []string, client Client, isSysAdmin bool) string {
+	if len(params) > 0 && params[0] == "--"+constants.FlagPersonal {
+		return p.handlePersonalSubscribe(args, params[1:], client, isSysAdmin)
+	}
+
 	p.API.PublishWebSocketEvent(
 		constants.WSEventOpenAddSubscriptionModal,
 		nil,
@@ -257,6 +473,68 @@ func (p *Plugin) handleSubscribe(_ *plugin.Context, args *model.CommandArgs, _ [
 	return ""
 }
 
+// handlePersonalSubscribe creates a personal subscription, whose notifications are delivered in the DM of the user with the bot
+func (p *Plugin) handlePersonalSubscribe(args *model.CommandArgs, params []string, client Client, isSysAdmin bool) string {
+	recordType := constants.RecordTypeIncident
+	if len(params) > 0 {
+		recordType = params[0]
+	}
+
+	channel, appErr := p.API.GetDirectChannel(args.UserId, p.botID)
+	if appErr != nil {
+		p.API.LogError(constants.ErrorGetBotChannel, "UserID", args.UserId, "Error", appErr.Error())
+		return genericErrorMessage
+	}
+
+	subscriptionType := constants.SubscriptionTypePersonal
+	subscriptionEvents := constants.PersonalSubscriptionEvents
+	serverURL := p.getConfiguration().MattermostSiteURL
+	isActive := true
+	subscription := &serializer.SubscriptionPayload{
+		UserID:             &args.UserId,
+		ChannelID:          &channel.Id,
+		Type:               &subscriptionType,
+		RecordType:         &recordType,
+		IsActive:           &isActive,
+		SubscriptionEvents: &subscriptionEvents,
+		ServerURL:          &serverURL,
+	}
+
+	if err := subscription.IsValidForCreation(serverURL, p.getConfiguration().RecordTypeRegistry); err != nil {
+		return fmt.Sprintf(invalidPersonalSubscriptionMessage, err.Error())
+	}
+
+	go func() {
+		exists, _, err := p.IsDuplicateSubscription(client, subscription, nil)
+		if err != nil {
+			p.API.LogError("Error in checking for duplicate subscription", "Error", err.Error())
+			p.postCommandResponse(args, p.handleClientError(nil, nil, err, isSysAdmin, 0, args.UserId, ""))
+			return
+		}
+
+		if exists {
+			p.postCommandResponse(args, personalSubscriptionExistsMessage)
+			return
+		}
+
+		resp, _, err := client.CreateSubscription(subscription)
+		if err != nil {
+			p.API.LogError("Error in creating subscription", "Error", err.Error())
+			p.postCommandResponse(args, p.handleClientError(nil, nil, err, isSysAdmin, 0, args.UserId, ""))
+			return
+		}
+
+		post := resp.CreateSubscriptionCreatedPost(p.botID, p.getConfiguration().ServiceNowBaseURL)
+		if _, postErr := p.API.CreatePost(post); postErr != nil {
+			p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
+		}
+
+		p.postCommandResponse(args, personalSubscriptionCreatedMessage)
+	}()
+
+	return genericWaitMessage
+}
+
 func (p *Plugin) handleSearchAndShare(_ *plugin.Context, args *model.CommandArgs, _ []string, _ Client, _ bool) string {
 	p.API.PublishWebSocketEvent(
 		constants.WSEventOpenSearchAndShareRecordsModal,
@@ -302,6 +580,14 @@ func (p *Plugin) handleListSubscriptions(_ *plugin.Context, args *model.CommandA
 		}
 
 		for _, subscription := range subscriptions {
+			// Personal subscriptions are delivered in the DM of the subscriber with the bot, so they are listed only to the subscriber
+			if subscription.Type == constants.SubscriptionTypePersonal {
+				if subscription.UserID == args.UserId {
+					subscriptionList = append(subscriptionList, subscription)
+				}
+				continue
+			}
+
 			_, permissionErr := p.HasPublicOrPrivateChannelPermissions(args.UserId, subscription.ChannelID)
 			if permissionErr == nil {
 				subscriptionList = append(subscriptionList, subscription)
@@ -335,15 +621,18 @@ func (p *Plugin) handleListSubscriptions(_ *plugin.Context, args *model.CommandA
 				}
 			}(subscription)
 
-			if subscription.Type == constants.SubscriptionTypeBulk {
-				continue
+			switch subscription.Type {
+			case constants.SubscriptionTypeBulk:
+				p.LoadSubscriptionFilters(subscription)
+				p.LoadSubscriptionDeliveryMode(subscription)
+			case constants.SubscriptionTypeRecord:
+				wg.Add(1)
+				go p.GetRecordFromServiceNowForSubscription(subscription, client, &wg)
 			}
-			wg.Add(1)
-			go p.GetRecordFromServiceNowForSubscription(subscription, client, &wg)
 		}
 
 		wg.Wait()
-		p.postCommandResponse(args, ParseSubscriptionsToCommandResponse(subscriptionList))
+		p.postCommandResponse(args, ParseSubscriptionsToCommandResponse(subscriptionList, p.getConfiguration().RecordTypeRegistry))
 	}()
 
 	return listSubscriptionsWaitMessage
@@ -378,6 +667,8 @@ func (p *Plugin) handleDeleteSubscription(_ *plugin.Context, args *model.Command
 			return
 		}
 
+		p.deleteSubscriptionData(subscriptionID)
+
 		p.API.PublishWebSocketEvent(
 			constants.WSEventSubscriptionDeleted,
 			nil,
@@ -411,8 +702,15 @@ func (p *Plugin) handleEditSubscription(_ *plugin.Context, args *model.CommandAr
 		return p.handleClientError(nil, nil, err, isSysAdmin, 0, args.UserId, "")
 	}
 
+	if subscription.Type == constants.SubscriptionTypePersonal {
+		return personalSubscriptionNotEditableMessage
+	}
+
 	if subscription.Type == constants.SubscriptionTypeRecord {
 		p.GetRecordFromServiceNowForSubscription(subscription, client, nil)
+	} else {
+		p.LoadSubscriptionFilters(subscription)
+		p.LoadSubscriptionDeliveryMode(subscription)
 	}
 
 	subscriptionMap, err := ConvertSubscriptionToMap(subscription)
@@ -431,7 +729,7 @@ func (p *Plugin) handleEditSubscription(_ *plugin.Context, args *model.CommandAr
 }
 
 func getAutocompleteData() *model.AutocompleteData {
-	serviceNow := model.NewAutocompleteData(constants.CommandTrigger, "[command]", fmt.Sprintf("Available commands: %s, %s, %s, %s, %s, %s", constants.CommandConnect, constants.CommandDisconnect, constants.CommandSubscriptions, constants.CommandSearchAndShare, constants.CommandIncident, constants.CommandHelp))
+	serviceNow := model.NewAutocompleteData(constants.CommandTrigger, "[command]", fmt.Sprintf("Available commands: %s, %s, %s, %s, %s, %s, %s", constants.CommandConnect, constants.CommandDisconnect, constants.CommandSubscriptions, constants.CommandSearchAndShare, constants.CommandIncident, constants.CommandKnowledgeBase, constants.CommandHelp))
 
 	connect := model.NewAutocompleteData(constants.CommandConnect, "", "Connect your Mattermost account to your ServiceNow account")
 	serviceNow.AddCommand(connect)
@@ -452,6 +750,9 @@ func getAutocompleteData() *model.AutocompleteData {
 	subscriptions.AddCommand(subscribeList)
 
 	subscriptionsAdd := model.NewAutocompleteData(constants.SubCommandAdd, "", "Subscribe to the record changes in ServiceNow")
+	subscriptionsAddPersonal := model.NewAutocompleteData("--"+constants.FlagPersonal, "[record type]", "Get notified in your DM when a record is assigned to you or your groups, or someone comments on a record opened by you")
+	subscriptionsAddPersonal.AddTextArgument("Type of the records, defaults to incident", "[record type]", "")
+	subscriptionsAdd.AddCommand(subscriptionsAddPersonal)
 	subscriptions.AddCommand(subscriptionsAdd)
 
 	subscriptionsEdit := model.NewAutocompleteData(constants.SubCommandEdit, "[subscription_id]", "Edit the subscriptions created to the record changes in ServiceNow")
@@ -467,11 +768,34 @@ func getAutocompleteData() *model.AutocompleteData {
 	searchRecords := model.NewAutocompleteData(constants.CommandSearchAndShare, "", "Search and share a ServiceNow record")
 	serviceNow.AddCommand(searchRecords)
 
-	incident := model.NewAutocompleteData(constants.CommandIncident, "[command]", fmt.Sprintf("Available command: %s", constants.SubCommandCreate))
-	incidentCreate := model.NewAutocompleteData(constants.SubCommandCreate, "", "Create an incident")
+	incident := model.NewAutocompleteData(constants.CommandIncident, "[command]", fmt.Sprintf("Available commands: %s, %s", constants.SubCommandCreate, constants.SubCommandWarRoom))
+	incidentCreate := model.NewAutocompleteData(constants.SubCommandCreate, "[short description]", "Create an incident. Leave the arguments empty to open the modal for creating an incident")
+	incidentCreate.AddTextArgument("Short description of the incident, in double quotes", "[short description]", "")
+	incidentCreate.AddNamedTextArgument(constants.FlagDescription, "Description of the incident, in double quotes", "[description]", "", false)
+	incidentCreate.AddNamedTextArgument(constants.FlagCaller, "Mattermost username of the caller", "[@username]", "", false)
+	incidentCreate.AddNamedStaticListArgument(constants.FlagUrgency, "Urgency of the incident", false, []model.AutocompleteListItem{
+		{Item: "1", HelpText: "High"},
+		{Item: "2", HelpText: "Medium"},
+		{Item: "3", HelpText: "Low"},
+	})
 	incident.AddCommand(incidentCreate)
+	incidentWarRoom := model.NewAutocompleteData(constants.SubCommandWarRoom, "[incident number]", "Create a channel for handling the incident with its assignee and assignment group")
+	incidentWarRoom.AddTextArgument("Number of the incident", "[incident number]", "")
+	incident.AddCommand(incidentWarRoom)
 	serviceNow.AddCommand(incident)
 
+	knowledgeBase := model.NewAutocompleteData(constants.CommandKnowledgeBase, "[search term]", "Search the published knowledge articles in ServiceNow")
+	serviceNow.AddCommand(knowledgeBase)
+
+	notifications := model.NewAutocompleteData(constants.CommandNotifications, "[command]", fmt.Sprintf("Available commands: %s, %s", constants.SubCommandFailed, constants.SubCommandReplay))
+	notifications.RoleID = model.SystemAdminRoleId
+	notificationsFailed := model.NewAutocompleteData(constants.SubCommandFailed, "", "List the notifications which could not be posted")
+	notifications.AddCommand(notificationsFailed)
+	notificationsReplay := model.NewAutocompleteData(constants.SubCommandReplay, "[notification ID]", "Post the failed notifications again")
+	notificationsReplay.AddTextArgument("ID of the failed notification, or \"all\"", "[notification ID]", "")
+	notifications.AddCommand(notificationsReplay)
+	serviceNow.AddCommand(notifications)
+
 	help := model.NewAutocompleteData(constants.CommandHelp, "", "Display slash command help text")
 	serviceNow.AddCommand(help)
 
@@ -486,9 +810,9 @@ func parseCommand(input string) (command, action string, parameters []string) {
 
 	for _, char := range input {
 		if unicode.IsSpace(char) {
-			// keep whitespaces that are inside double qoutes
+			// keep whitespaces that are inside double qoutes as they are, like the new lines of a multi-line description
 			if inQuotes {
-				current += " "
+				current += string(char)
 				continue
 			}
 
@@ -529,6 +853,48 @@ func parseCommand(input string) (command, action string, parameters []string) {
 	return command, action, parameters
 }
 
+// parseIncidentParams parses the short description and the flags passed to the incident create command
+func parseIncidentParams(params []string) (incident *serializer.IncidentPayload, callerUsername string, err error) {
+	incident = &serializer.IncidentPayload{}
+	var shortDescription []string
+	for i := 0; i < len(params); i++ {
+		if !strings.HasPrefix(params[i], "--") {
+			shortDescription = append(shortDescription, trimQuotes(params[i]))
+			continue
+		}
+
+		flag := strings.TrimPrefix(params[i], "--")
+		if i+1 == len(params) {
+			return nil, "", fmt.Errorf("missing value for the flag --%s", flag)
+		}
+
+		i++
+		value := trimQuotes(params[i])
+		switch flag {
+		case constants.FlagDescription:
+			incident.Description = value
+		case constants.FlagCaller:
+			callerUsername = value
+		case constants.FlagUrgency:
+			incident.Urgency = value
+		default:
+			return nil, "", fmt.Errorf("unknown flag --%s", flag)
+		}
+	}
+
+	incident.ShortDescription = strings.Join(shortDescription, " ")
+	return incident, callerUsername, nil
+}
+
+// trimQuotes removes the double quotes surrounding a parameter parsed by parseCommand
+func trimQuotes(param string) string {
+	if len(param) >= 2 && strings.HasPrefix(param, `"`) && strings.HasSuffix(param, `"`) {
+		return param[1 : len(param)-1]
+	}
+
+	return param
+}
+
 func (p *Plugin) postCommandResponse(args *model.CommandArgs, text string) {
 	p.Ephemeral(args.UserId, args.ChannelId, args.RootId, text)
 }
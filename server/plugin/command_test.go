@@ -424,15 +424,34 @@ func TestHandleSubscribe(t *testing.T) {
 	}
 	for _, testCase := range []struct {
 		description   string
+		params        []string
 		setupAPI      func(*plugintest.API)
 		expectedError string
 	}{
 		{
 			description: "HandleSubscribe: Success",
+			params:      []string{},
 			setupAPI: func(a *plugintest.API) {
 				a.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return()
 			},
 		},
+		{
+			description: "HandleSubscribe: Personal subscription for an invalid record type",
+			params:      []string{"--personal", "invalid"},
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetDirectChannel", testutils.GetID(), mock.AnythingOfType("string")).Return(&model.Channel{Id: testutils.GetChannelID()}, nil).Once()
+			},
+			expectedError: fmt.Sprintf(invalidPersonalSubscriptionMessage, "recordType is not valid"),
+		},
+		{
+			description: "HandleSubscribe: Failed to get the DM channel for a personal subscription",
+			params:      []string{"--personal"},
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetDirectChannel", testutils.GetID(), mock.AnythingOfType("string")).Return(nil, testutils.GetInternalServerAppError()).Once()
+				a.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return().Once()
+			},
+			expectedError: genericErrorMessage,
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			defer mockAPI.AssertExpectations(t)
@@ -440,7 +459,7 @@ func TestHandleSubscribe(t *testing.T) {
 			testCase.setupAPI(mockAPI)
 			p.SetAPI(mockAPI)
 
-			resp := p.handleSubscribe(&plugin.Context{}, args, []string{}, mock_plugin.NewClient(t), true)
+			resp := p.handleSubscribe(&plugin.Context{}, args, testCase.params, mock_plugin.NewClient(t), true)
 
 			assert.EqualValues(testCase.expectedError, resp)
 		})
@@ -491,6 +510,7 @@ func TestHandleListSubscriptions(t *testing.T) {
 		setupAPI         func(*plugintest.API)
 		setupClient      func(client *mock_plugin.Client)
 		setupPlugin      func(p *Plugin)
+		setupStore       func(s *mock_plugin.Store)
 		isResponse       bool
 		expectedResponse string
 		expectedError    string
@@ -501,6 +521,7 @@ func TestHandleListSubscriptions(t *testing.T) {
 			setupAPI:      func(a *plugintest.API) {},
 			setupClient:   func(client *mock_plugin.Client) {},
 			setupPlugin:   func(p *Plugin) {},
+			setupStore:    func(s *mock_plugin.Store) {},
 			expectedError: "Unknown filter invalid",
 		},
 		{
@@ -509,6 +530,7 @@ func TestHandleListSubscriptions(t *testing.T) {
 			setupAPI:      func(a *plugintest.API) {},
 			setupClient:   func(client *mock_plugin.Client) {},
 			setupPlugin:   func(p *Plugin) {},
+			setupStore:    func(s *mock_plugin.Store) {},
 			expectedError: "Unknown filter invalid",
 		},
 		{
@@ -523,6 +545,7 @@ func TestHandleListSubscriptions(t *testing.T) {
 				)
 			},
 			setupPlugin:      func(p *Plugin) {},
+			setupStore:       func(s *mock_plugin.Store) {},
 			isResponse:       true,
 			expectedResponse: genericErrorMessage,
 			expectedError:    listSubscriptionsWaitMessage,
@@ -539,6 +562,7 @@ func TestHandleListSubscriptions(t *testing.T) {
 				)
 			},
 			setupPlugin:      func(p *Plugin) {},
+			setupStore:       func(s *mock_plugin.Store) {},
 			isResponse:       true,
 			expectedResponse: constants.ErrorNoActiveSubscriptions,
 			expectedError:    listSubscriptionsWaitMessage,
@@ -568,8 +592,12 @@ func TestHandleListSubscriptions(t *testing.T) {
 					return http.StatusOK, nil
 				})
 			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+				s.On("LoadSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+			},
 			isResponse:       true,
-			expectedResponse: fmt.Sprintf("#### Bulk subscriptions\n| Subscription ID | Record Type | Events | Created By | Channel |\n| :----|:--------| :--------|:--------|:--------|\n|%s|Problem|Priority changed, State changed|N/A|N/A|\n#### Record subscriptions\n| Subscription ID | Record Type | Record Number | Record Short Description | Events | Created By | Channel |\n| :----|:--------| :--------| :-----| :--------|:--------|:--------|\n|%s|Problem|PRB0000005|Test description|Priority changed, State changed|N/A|N/A|", testutils.GetServiceNowSysID(), testutils.GetServiceNowSysID()),
+			expectedResponse: fmt.Sprintf("#### Bulk subscriptions\n| Subscription ID | Record Type | Events | Filters | Delivery | Created By | Channel |\n| :----|:--------| :--------| :--------| :--------|:--------|:--------|\n|%s|Problem|Priority changed, State changed|N/A|Immediate|N/A|N/A|\n#### Record subscriptions\n| Subscription ID | Record Type | Record Number | Record Short Description | Events | Created By | Channel |\n| :----|:--------| :--------| :-----| :--------|:--------|:--------|\n|%s|Problem|PRB0000005|Test description|Priority changed, State changed|N/A|N/A|", testutils.GetServiceNowSysID(), testutils.GetServiceNowSysID()),
 			expectedError:    listSubscriptionsWaitMessage,
 		},
 		{
@@ -593,6 +621,7 @@ func TestHandleListSubscriptions(t *testing.T) {
 					return http.StatusInternalServerError, fmt.Errorf(constants.ErrorChannelPermissionsForUser)
 				})
 			},
+			setupStore:       func(s *mock_plugin.Store) {},
 			isResponse:       true,
 			expectedResponse: constants.ErrorNoActiveSubscriptions,
 			expectedError:    listSubscriptionsWaitMessage,
@@ -619,6 +648,7 @@ func TestHandleListSubscriptions(t *testing.T) {
 					return http.StatusBadRequest, fmt.Errorf(constants.ErrorInsufficientPermissions)
 				})
 			},
+			setupStore:       func(s *mock_plugin.Store) {},
 			isResponse:       true,
 			expectedResponse: constants.ErrorNoActiveSubscriptions,
 			expectedError:    listSubscriptionsWaitMessage,
@@ -647,8 +677,12 @@ func TestHandleListSubscriptions(t *testing.T) {
 					return http.StatusOK, nil
 				})
 			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+				s.On("LoadSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+			},
 			isResponse:       true,
-			expectedResponse: fmt.Sprintf("#### Bulk subscriptions\n| Subscription ID | Record Type | Events | Created By | Channel |\n| :----|:--------| :--------|:--------|:--------|\n|%s|Problem|Priority changed, State changed|N/A|N/A|\n#### Record subscriptions\n| Subscription ID | Record Type | Record Number | Record Short Description | Events | Created By | Channel |\n| :----|:--------| :--------| :-----| :--------|:--------|:--------|\n|%s|Problem|PRB0000005|Test description|Priority changed, State changed|N/A|N/A|", testutils.GetServiceNowSysID(), testutils.GetServiceNowSysID()),
+			expectedResponse: fmt.Sprintf("#### Bulk subscriptions\n| Subscription ID | Record Type | Events | Filters | Delivery | Created By | Channel |\n| :----|:--------| :--------| :--------| :--------|:--------|:--------|\n|%s|Problem|Priority changed, State changed|N/A|Immediate|N/A|N/A|\n#### Record subscriptions\n| Subscription ID | Record Type | Record Number | Record Short Description | Events | Created By | Channel |\n| :----|:--------| :--------| :-----| :--------|:--------|:--------|\n|%s|Problem|PRB0000005|Test description|Priority changed, State changed|N/A|N/A|", testutils.GetServiceNowSysID(), testutils.GetServiceNowSysID()),
 			expectedError:    listSubscriptionsWaitMessage,
 		},
 	} {
@@ -656,10 +690,13 @@ func TestHandleListSubscriptions(t *testing.T) {
 			defer mockAPI.AssertExpectations(t)
 			assert := assert.New(t)
 			c := mock_plugin.NewClient(t)
+			s := mock_plugin.NewStore(t)
 			testCase.setupAPI(mockAPI)
 			testCase.setupClient(c)
 			testCase.setupPlugin(&p)
+			testCase.setupStore(s)
 			p.SetAPI(mockAPI)
+			p.store = s
 
 			if testCase.isResponse {
 				mockAPI.On("SendEphemeralPost", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
@@ -688,6 +725,7 @@ func TestHandleDeleteSubscription(t *testing.T) {
 		params           []string
 		setupAPI         func(*plugintest.API)
 		setupClient      func(client *mock_plugin.Client)
+		setupStore       func(s *mock_plugin.Store)
 		isResponse       bool
 		expectedResponse string
 		expectedError    string
@@ -703,6 +741,12 @@ func TestHandleDeleteSubscription(t *testing.T) {
 					0, nil,
 				)
 			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("DeleteSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("DeleteSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("LoadNotificationDigests").Return([]*serializer.NotificationDigest{}, nil)
+				s.On("DeleteCachedSubscription", testutils.GetServiceNowSysID()).Return(nil)
+			},
 			isResponse:       true,
 			expectedResponse: deleteSubscriptionSuccessMessage,
 			expectedError:    genericWaitMessage,
@@ -711,6 +755,7 @@ func TestHandleDeleteSubscription(t *testing.T) {
 			description:   "HandleDeleteSubscription: Invalid number of params",
 			setupAPI:      func(a *plugintest.API) {},
 			setupClient:   func(client *mock_plugin.Client) {},
+			setupStore:    func(s *mock_plugin.Store) {},
 			expectedError: constants.ErrorCommandInvalidNumberOfParams,
 		},
 		{
@@ -718,6 +763,7 @@ func TestHandleDeleteSubscription(t *testing.T) {
 			params:           []string{"invalidID"},
 			setupAPI:         func(a *plugintest.API) {},
 			setupClient:      func(client *mock_plugin.Client) {},
+			setupStore:       func(s *mock_plugin.Store) {},
 			isResponse:       true,
 			expectedResponse: invalidSubscriptionIDMessage,
 			expectedError:    genericWaitMessage,
@@ -733,18 +779,44 @@ func TestHandleDeleteSubscription(t *testing.T) {
 					0, errors.New("unable to delete the subscription"),
 				)
 			},
+			setupStore:       func(s *mock_plugin.Store) {},
 			isResponse:       true,
 			expectedResponse: genericErrorMessage,
 			expectedError:    genericWaitMessage,
 		},
+		{
+			description: "HandleDeleteSubscription: Unable to delete the subscription filters",
+			params:      []string{testutils.GetServiceNowSysID()},
+			setupAPI: func(a *plugintest.API) {
+				a.On("LogWarn", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+				a.On("PublishWebSocketEvent", mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return()
+			},
+			setupClient: func(client *mock_plugin.Client) {
+				client.On("DeleteSubscription", testutils.GetServiceNowSysID()).Return(
+					0, nil,
+				)
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("DeleteSubscriptionFilters", testutils.GetServiceNowSysID()).Return(errors.New("unable to delete the subscription filters"))
+				s.On("DeleteSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return(nil)
+				s.On("LoadNotificationDigests").Return([]*serializer.NotificationDigest{}, nil)
+				s.On("DeleteCachedSubscription", testutils.GetServiceNowSysID()).Return(nil)
+			},
+			isResponse:       true,
+			expectedResponse: deleteSubscriptionSuccessMessage,
+			expectedError:    genericWaitMessage,
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			defer mockAPI.AssertExpectations(t)
 			assert := assert.New(t)
 			c := mock_plugin.NewClient(t)
+			s := mock_plugin.NewStore(t)
 			testCase.setupAPI(mockAPI)
 			testCase.setupClient(c)
+			testCase.setupStore(s)
 			p.SetAPI(mockAPI)
+			p.store = s
 
 			if testCase.isResponse {
 				mockAPI.On("SendEphemeralPost", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
@@ -771,6 +843,7 @@ func TestHandleEditSubscription(t *testing.T) {
 		params        []string
 		setupAPI      func(*plugintest.API)
 		setupClient   func(client *mock_plugin.Client)
+		setupStore    func(s *mock_plugin.Store)
 		expectedError string
 	}{
 		{
@@ -784,11 +857,16 @@ func TestHandleEditSubscription(t *testing.T) {
 					testutils.GetSubscription(constants.SubscriptionTypeBulk), 0, nil,
 				)
 			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+				s.On("LoadSubscriptionDeliveryMode", testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+			},
 		},
 		{
 			description:   "HandleEditSubscription: Invalid number of params",
 			setupAPI:      func(a *plugintest.API) {},
 			setupClient:   func(client *mock_plugin.Client) {},
+			setupStore:    func(s *mock_plugin.Store) {},
 			expectedError: constants.ErrorCommandInvalidNumberOfParams,
 		},
 		{
@@ -796,6 +874,7 @@ func TestHandleEditSubscription(t *testing.T) {
 			params:        []string{"invalidID"},
 			setupAPI:      func(a *plugintest.API) {},
 			setupClient:   func(client *mock_plugin.Client) {},
+			setupStore:    func(s *mock_plugin.Store) {},
 			expectedError: invalidSubscriptionIDMessage,
 		},
 		{
@@ -809,6 +888,7 @@ func TestHandleEditSubscription(t *testing.T) {
 					nil, 0, errors.New("unable to get the subscription"),
 				)
 			},
+			setupStore:    func(s *mock_plugin.Store) {},
 			expectedError: genericErrorMessage,
 		},
 	} {
@@ -816,9 +896,12 @@ func TestHandleEditSubscription(t *testing.T) {
 			defer mockAPI.AssertExpectations(t)
 			assert := assert.New(t)
 			c := mock_plugin.NewClient(t)
+			s := mock_plugin.NewStore(t)
 			testCase.setupAPI(mockAPI)
 			testCase.setupClient(c)
+			testCase.setupStore(s)
 			p.SetAPI(mockAPI)
+			p.store = s
 
 			resp := p.handleEditSubscription(&plugin.Context{}, args, testCase.params, c, true)
 
@@ -827,6 +910,409 @@ func TestHandleEditSubscription(t *testing.T) {
 	}
 }
 
+func TestHandleIncident(t *testing.T) {
+	defer monkey.UnpatchAll()
+	p := Plugin{}
+	mockAPI := &plugintest.API{}
+	args := &model.CommandArgs{
+		UserId:    testutils.GetID(),
+		ChannelId: testutils.GetChannelID(),
+	}
+	for _, testCase := range []struct {
+		description      string
+		params           []string
+		setupAPI         func(*plugintest.API)
+		setupClient      func(client *mock_plugin.Client)
+		setupPlugin      func()
+		isResponse       bool
+		expectedResponse string
+		expectedError    string
+	}{
+		{
+			description: "HandleIncident: Open the create incident modal",
+			params:      []string{constants.SubCommandCreate},
+			setupAPI: func(a *plugintest.API) {
+				a.On("PublishWebSocketEvent", constants.WSEventOpenCreateIncidentModal, mock.Anything, mock.AnythingOfType("*model.WebsocketBroadcast")).Return()
+			},
+			setupClient: func(client *mock_plugin.Client) {},
+			setupPlugin: func() {},
+		},
+		{
+			description:   "HandleIncident: Unknown flag",
+			params:        []string{constants.SubCommandCreate, `"DB down"`, "--impact", "1"},
+			setupAPI:      func(a *plugintest.API) {},
+			setupClient:   func(client *mock_plugin.Client) {},
+			setupPlugin:   func() {},
+			expectedError: fmt.Sprintf(invalidIncidentCommandMessage, "unknown flag --impact"),
+		},
+		{
+			description:   "HandleIncident: Empty short description",
+			params:        []string{constants.SubCommandCreate, "--urgency", "1"},
+			setupAPI:      func(a *plugintest.API) {},
+			setupClient:   func(client *mock_plugin.Client) {},
+			setupPlugin:   func() {},
+			expectedError: fmt.Sprintf(invalidIncidentCommandMessage, constants.ErrorEmptyShortDescription),
+		},
+		{
+			description:   "HandleIncident: Invalid urgency",
+			params:        []string{constants.SubCommandCreate, `"DB down"`, "--urgency", "5"},
+			setupAPI:      func(a *plugintest.API) {},
+			setupClient:   func(client *mock_plugin.Client) {},
+			setupPlugin:   func() {},
+			expectedError: fmt.Sprintf(invalidIncidentCommandMessage, constants.ErrorInvalidUrgency),
+		},
+		{
+			description: "HandleIncident: Caller not found",
+			params:      []string{constants.SubCommandCreate, `"DB down"`, "--caller", "@alice"},
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetUserByUsername", "alice").Return(nil, testutils.GetInternalServerAppError())
+				a.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			setupClient:   func(client *mock_plugin.Client) {},
+			setupPlugin:   func() {},
+			expectedError: fmt.Sprintf(callerNotFoundMessage, "alice"),
+		},
+		{
+			description: "HandleIncident: Caller not connected",
+			params:      []string{constants.SubCommandCreate, `"DB down"`, "--caller", "@bob"},
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetUserByUsername", "bob").Return(testutils.GetUser(model.SystemUserRoleId), nil)
+			},
+			setupClient: func(client *mock_plugin.Client) {},
+			setupPlugin: func() {
+				monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GetUser", func(*Plugin, string) (*serializer.User, error) {
+					return nil, ErrNotFound
+				})
+			},
+			expectedError: fmt.Sprintf(callerNotConnectedMessage, "bob"),
+		},
+		{
+			description: "HandleIncident: Unable to create the incident",
+			params:      []string{constants.SubCommandCreate, `"DB down"`},
+			setupAPI: func(a *plugintest.API) {
+				a.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			setupClient: func(client *mock_plugin.Client) {
+				client.On("CreateIncident", mock.AnythingOfType("*serializer.IncidentPayload")).Return(
+					nil, http.StatusInternalServerError, errors.New("unable to create the incident"),
+				)
+			},
+			setupPlugin:      func() {},
+			isResponse:       true,
+			expectedResponse: genericErrorMessage,
+			expectedError:    genericWaitMessage,
+		},
+		{
+			description: "HandleIncident: Success",
+			params:      []string{constants.SubCommandCreate, `"DB down"`, "--description", `"The database is not reachable"`, "--caller", "@charlie", "--urgency", "1"},
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetUserByUsername", "charlie").Return(testutils.GetUser(model.SystemUserRoleId), nil)
+			},
+			setupClient: func(client *mock_plugin.Client) {
+				client.On("CreateIncident", &serializer.IncidentPayload{
+					ShortDescription: "DB down",
+					Description:      "The database is not reachable",
+					Caller:           testutils.GetServiceNowSysID(),
+					Urgency:          "1",
+					ChannelID:        testutils.GetChannelID(),
+				}).Return(
+					&serializer.IncidentResponse{Number: "INC0000001"}, http.StatusCreated, nil,
+				)
+			},
+			setupPlugin: func() {
+				monkey.PatchInstanceMethod(reflect.TypeOf(&p), "GetUser", func(*Plugin, string) (*serializer.User, error) {
+					return testutils.GetSerializerUser(), nil
+				})
+				monkey.PatchInstanceMethod(reflect.TypeOf(&p), "ShareCreatedIncident", func(*Plugin, string, *serializer.IncidentResponse) (*serializer.ServiceNowRecord, error) {
+					return &serializer.ServiceNowRecord{}, nil
+				})
+			},
+			isResponse:       true,
+			expectedResponse: fmt.Sprintf(incidentCreatedMessage, "INC0000001"),
+			expectedError:    genericWaitMessage,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			defer mockAPI.AssertExpectations(t)
+			assert := assert.New(t)
+			c := mock_plugin.NewClient(t)
+			testCase.setupAPI(mockAPI)
+			testCase.setupClient(c)
+			testCase.setupPlugin()
+			p.SetAPI(mockAPI)
+
+			if testCase.isResponse {
+				mockAPI.On("SendEphemeralPost", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					post := args.Get(1).(*model.Post)
+					assert.Equal(testCase.expectedResponse, post.Message)
+				}).Once().Return(&model.Post{})
+			}
+
+			resp := p.handleIncident(&plugin.Context{}, args, testCase.params, c, true)
+
+			// This is used to wait for goroutine to finish.
+			time.Sleep(100 * time.Millisecond)
+			assert.EqualValues(testCase.expectedError, resp)
+		})
+	}
+}
+
+func TestHandleKnowledgeBase(t *testing.T) {
+	defer monkey.UnpatchAll()
+	p := Plugin{}
+	mockAPI := &plugintest.API{}
+	args := &model.CommandArgs{
+		UserId:    testutils.GetID(),
+		ChannelId: testutils.GetChannelID(),
+	}
+	for _, testCase := range []struct {
+		description         string
+		params              []string
+		setupAPI            func(*plugintest.API)
+		setupClient         func(client *mock_plugin.Client)
+		isResponse          bool
+		expectedMessage     string
+		expectedAttachments int
+		expectedResponse    string
+	}{
+		{
+			description:      "HandleKnowledgeBase: Search term too short",
+			params:           []string{"ab"},
+			setupAPI:         func(a *plugintest.API) {},
+			setupClient:      func(client *mock_plugin.Client) {},
+			expectedResponse: fmt.Sprintf(constants.ErrorSearchTermThreshold, constants.CharacterThresholdForSearchingRecords),
+		},
+		{
+			description: "HandleKnowledgeBase: No articles found",
+			params:      []string{"reset", "password"},
+			setupAPI:    func(a *plugintest.API) {},
+			setupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeKnowledge, "reset password", fmt.Sprint(constants.MaxKnowledgeSearchResults), "0", &serializer.RecordSearchFilters{PublishedOnly: true}).Return(
+					nil, http.StatusOK, nil,
+				)
+			},
+			isResponse:       true,
+			expectedMessage:  fmt.Sprintf(noKnowledgeArticlesFoundMessage, "reset password"),
+			expectedResponse: genericWaitMessage,
+		},
+		{
+			description: "HandleKnowledgeBase: Success",
+			params:      []string{`"reset password"`},
+			setupAPI: func(a *plugintest.API) {
+				a.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			setupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeKnowledge, "reset password", fmt.Sprint(constants.MaxKnowledgeSearchResults), "0", &serializer.RecordSearchFilters{PublishedOnly: true}).Return(
+					[]*serializer.ServiceNowPartialRecord{{SysID: "article1"}, {SysID: "article2"}}, http.StatusOK, nil,
+				)
+				client.On("GetRecordFromServiceNow", constants.RecordTypeKnowledge, "article1").Return(
+					&serializer.ServiceNowRecord{SysID: "article1", Number: "KB0000001", Text: "<p>Open the portal</p>"}, http.StatusOK, nil,
+				)
+				client.On("GetRecordFromServiceNow", constants.RecordTypeKnowledge, "article2").Return(
+					nil, http.StatusForbidden, errors.New("get record error"),
+				)
+			},
+			isResponse:          true,
+			expectedMessage:     fmt.Sprintf(knowledgeArticlesFoundMessage, "reset password"),
+			expectedAttachments: 1,
+			expectedResponse:    genericWaitMessage,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			defer mockAPI.AssertExpectations(t)
+			assert := assert.New(t)
+			c := mock_plugin.NewClient(t)
+			testCase.setupAPI(mockAPI)
+			testCase.setupClient(c)
+			p.SetAPI(mockAPI)
+
+			if testCase.isResponse {
+				mockAPI.On("SendEphemeralPost", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					post := args.Get(1).(*model.Post)
+					assert.Equal(testCase.expectedMessage, post.Message)
+					assert.Len(post.Attachments(), testCase.expectedAttachments)
+				}).Once().Return(&model.Post{})
+			}
+
+			resp := p.handleKnowledgeBase(&plugin.Context{}, args, testCase.params, c, true)
+
+			// This is used to wait for goroutine to finish.
+			time.Sleep(100 * time.Millisecond)
+			assert.EqualValues(testCase.expectedResponse, resp)
+		})
+	}
+}
+
+func TestHandleWarRoom(t *testing.T) {
+	p := Plugin{}
+	mockAPI := &plugintest.API{}
+	args := &model.CommandArgs{
+		UserId:    testutils.GetID(),
+		ChannelId: testutils.GetChannelID(),
+		TeamId:    "mockTeamID",
+	}
+	for _, testCase := range []struct {
+		description      string
+		params           []string
+		setupAPI         func(*plugintest.API)
+		setupClient      func(client *mock_plugin.Client)
+		setupStore       func(s *mock_plugin.Store)
+		isResponse       bool
+		expectedMessage  string
+		expectedResponse string
+	}{
+		{
+			description:      "HandleWarRoom: Missing incident number",
+			setupAPI:         func(a *plugintest.API) {},
+			setupClient:      func(client *mock_plugin.Client) {},
+			setupStore:       func(s *mock_plugin.Store) {},
+			expectedResponse: fmt.Sprintf(invalidWarRoomCommandMessage, constants.ErrorInvalidIncidentNumber),
+		},
+		{
+			description:      "HandleWarRoom: Invalid incident number",
+			params:           []string{"PRB0000001"},
+			setupAPI:         func(a *plugintest.API) {},
+			setupClient:      func(client *mock_plugin.Client) {},
+			setupStore:       func(s *mock_plugin.Store) {},
+			expectedResponse: fmt.Sprintf(invalidWarRoomCommandMessage, constants.ErrorInvalidIncidentNumber),
+		},
+		{
+			description: "HandleWarRoom: Incident not found",
+			params:      []string{"inc0000001"},
+			setupAPI:    func(a *plugintest.API) {},
+			setupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordByNumber", constants.RecordTypeIncident, "INC0000001").Return(nil, http.StatusOK, nil)
+			},
+			setupStore:       func(s *mock_plugin.Store) {},
+			isResponse:       true,
+			expectedMessage:  fmt.Sprintf(constants.ErrorIncidentNotFound, "INC0000001"),
+			expectedResponse: genericWaitMessage,
+		},
+		{
+			description: "HandleWarRoom: War room already exists",
+			params:      []string{"INC0000001"},
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetChannel", testutils.GetChannelID()).Return(&model.Channel{Id: testutils.GetChannelID(), Name: "inc0000001", Type: model.ChannelTypeOpen}, nil).Once()
+				a.On("HasPermissionToTeam", testutils.GetID(), "mockTeamID", model.PermissionJoinPublicChannels).Return(true).Once()
+				a.On("AddChannelMember", testutils.GetChannelID(), testutils.GetID()).Return(&model.ChannelMember{}, nil).Once()
+			},
+			setupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordByNumber", constants.RecordTypeIncident, "INC0000001").Return(&serializer.ServiceNowRecord{SysID: testutils.GetServiceNowSysID(), Number: "INC0000001"}, http.StatusOK, nil)
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadWarRoomChannelID", "mockTeamID", testutils.GetServiceNowSysID()).Return(testutils.GetChannelID(), nil)
+			},
+			isResponse:       true,
+			expectedMessage:  fmt.Sprintf(constants.WarRoomExistsMessage, "inc0000001"),
+			expectedResponse: genericWaitMessage,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			defer mockAPI.AssertExpectations(t)
+			assert := assert.New(t)
+			c := mock_plugin.NewClient(t)
+			testCase.setupAPI(mockAPI)
+			testCase.setupClient(c)
+			s := mock_plugin.NewStore(t)
+			testCase.setupStore(s)
+			p.store = s
+			p.SetAPI(mockAPI)
+
+			if testCase.isResponse {
+				mockAPI.On("SendEphemeralPost", mock.AnythingOfType("string"), mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					post := args.Get(1).(*model.Post)
+					assert.Equal(testCase.expectedMessage, post.Message)
+				}).Once().Return(&model.Post{})
+			}
+
+			resp := p.handleIncident(&plugin.Context{}, args, append([]string{constants.SubCommandWarRoom}, testCase.params...), c, true)
+
+			// This is used to wait for goroutine to finish.
+			time.Sleep(100 * time.Millisecond)
+			assert.EqualValues(testCase.expectedResponse, resp)
+		})
+	}
+}
+
+func TestHandleNotifications(t *testing.T) {
+	deadLetters := []*serializer.QueuedNotification{
+		{ID: "notification1", Event: &serializer.ServiceNowEvent{Number: "INC0000001", EventOccurred: constants.SubscriptionEventState}, Attempts: maxNotificationPostAttempts, LastError: "post error"},
+	}
+	for _, testCase := range []struct {
+		description      string
+		params           []string
+		isSysAdmin       bool
+		setupStore       func(*mock_plugin.Store)
+		expectedResponse string
+	}{
+		{
+			description:      "HandleNotifications: User is not a system admin",
+			params:           []string{constants.SubCommandFailed},
+			setupStore:       func(s *mock_plugin.Store) {},
+			expectedResponse: notificationsNotPermittedMessage,
+		},
+		{
+			description:      "HandleNotifications: Invalid number of params",
+			isSysAdmin:       true,
+			setupStore:       func(s *mock_plugin.Store) {},
+			expectedResponse: invalidNotificationsCommandMessage,
+		},
+		{
+			description: "HandleNotifications: No failed notifications",
+			params:      []string{constants.SubCommandFailed},
+			isSysAdmin:  true,
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadDeadLetterNotifications").Return([]*serializer.QueuedNotification{}, nil)
+			},
+			expectedResponse: noDeadLetterNotificationsMessage,
+		},
+		{
+			description: "HandleNotifications: Failed notifications are listed",
+			params:      []string{constants.SubCommandFailed},
+			isSysAdmin:  true,
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadDeadLetterNotifications").Return(deadLetters, nil)
+			},
+			expectedResponse: ParseDeadLetterNotificationsToCommandResponse(deadLetters),
+		},
+		{
+			description:      "HandleNotifications: Notification ID missing for replaying",
+			params:           []string{constants.SubCommandReplay},
+			isSysAdmin:       true,
+			setupStore:       func(s *mock_plugin.Store) {},
+			expectedResponse: replayNotificationIDMissingMessage,
+		},
+		{
+			description: "HandleNotifications: Failed notification not found",
+			params:      []string{constants.SubCommandReplay, "notification2"},
+			isSysAdmin:  true,
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadDeadLetterNotifications").Return(deadLetters, nil)
+			},
+			expectedResponse: fmt.Sprintf(deadLetterNotificationNotFoundMessage, "notification2"),
+		},
+		{
+			description: "HandleNotifications: All failed notifications are replayed",
+			params:      []string{constants.SubCommandReplay, "all"},
+			isSysAdmin:  true,
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadDeadLetterNotifications").Return(deadLetters, nil)
+				s.On("MoveNotificationToQueue", deadLetters[0]).Return(nil)
+			},
+			expectedResponse: fmt.Sprintf(notificationsReplayedMessage, 1),
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			testCase.setupStore(store)
+			p, _ := setupTestPlugin(&plugintest.API{}, store)
+
+			resp := p.handleNotifications(testCase.params, testCase.isSysAdmin)
+			assert.EqualValues(t, testCase.expectedResponse, resp)
+		})
+	}
+}
+
 func TestGetAutocompleteData(t *testing.T) {
 	t.Run("GetAutocompleteData", func(t *testing.T) {
 		assert := assert.New(t)
@@ -882,6 +1368,18 @@ func TestParseCommand(t *testing.T) {
 			input:          "/servicenow disconnect",
 			expectedAction: constants.CommandDisconnect,
 		},
+		{
+			description:        "ParseCommand: incident create command with flags",
+			input:              `/servicenow incident create "DB down" --description "The database  is down" --urgency 1`,
+			expectedAction:     constants.CommandIncident,
+			expectedParameters: []string{constants.SubCommandCreate, `"DB down"`, "--description", `"The database  is down"`, "--urgency", "1"},
+		},
+		{
+			description:        "ParseCommand: incident create command with a multi-line description",
+			input:              "/servicenow incident create \"DB down\" --description \"The database is down.\n\tSteps:\n1. Open the app\" --urgency 1",
+			expectedAction:     constants.CommandIncident,
+			expectedParameters: []string{constants.SubCommandCreate, `"DB down"`, "--description", "\"The database is down.\n\tSteps:\n1. Open the app\"", "--urgency", "1"},
+		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			assert := assert.New(t)
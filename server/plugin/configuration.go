@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
 	"github.com/mattermost/mattermost-plugin-servicenow/server/telemetry"
 )
 
@@ -27,11 +28,18 @@ type configuration struct {
 	ServiceNowOAuthClientSecret string `json:"ServiceNowOAuthClientSecret"`
 	EncryptionSecret            string `json:"EncryptionSecret"`
 	WebhookSecret               string `json:"WebhookSecret"`
+	WebhookSecretGracePeriod    int    `json:"WebhookSecretGracePeriod"`
 	UpdateSetDownload           string `json:"ServiceNowUpdateSetDownload"`
+	RecordTypes                 string `json:"RecordTypes"`
+	SuggestKnowledgeArticles    bool   `json:"SuggestKnowledgeArticles"`
+	RequireWebhookSignature     bool   `json:"RequireWebhookSignature"`
 	MattermostSiteURL           string `json:"-"`
 	PluginID                    string `json:"-"`
 	PluginURL                   string `json:"-"`
 	PluginURLPath               string `json:"-"`
+
+	// RecordTypeRegistry is computed from RecordTypes and is never modified after being created
+	RecordTypeRegistry *serializer.RecordTypeRegistry `json:"-"`
 }
 
 // Clone shallow copies the configuration. Your implementation may require a deep copy if
@@ -45,10 +53,19 @@ func (c *configuration) Clone() *configuration {
 func (c *configuration) ProcessConfiguration() error {
 	c.ServiceNowBaseURL = strings.TrimRight(strings.TrimSpace(c.ServiceNowBaseURL), "/")
 	c.WebhookSecret = strings.TrimSpace(c.WebhookSecret)
+	if c.WebhookSecretGracePeriod < 0 {
+		c.WebhookSecretGracePeriod = 0
+	}
 	c.ServiceNowOAuthClientID = strings.TrimSpace(c.ServiceNowOAuthClientID)
 	c.ServiceNowOAuthClientSecret = strings.TrimSpace(c.ServiceNowOAuthClientSecret)
 	c.EncryptionSecret = strings.TrimSpace(c.EncryptionSecret)
 
+	recordTypeRegistry, err := serializer.RecordTypeRegistryFromJSON(c.RecordTypes)
+	if err != nil {
+		return errors.Wrap(err, constants.ErrorInvalidRecordTypesConfig)
+	}
+	c.RecordTypeRegistry = recordTypeRegistry
+
 	return nil
 }
 
@@ -141,8 +158,13 @@ func (p *Plugin) OnConfigurationChange() error {
 	configuration.PluginURLPath = p.GetPluginURLPath()
 	configuration.PluginID = Manifest.Id
 
+	previousConfiguration := p.getConfiguration()
 	p.setConfiguration(configuration)
 
+	if previousConfiguration.WebhookSecret != "" && previousConfiguration.WebhookSecret != configuration.WebhookSecret {
+		p.handleWebhookSecretRotation(previousConfiguration.WebhookSecret)
+	}
+
 	// Some config changes require reloading tracking config
 	if p.tracker != nil {
 		p.tracker.ReloadConfig(telemetry.NewTrackerConfig(p.API.GetConfig()))
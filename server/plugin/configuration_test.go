@@ -92,3 +92,69 @@ func TestIsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessConfiguration(t *testing.T) {
+	for _, testCase := range []struct {
+		description         string
+		recordTypes         string
+		errMsg              string
+		expectedSearchable  map[string]bool
+		expectedDisplayName map[string]string
+	}{
+		{
+			description: "default record types",
+			expectedSearchable: map[string]bool{
+				constants.RecordTypeIncident: true,
+				"sc_task":                    false,
+			},
+			expectedDisplayName: map[string]string{
+				constants.RecordTypeChangeRequest: "Change Request",
+			},
+		},
+		{
+			description: "configured record types",
+			recordTypes: `[{"name": "sc_task", "display_name": "Catalog Task", "search": true}, {"name": "incident", "display_name": "Incident"}, {"name": "u_security_incident"}]`,
+			expectedSearchable: map[string]bool{
+				constants.RecordTypeIncident: false,
+				constants.RecordTypeProblem:  true,
+				"sc_task":                    true,
+				"u_security_incident":        false,
+			},
+			expectedDisplayName: map[string]string{
+				"sc_task":             "Catalog Task",
+				"u_security_incident": "u_security_incident",
+			},
+		},
+		{
+			description: "invalid JSON",
+			recordTypes: `{"name": "sc_task"}`,
+			errMsg:      constants.ErrorInvalidRecordTypesConfig,
+		},
+		{
+			description: "invalid table name",
+			recordTypes: `[{"name": "sc_task?sysparm_limit=1"}]`,
+			errMsg:      constants.ErrorInvalidRecordTypesConfig,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			config := &configuration{
+				RecordTypes: testCase.recordTypes,
+			}
+
+			err := config.ProcessConfiguration()
+			if testCase.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), testCase.errMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			for recordType, searchable := range testCase.expectedSearchable {
+				assert.Equal(t, searchable, config.RecordTypeRegistry.IsSearchable(recordType), recordType)
+			}
+			for recordType, displayName := range testCase.expectedDisplayName {
+				assert.Equal(t, displayName, config.RecordTypeRegistry.GetDisplayName(recordType), recordType)
+			}
+		})
+	}
+}
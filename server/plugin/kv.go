@@ -1,8 +1,13 @@
 package plugin
 
 import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin"
 	"github.com/pkg/errors"
 
@@ -14,6 +19,20 @@ import (
 const (
 	OAuth2KeyExpiration   = 15 * time.Minute
 	oAuth2StateTimeToLive = 300 // seconds
+
+	maxAtomicUpdateAttempts = 5
+
+	// The webhook events are remembered for a day, which is longer than ServiceNow keeps retrying a delivery
+	webhookEventTimeToLive = 24 * 60 * 60 // seconds
+
+	// The subscriptions changed directly in ServiceNow are noticed after their cached copy expires
+	subscriptionCacheTimeToLive = 10 * 60 // seconds
+
+	// The oldest failed notifications are discarded when there are more of them
+	maxDeadLetterNotifications = 500
+
+	// The keys of the queued notifications are listed in pages of this size
+	notificationKeysPerPage = 1000
 )
 
 var ErrNotFound = kvstore.ErrNotFound
@@ -21,11 +40,21 @@ var ErrNotFound = kvstore.ErrNotFound
 type Store interface {
 	UserStore
 	OAuth2StateStore
+	SubscriptionFiltersStore
+	NotificationThreadStore
+	WebhookEventStore
+	SubscriptionCacheStore
+	WebhookSecretStore
+	NotificationQueueStore
+	SubscriptionDeliveryModeStore
+	NotificationDigestStore
+	WarRoomStore
 }
 
 type UserStore interface {
 	LoadUser(mattermostUserID string) (*serializer.User, error)
 	StoreUser(user *serializer.User) error
+	UpdateUserToken(mattermostUserID, encodedToken string, shouldUpdate func(storedToken string) bool) error
 	DeleteUser(mattermostUserID string) error
 	GetAllUsers() ([]*serializer.IncidentCaller, error)
 }
@@ -36,20 +65,102 @@ type OAuth2StateStore interface {
 	StoreOAuth2State(state string) error
 }
 
+// SubscriptionFiltersStore manages the filters of bulk subscriptions
+type SubscriptionFiltersStore interface {
+	LoadSubscriptionFilters(subscriptionID string) (*serializer.SubscriptionFilters, error)
+	StoreSubscriptionFilters(subscriptionID string, filters *serializer.SubscriptionFilters) error
+	DeleteSubscriptionFilters(subscriptionID string) error
+}
+
+// NotificationThreadStore manages the root posts of the notification threads of the records in the channels
+type NotificationThreadStore interface {
+	LoadNotificationThread(channelID, recordID string) (string, error)
+	StoreNotificationThread(channelID, recordID, postID string) error
+	DeleteNotificationThread(channelID, recordID string) error
+}
+
+// WebhookEventStore keeps track of the webhook events already processed, so that the repeated deliveries are dropped
+type WebhookEventStore interface {
+	StoreWebhookEvent(eventID string) (bool, error)
+	DeleteWebhookEvent(eventID string) error
+}
+
+// SubscriptionCacheStore caches the subscriptions fetched from ServiceNow for validating the notification events
+type SubscriptionCacheStore interface {
+	LoadCachedSubscription(subscriptionID string) (*serializer.SubscriptionResponse, error)
+	StoreCachedSubscription(subscription *serializer.SubscriptionResponse) error
+	DeleteCachedSubscription(subscriptionID string) error
+}
+
+// WebhookSecretStore keeps the webhook secret replaced by the last rotation for the grace period
+type WebhookSecretStore interface {
+	LoadPreviousWebhookSecret() (*serializer.PreviousWebhookSecret, error)
+	StorePreviousWebhookSecret(previousSecret *serializer.PreviousWebhookSecret, ttlSeconds int64) error
+	StoreWebhookSecretRotation(secretHash string) (bool, error)
+}
+
+// NotificationQueueStore manages the queue of the notifications waiting to be posted,
+// and the dead letters of the notifications which could not be posted after all the attempts
+type NotificationQueueStore interface {
+	EnqueueNotification(notification *serializer.QueuedNotification) error
+	LoadQueuedNotifications() ([]*serializer.QueuedNotification, error)
+	UpdateQueuedNotification(notification *serializer.QueuedNotification) error
+	DequeueNotification(notificationID string) error
+	MoveNotificationToDeadLetters(notification *serializer.QueuedNotification) error
+	LoadDeadLetterNotifications() ([]*serializer.QueuedNotification, error)
+	MoveNotificationToQueue(notification *serializer.QueuedNotification) error
+}
+
+// SubscriptionDeliveryModeStore manages the delivery modes of the notifications of bulk subscriptions
+type SubscriptionDeliveryModeStore interface {
+	LoadSubscriptionDeliveryMode(subscriptionID string) (string, error)
+	StoreSubscriptionDeliveryMode(subscriptionID, deliveryMode string) error
+	DeleteSubscriptionDeliveryMode(subscriptionID string) error
+}
+
+// NotificationDigestStore buffers the events of the subscriptions delivered as digests until the end of their windows
+type NotificationDigestStore interface {
+	AddEventToNotificationDigest(digest *serializer.NotificationDigest, event *serializer.ServiceNowEvent) error
+	LoadNotificationDigests() ([]*serializer.NotificationDigest, error)
+	DeleteNotificationDigest(digestID string) error
+}
+
+// WarRoomStore manages the war room channels created for the incidents in the teams
+type WarRoomStore interface {
+	LoadWarRoomChannelID(teamID, incidentID string) (string, error)
+	StoreWarRoomChannelID(teamID, incidentID, channelID string) error
+}
+
 type pluginStore struct {
-	plugin   *Plugin
-	basicKV  kvstore.KVStore
-	oauth2KV kvstore.KVStore
-	userKV   kvstore.KVStore
+	plugin         *Plugin
+	basicKV        kvstore.KVStore
+	oauth2KV       kvstore.KVStore
+	userKV         kvstore.KVStore
+	threadKV       kvstore.KVStore
+	eventKV        kvstore.KVStore
+	cacheKV        kvstore.KVStore
+	notificationKV kvstore.KVStore
+	deadLetterKV   kvstore.KVStore
+	digestKV       kvstore.KVStore
+
+	// notificationListsLock serializes the updates of the notification digests list made by this server,
+	// so that the atomic updates are retried only when the list is updated by another server
+	notificationListsLock sync.Mutex
 }
 
 func (p *Plugin) NewStore(api plugin.API) Store {
 	basicKV := kvstore.NewPluginStore(api)
 	return &pluginStore{
-		plugin:   p,
-		basicKV:  basicKV,
-		userKV:   kvstore.NewHashedKeyStore(basicKV, constants.UserKeyPrefix),
-		oauth2KV: kvstore.NewHashedKeyStore(kvstore.NewOneTimePluginStore(api, OAuth2KeyExpiration), constants.OAuth2KeyPrefix),
+		plugin:         p,
+		basicKV:        basicKV,
+		userKV:         kvstore.NewHashedKeyStore(basicKV, constants.UserKeyPrefix),
+		oauth2KV:       kvstore.NewHashedKeyStore(kvstore.NewOneTimePluginStore(api, OAuth2KeyExpiration), constants.OAuth2KeyPrefix),
+		threadKV:       kvstore.NewHashedKeyStore(basicKV, constants.NotificationThreadKeyPrefix),
+		eventKV:        kvstore.NewHashedKeyStore(basicKV, constants.WebhookEventKeyPrefix),
+		cacheKV:        kvstore.NewHashedKeyStore(basicKV, constants.SubscriptionCacheKeyPrefix),
+		notificationKV: kvstore.NewHashedKeyStore(basicKV, constants.QueuedNotificationKeyPrefix),
+		deadLetterKV:   kvstore.NewHashedKeyStore(basicKV, constants.DeadLetterNotificationKeyPrefix),
+		digestKV:       kvstore.NewHashedKeyStore(basicKV, constants.NotificationDigestKeyPrefix),
 	}
 }
 
@@ -67,6 +178,47 @@ func (s *pluginStore) StoreUser(user *serializer.User) error {
 	return err
 }
 
+// UpdateUserToken atomically replaces the encoded OAuth2 token of a stored user.
+// The token is not replaced if shouldUpdate returns false for the currently stored token,
+// and the update is retried if the user gets modified concurrently by another server.
+func (s *pluginStore) UpdateUserToken(mattermostUserID, encodedToken string, shouldUpdate func(storedToken string) bool) error {
+	for attempt := 0; attempt < maxAtomicUpdateAttempts; attempt++ {
+		data, err := s.userKV.Load(mattermostUserID)
+		if err != nil {
+			return err
+		}
+
+		user := serializer.User{}
+		if err = json.Unmarshal(data, &user); err != nil {
+			return err
+		}
+
+		if !shouldUpdate(user.OAuth2Token) {
+			return nil
+		}
+
+		user.OAuth2Token = encodedToken
+		updatedData, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+
+		stored, err := s.userKV.StoreWithOptions(mattermostUserID, updatedData, model.PluginKVSetOptions{
+			Atomic:   true,
+			OldValue: data,
+		})
+		if err != nil {
+			return err
+		}
+
+		if stored {
+			return nil
+		}
+	}
+
+	return errors.Errorf("failed to update the token of the user after %d attempts", maxAtomicUpdateAttempts)
+}
+
 func (s *pluginStore) DeleteUser(mattermostUserID string) error {
 	u, err := s.LoadUser(mattermostUserID)
 	if err != nil {
@@ -121,7 +273,7 @@ func (s *pluginStore) GetAllUsers() ([]*serializer.IncidentCaller, error) {
 func (s *pluginStore) VerifyOAuth2State(state string) error {
 	data, err := s.oauth2KV.Load(state)
 	if err != nil {
-		if err == ErrNotFound {
+		if errors.Is(err, ErrNotFound) {
 			return errors.New("authentication attempt expired, please try again")
 		}
 		return err
@@ -136,3 +288,374 @@ func (s *pluginStore) VerifyOAuth2State(state string) error {
 func (s *pluginStore) StoreOAuth2State(state string) error {
 	return s.oauth2KV.StoreTTL(state, []byte(state), oAuth2StateTimeToLive)
 }
+
+func (s *pluginStore) LoadSubscriptionFilters(subscriptionID string) (*serializer.SubscriptionFilters, error) {
+	filters := serializer.SubscriptionFilters{}
+	if err := kvstore.LoadJSON(s.basicKV, constants.SubscriptionFiltersKeyPrefix+subscriptionID, &filters); err != nil {
+		return nil, err
+	}
+
+	return &filters, nil
+}
+
+func (s *pluginStore) StoreSubscriptionFilters(subscriptionID string, filters *serializer.SubscriptionFilters) error {
+	return kvstore.StoreJSON(s.basicKV, constants.SubscriptionFiltersKeyPrefix+subscriptionID, filters)
+}
+
+func (s *pluginStore) DeleteSubscriptionFilters(subscriptionID string) error {
+	return s.basicKV.Delete(constants.SubscriptionFiltersKeyPrefix + subscriptionID)
+}
+
+func (s *pluginStore) LoadNotificationThread(channelID, recordID string) (string, error) {
+	data, err := s.threadKV.Load(getNotificationThreadKey(channelID, recordID))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (s *pluginStore) StoreNotificationThread(channelID, recordID, postID string) error {
+	return s.threadKV.Store(getNotificationThreadKey(channelID, recordID), []byte(postID))
+}
+
+func (s *pluginStore) DeleteNotificationThread(channelID, recordID string) error {
+	return s.threadKV.Delete(getNotificationThreadKey(channelID, recordID))
+}
+
+func getNotificationThreadKey(channelID, recordID string) string {
+	return channelID + "_" + recordID
+}
+
+// StoreWebhookEvent records the ID of a webhook event. It returns false if the event was already recorded,
+// which is checked atomically so that a delivery retried concurrently on another server is also dropped.
+func (s *pluginStore) StoreWebhookEvent(eventID string) (bool, error) {
+	return s.eventKV.StoreWithOptions(eventID, []byte{1}, model.PluginKVSetOptions{
+		Atomic:          true,
+		OldValue:        nil,
+		ExpireInSeconds: webhookEventTimeToLive,
+	})
+}
+
+// DeleteWebhookEvent forgets a webhook event, so that its repeated delivery is processed
+func (s *pluginStore) DeleteWebhookEvent(eventID string) error {
+	return s.eventKV.Delete(eventID)
+}
+
+func (s *pluginStore) LoadCachedSubscription(subscriptionID string) (*serializer.SubscriptionResponse, error) {
+	subscription := serializer.SubscriptionResponse{}
+	if err := kvstore.LoadJSON(s.cacheKV, subscriptionID, &subscription); err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (s *pluginStore) StoreCachedSubscription(subscription *serializer.SubscriptionResponse) error {
+	data, err := json.Marshal(subscription)
+	if err != nil {
+		return err
+	}
+
+	return s.cacheKV.StoreTTL(subscription.SysID, data, subscriptionCacheTimeToLive)
+}
+
+func (s *pluginStore) DeleteCachedSubscription(subscriptionID string) error {
+	return s.cacheKV.Delete(subscriptionID)
+}
+
+func (s *pluginStore) LoadPreviousWebhookSecret() (*serializer.PreviousWebhookSecret, error) {
+	previousSecret := serializer.PreviousWebhookSecret{}
+	if err := kvstore.LoadJSON(s.basicKV, constants.PreviousWebhookSecretKey, &previousSecret); err != nil {
+		return nil, err
+	}
+
+	return &previousSecret, nil
+}
+
+func (s *pluginStore) StorePreviousWebhookSecret(previousSecret *serializer.PreviousWebhookSecret, ttlSeconds int64) error {
+	data, err := json.Marshal(previousSecret)
+	if err != nil {
+		return err
+	}
+
+	return s.basicKV.StoreTTL(constants.PreviousWebhookSecretKey, data, ttlSeconds)
+}
+
+// StoreWebhookSecretRotation records the rotation to the webhook secret with the given hash. It returns false if the
+// rotation was already recorded, which is checked atomically so that the rotation is handled by only one server.
+func (s *pluginStore) StoreWebhookSecretRotation(secretHash string) (bool, error) {
+	oldValue, err := s.basicKV.Load(constants.WebhookSecretRotationKey)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return false, err
+	}
+
+	if string(oldValue) == secretHash {
+		return false, nil
+	}
+
+	return s.basicKV.StoreWithOptions(constants.WebhookSecretRotationKey, []byte(secretHash), model.PluginKVSetOptions{
+		Atomic:   true,
+		OldValue: oldValue,
+	})
+}
+
+func (s *pluginStore) EnqueueNotification(notification *serializer.QueuedNotification) error {
+	return kvstore.StoreJSON(s.notificationKV, notification.ID, notification)
+}
+
+func (s *pluginStore) LoadQueuedNotifications() ([]*serializer.QueuedNotification, error) {
+	return s.loadNotifications(constants.QueuedNotificationKeyPrefix)
+}
+
+func (s *pluginStore) UpdateQueuedNotification(notification *serializer.QueuedNotification) error {
+	return kvstore.StoreJSON(s.notificationKV, notification.ID, notification)
+}
+
+func (s *pluginStore) DequeueNotification(notificationID string) error {
+	return s.notificationKV.Delete(notificationID)
+}
+
+// MoveNotificationToDeadLetters moves a notification from the queue to the dead letters.
+// The oldest dead letters are discarded if there are more than maxDeadLetterNotifications of them.
+func (s *pluginStore) MoveNotificationToDeadLetters(notification *serializer.QueuedNotification) error {
+	if err := kvstore.StoreJSON(s.deadLetterKV, notification.ID, notification); err != nil {
+		return err
+	}
+
+	if err := s.notificationKV.Delete(notification.ID); err != nil {
+		return err
+	}
+
+	deadLetters, err := s.LoadDeadLetterNotifications()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < len(deadLetters)-maxDeadLetterNotifications; i++ {
+		if err := s.deadLetterKV.Delete(deadLetters[i].ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *pluginStore) LoadDeadLetterNotifications() ([]*serializer.QueuedNotification, error) {
+	return s.loadNotifications(constants.DeadLetterNotificationKeyPrefix)
+}
+
+// MoveNotificationToQueue moves a notification from the dead letters back to the queue
+func (s *pluginStore) MoveNotificationToQueue(notification *serializer.QueuedNotification) error {
+	if err := s.EnqueueNotification(notification); err != nil {
+		return err
+	}
+
+	return s.deadLetterKV.Delete(notification.ID)
+}
+
+// loadNotifications loads the notifications stored with the given key prefix, oldest first.
+// Every notification is stored in its own key, so that queuing and removing a notification never conflicts
+// with the other servers, and the notifications are found by listing the keys of the KV store.
+// The notifications removed from the KV store after being listed are skipped.
+func (s *pluginStore) loadNotifications(keyPrefix string) ([]*serializer.QueuedNotification, error) {
+	notifications := []*serializer.QueuedNotification{}
+	for page := 0; ; page++ {
+		keys, err := s.plugin.API.KVList(page, notificationKeysPerPage)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, key := range keys {
+			if !strings.HasPrefix(key, keyPrefix) {
+				continue
+			}
+
+			notification := serializer.QueuedNotification{}
+			if err := kvstore.LoadJSON(s.basicKV, key, &notification); err != nil {
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				return nil, err
+			}
+
+			notifications = append(notifications, &notification)
+		}
+
+		if len(keys) < notificationKeysPerPage {
+			break
+		}
+	}
+
+	sort.SliceStable(notifications, func(i, j int) bool {
+		if notifications[i].CreatedAt != notifications[j].CreatedAt {
+			return notifications[i].CreatedAt < notifications[j].CreatedAt
+		}
+		return notifications[i].ID < notifications[j].ID
+	})
+
+	return notifications, nil
+}
+
+func (s *pluginStore) LoadSubscriptionDeliveryMode(subscriptionID string) (string, error) {
+	data, err := s.basicKV.Load(constants.DeliveryModeKeyPrefix + subscriptionID)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (s *pluginStore) StoreSubscriptionDeliveryMode(subscriptionID, deliveryMode string) error {
+	return s.basicKV.Store(constants.DeliveryModeKeyPrefix+subscriptionID, []byte(deliveryMode))
+}
+
+func (s *pluginStore) DeleteSubscriptionDeliveryMode(subscriptionID string) error {
+	return s.basicKV.Delete(constants.DeliveryModeKeyPrefix + subscriptionID)
+}
+
+// AddEventToNotificationDigest atomically adds an event to the stored digest with the ID of the given digest.
+// The given digest is stored if it is the first event of its window.
+func (s *pluginStore) AddEventToNotificationDigest(digest *serializer.NotificationDigest, event *serializer.ServiceNowEvent) error {
+	if err := s.updateNotificationList(constants.NotificationDigestsKey, func(ids []string) []string {
+		return append(removeNotificationID(ids, digest.ID), digest.ID)
+	}); err != nil {
+		return err
+	}
+
+	for attempt := 0; attempt < maxAtomicUpdateAttempts; attempt++ {
+		data, err := s.digestKV.Load(digest.ID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		storedDigest := *digest
+		if data != nil {
+			if err = json.Unmarshal(data, &storedDigest); err != nil {
+				return err
+			}
+		}
+
+		storedDigest.AddEvent(event)
+		updatedData, err := json.Marshal(storedDigest)
+		if err != nil {
+			return err
+		}
+
+		stored, err := s.digestKV.StoreWithOptions(digest.ID, updatedData, model.PluginKVSetOptions{
+			Atomic:   true,
+			OldValue: data,
+		})
+		if err != nil {
+			return err
+		}
+
+		if stored {
+			return nil
+		}
+	}
+
+	return errors.Errorf("failed to update the notification digest after %d attempts", maxAtomicUpdateAttempts)
+}
+
+// LoadNotificationDigests loads the digests in the order their first events were added.
+// The digests removed from the KV store after being listed are skipped.
+func (s *pluginStore) LoadNotificationDigests() ([]*serializer.NotificationDigest, error) {
+	ids := []string{}
+	if err := kvstore.LoadJSON(s.basicKV, constants.NotificationDigestsKey, &ids); err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	digests := make([]*serializer.NotificationDigest, 0, len(ids))
+	for _, id := range ids {
+		digest := serializer.NotificationDigest{}
+		if err := kvstore.LoadJSON(s.digestKV, id, &digest); err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		digests = append(digests, &digest)
+	}
+
+	return digests, nil
+}
+
+func (s *pluginStore) DeleteNotificationDigest(digestID string) error {
+	if err := s.updateNotificationList(constants.NotificationDigestsKey, func(ids []string) []string {
+		return removeNotificationID(ids, digestID)
+	}); err != nil {
+		return err
+	}
+
+	return s.digestKV.Delete(digestID)
+}
+
+// updateNotificationList atomically replaces the IDs of the notification digests in a list.
+// The update is retried if the list gets modified concurrently by another server.
+func (s *pluginStore) updateNotificationList(listKey string, update func(ids []string) []string) error {
+	s.notificationListsLock.Lock()
+	defer s.notificationListsLock.Unlock()
+
+	for attempt := 0; attempt < maxAtomicUpdateAttempts; attempt++ {
+		data, err := s.basicKV.Load(listKey)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+
+		ids := []string{}
+		if data != nil {
+			if err = json.Unmarshal(data, &ids); err != nil {
+				return err
+			}
+		}
+
+		updatedData, err := json.Marshal(update(ids))
+		if err != nil {
+			return err
+		}
+
+		stored, err := s.basicKV.StoreWithOptions(listKey, updatedData, model.PluginKVSetOptions{
+			Atomic:   true,
+			OldValue: data,
+		})
+		if err != nil {
+			return err
+		}
+
+		if stored {
+			return nil
+		}
+	}
+
+	return errors.Errorf("failed to update the list of notification digests after %d attempts", maxAtomicUpdateAttempts)
+}
+
+func removeNotificationID(ids []string, notificationID string) []string {
+	remaining := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id != notificationID {
+			remaining = append(remaining, id)
+		}
+	}
+
+	return remaining
+}
+
+func (s *pluginStore) LoadWarRoomChannelID(teamID, incidentID string) (string, error) {
+	data, err := s.basicKV.Load(getWarRoomKey(teamID, incidentID))
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+func (s *pluginStore) StoreWarRoomChannelID(teamID, incidentID, channelID string) error {
+	return s.basicKV.Store(getWarRoomKey(teamID, incidentID), []byte(channelID))
+}
+
+func getWarRoomKey(teamID, incidentID string) string {
+	return constants.WarRoomKeyPrefix + teamID + "_" + incidentID
+}
@@ -1,12 +1,17 @@
 package plugin
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"testing"
 
 	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
 	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
@@ -145,6 +150,94 @@ func TestDeleteUser(t *testing.T) {
 	}
 }
 
+func TestUpdateUserToken(t *testing.T) {
+	ps := new(pluginStore)
+	p := Plugin{}
+	ps.userKV = kvstore.NewHashedKeyStore(kvstore.NewPluginStore(p.API), constants.UserKeyPrefix)
+	storedUser, _ := json.Marshal(testutils.GetSerializerUser())
+	for _, test := range []struct {
+		description       string
+		loadError         error
+		shouldUpdate      bool
+		storeResults      []bool
+		storeError        error
+		expectedStoreCall int
+		expectedError     string
+	}{
+		{
+			description:       "Token is updated",
+			shouldUpdate:      true,
+			storeResults:      []bool{true},
+			expectedStoreCall: 1,
+		},
+		{
+			description:       "Token is updated after the user is modified concurrently",
+			shouldUpdate:      true,
+			storeResults:      []bool{false, true},
+			expectedStoreCall: 2,
+		},
+		{
+			description:  "Token is not updated because the stored token has changed",
+			shouldUpdate: false,
+		},
+		{
+			description:   "User is not loaded",
+			loadError:     ErrNotFound,
+			expectedError: ErrNotFound.Error(),
+		},
+		{
+			description:       "Token is not stored",
+			shouldUpdate:      true,
+			storeError:        fmt.Errorf("error in storing the user"),
+			storeResults:      []bool{false},
+			expectedStoreCall: 1,
+			expectedError:     "error in storing the user",
+		},
+		{
+			description:       "Token is not updated because the user is modified concurrently every time",
+			shouldUpdate:      true,
+			storeResults:      []bool{false, false, false, false, false},
+			expectedStoreCall: maxAtomicUpdateAttempts,
+			expectedError:     fmt.Sprintf("failed to update the token of the user after %d attempts", maxAtomicUpdateAttempts),
+		},
+	} {
+		t.Run(test.description, func(t *testing.T) {
+			assert := assert.New(t)
+			defer monkey.UnpatchAll()
+			monkey.PatchInstanceMethod(reflect.TypeOf(ps.userKV), "Load", func(*kvstore.HashedKeyStore, string) ([]byte, error) {
+				return storedUser, test.loadError
+			})
+
+			storeCalls := 0
+			monkey.PatchInstanceMethod(reflect.TypeOf(ps.userKV), "StoreWithOptions", func(_ *kvstore.HashedKeyStore, _ string, value []byte, opts model.PluginKVSetOptions) (bool, error) {
+				assert.True(opts.Atomic)
+				assert.Equal(storedUser, opts.OldValue)
+
+				user := &serializer.User{}
+				assert.Nil(json.Unmarshal(value, user))
+				assert.Equal("mockEncodedToken", user.OAuth2Token)
+
+				stored := test.storeResults[storeCalls]
+				storeCalls++
+				return stored, test.storeError
+			})
+
+			err := ps.UpdateUserToken(testutils.GetID(), "mockEncodedToken", func(storedToken string) bool {
+				assert.Equal(testutils.GetSerializerUser().OAuth2Token, storedToken)
+				return test.shouldUpdate
+			})
+
+			assert.Equal(test.expectedStoreCall, storeCalls)
+			if test.expectedError != "" {
+				assert.EqualError(err, test.expectedError)
+				return
+			}
+
+			assert.Nil(err)
+		})
+	}
+}
+
 func TestVerifyOAuth2State(t *testing.T) {
 	ps := new(pluginStore)
 	p := Plugin{}
@@ -187,3 +280,192 @@ func TestVerifyOAuth2State(t *testing.T) {
 		})
 	}
 }
+
+// memoryKVStore is a KV store kept in memory, which supports the atomic updates of the plugin KV store
+type memoryKVStore map[string][]byte
+
+func (s memoryKVStore) Load(key string) ([]byte, error) {
+	data, ok := s[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return data, nil
+}
+
+func (s memoryKVStore) Store(key string, data []byte) error {
+	s[key] = data
+	return nil
+}
+
+func (s memoryKVStore) StoreTTL(key string, data []byte, _ int64) error {
+	return s.Store(key, data)
+}
+
+func (s memoryKVStore) StoreWithOptions(key string, value []byte, opts model.PluginKVSetOptions) (bool, error) {
+	if opts.Atomic && string(s[key]) != string(opts.OldValue) {
+		return false, nil
+	}
+	return true, s.Store(key, value)
+}
+
+func (s memoryKVStore) Delete(key string) error {
+	delete(s, key)
+	return nil
+}
+
+// list returns a page of the sorted keys, like the KVList method of the plugin API
+func (s memoryKVStore) list(page, perPage int) []string {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	start, end := page*perPage, (page+1)*perPage
+	if start > len(keys) {
+		return []string{}
+	}
+	if end > len(keys) {
+		end = len(keys)
+	}
+	return keys[start:end]
+}
+
+func setupNotificationQueueStore() (*pluginStore, memoryKVStore) {
+	kv := memoryKVStore{}
+	api := &plugintest.API{}
+	api.On("KVList", mock.AnythingOfType("int"), mock.AnythingOfType("int")).Return(kv.list, nil)
+
+	p := &Plugin{}
+	p.SetAPI(api)
+	return &pluginStore{
+		plugin:         p,
+		basicKV:        kv,
+		notificationKV: kvstore.NewHashedKeyStore(kv, constants.QueuedNotificationKeyPrefix),
+		deadLetterKV:   kvstore.NewHashedKeyStore(kv, constants.DeadLetterNotificationKeyPrefix),
+	}, kv
+}
+
+func TestStoreWebhookSecretRotation(t *testing.T) {
+	assert := assert.New(t)
+	ps := &pluginStore{basicKV: memoryKVStore{}}
+
+	isFirst, err := ps.StoreWebhookSecretRotation("firstHash")
+	assert.Nil(err)
+	assert.True(isFirst)
+
+	// The rotation is received again by another server
+	isFirst, err = ps.StoreWebhookSecretRotation("firstHash")
+	assert.Nil(err)
+	assert.False(isFirst)
+
+	isFirst, err = ps.StoreWebhookSecretRotation("secondHash")
+	assert.Nil(err)
+	assert.True(isFirst)
+
+	// The secret is rotated back to the first one
+	isFirst, err = ps.StoreWebhookSecretRotation("firstHash")
+	assert.Nil(err)
+	assert.True(isFirst)
+}
+
+func TestNotificationQueueStore(t *testing.T) {
+	assert := assert.New(t)
+	ps, _ := setupNotificationQueueStore()
+	getIDs := func(notifications []*serializer.QueuedNotification) []string {
+		ids := []string{}
+		for _, notification := range notifications {
+			ids = append(ids, notification.ID)
+		}
+		return ids
+	}
+
+	queued, err := ps.LoadQueuedNotifications()
+	assert.Nil(err)
+	assert.Empty(queued)
+
+	for i, id := range []string{"notification3", "notification2", "notification1"} {
+		assert.Nil(ps.EnqueueNotification(&serializer.QueuedNotification{ID: id, Event: &serializer.ServiceNowEvent{Number: id}, CreatedAt: int64(3 - i)}))
+	}
+
+	queued, err = ps.LoadQueuedNotifications()
+	assert.Nil(err)
+	assert.Equal([]string{"notification1", "notification2", "notification3"}, getIDs(queued))
+
+	assert.Nil(ps.UpdateQueuedNotification(&serializer.QueuedNotification{ID: "notification2", Attempts: 1, CreatedAt: 2}))
+	assert.Nil(ps.DequeueNotification("notification1"))
+	assert.Nil(ps.MoveNotificationToDeadLetters(&serializer.QueuedNotification{ID: "notification3", Attempts: maxNotificationPostAttempts, CreatedAt: 3}))
+
+	queued, err = ps.LoadQueuedNotifications()
+	assert.Nil(err)
+	assert.Equal([]string{"notification2"}, getIDs(queued))
+	assert.Equal(1, queued[0].Attempts)
+
+	deadLetters, err := ps.LoadDeadLetterNotifications()
+	assert.Nil(err)
+	assert.Equal([]string{"notification3"}, getIDs(deadLetters))
+	assert.Equal(maxNotificationPostAttempts, deadLetters[0].Attempts)
+
+	assert.Nil(ps.MoveNotificationToQueue(&serializer.QueuedNotification{ID: "notification3", CreatedAt: 3}))
+
+	queued, err = ps.LoadQueuedNotifications()
+	assert.Nil(err)
+	assert.Equal([]string{"notification2", "notification3"}, getIDs(queued))
+
+	deadLetters, err = ps.LoadDeadLetterNotifications()
+	assert.Nil(err)
+	assert.Empty(deadLetters)
+}
+
+func TestMoveNotificationToDeadLettersDiscardsOldest(t *testing.T) {
+	assert := assert.New(t)
+	ps, _ := setupNotificationQueueStore()
+
+	for i := 0; i <= maxDeadLetterNotifications; i++ {
+		assert.Nil(ps.MoveNotificationToDeadLetters(&serializer.QueuedNotification{ID: fmt.Sprintf("notification%d", i), CreatedAt: int64(i)}))
+	}
+
+	deadLetters, err := ps.LoadDeadLetterNotifications()
+	assert.Nil(err)
+	assert.Len(deadLetters, maxDeadLetterNotifications)
+	assert.Equal("notification1", deadLetters[0].ID)
+
+	_, err = ps.deadLetterKV.Load("notification0")
+	assert.Equal(ErrNotFound, err)
+}
+
+func TestNotificationDigestStore(t *testing.T) {
+	assert := assert.New(t)
+	kv := memoryKVStore{}
+	ps := &pluginStore{
+		basicKV:  kv,
+		digestKV: kvstore.NewHashedKeyStore(kv, constants.NotificationDigestKeyPrefix),
+	}
+
+	digests, err := ps.LoadNotificationDigests()
+	assert.Nil(err)
+	assert.Empty(digests)
+
+	for _, number := range []string{"INC0000001", "INC0000002"} {
+		assert.Nil(ps.AddEventToNotificationDigest(&serializer.NotificationDigest{ID: "digest1", SubscriptionID: "subscription1"}, &serializer.ServiceNowEvent{Number: number}))
+	}
+	assert.Nil(ps.AddEventToNotificationDigest(&serializer.NotificationDigest{ID: "digest2", SubscriptionID: "subscription2"}, &serializer.ServiceNowEvent{Number: "INC0000003"}))
+
+	digests, err = ps.LoadNotificationDigests()
+	assert.Nil(err)
+	assert.Len(digests, 2)
+	assert.Equal("digest1", digests[0].ID)
+	assert.Equal("subscription1", digests[0].SubscriptionID)
+	assert.Len(digests[0].Events, 2)
+	assert.Equal("INC0000002", digests[0].Events[1].Number)
+
+	assert.Nil(ps.DeleteNotificationDigest("digest1"))
+
+	digests, err = ps.LoadNotificationDigests()
+	assert.Nil(err)
+	assert.Len(digests, 1)
+	assert.Equal("digest2", digests[0].ID)
+
+	_, err = ps.digestKV.Load("digest1")
+	assert.Equal(ErrNotFound, err)
+}
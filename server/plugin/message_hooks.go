@@ -0,0 +1,136 @@
+package plugin
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+)
+
+var (
+	urlRegex          = regexp.MustCompile(`https?://[^\s<>()\[\]]+`)
+	recordURLRegex    = regexp.MustCompile(`([a-z][a-z0-9_]*)\.do(?:\?|%3F)sys_id(?:=|%3D)([0-9a-f]{32})`)
+	recordNumberRegex = regexp.MustCompile(`\b(INC|PRB|CHG|KB|TASK|CTASK)[0-9]{7,}\b`)
+)
+
+// recordReference is a ServiceNow record referred to in a message, either by a link containing its sys ID or by its number
+type recordReference struct {
+	RecordType string
+	SysID      string
+	Number     string
+}
+
+// MessageHasBeenPosted unfurls the links to the ServiceNow records and the record numbers present in a message
+// by attaching the details of the records to the post. The records are fetched with the token of the poster,
+// so only the records visible to the poster in ServiceNow are unfurled.
+// The records are fetched after the message is posted, so that a slow ServiceNow instance does not delay posting it.
+func (p *Plugin) MessageHasBeenPosted(_ *plugin.Context, post *model.Post) {
+	if post.UserId == p.botID || post.IsSystemMessage() || len(post.Attachments()) > 0 {
+		return
+	}
+
+	references := p.getRecordReferences(post.Message)
+	if len(references) == 0 {
+		return
+	}
+
+	client, err := p.GetClientForUser(post.UserId)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			p.API.LogWarn("Unable to get the client for unfurling the records", "UserID", post.UserId, "Error", err.Error())
+		}
+		return
+	}
+
+	var attachments []*model.SlackAttachment
+	for _, reference := range references {
+		record := p.getRecordForUnfurling(client, reference)
+		if record == nil {
+			continue
+		}
+
+		sharingPost := record.CreateSharingPost(post.ChannelId, p.botID, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL(), "", p.getConfiguration().RecordTypeRegistry)
+		attachments = append(attachments, sharingPost.Attachments()...)
+	}
+
+	if len(attachments) == 0 {
+		return
+	}
+
+	model.ParseSlackAttachment(post, attachments)
+	if _, appErr := p.API.UpdatePost(post); appErr != nil {
+		p.API.LogError(constants.ErrorUpdatePost, "PostID", post.Id, "Error", appErr.Error())
+	}
+}
+
+// getRecordReferences returns the shareable records referred to in the message by the links to the configured
+// ServiceNow instance or by their numbers, without duplicates and limited to MaxUnfurledRecordsPerPost
+func (p *Plugin) getRecordReferences(message string) []*recordReference {
+	recordTypes := p.getConfiguration().RecordTypeRegistry
+	serviceNowURL := strings.TrimSuffix(p.getConfiguration().ServiceNowBaseURL, "/")
+	seen := map[string]bool{}
+	var references []*recordReference
+	addReference := func(reference *recordReference, key string) {
+		if seen[key] || len(references) == constants.MaxUnfurledRecordsPerPost || !recordTypes.IsShareable(reference.RecordType) {
+			return
+		}
+
+		seen[key] = true
+		references = append(references, reference)
+	}
+
+	if serviceNowURL != "" {
+		for _, link := range urlRegex.FindAllString(message, -1) {
+			if !strings.HasPrefix(link, serviceNowURL+"/") {
+				continue
+			}
+
+			match := recordURLRegex.FindStringSubmatch(link)
+			if match == nil {
+				continue
+			}
+
+			addReference(&recordReference{RecordType: match[1], SysID: match[2]}, match[2])
+		}
+	}
+
+	for _, match := range recordNumberRegex.FindAllStringSubmatch(message, -1) {
+		addReference(&recordReference{RecordType: constants.RecordNumberPrefixes[match[1]], Number: match[0]}, match[0])
+	}
+
+	return references
+}
+
+// getRecordForUnfurling fetches the referred record from ServiceNow. It returns nil if the record does not exist
+// or can't be fetched, e.g. when an ACL of ServiceNow restricts the poster from viewing the record.
+func (p *Plugin) getRecordForUnfurling(client Client, reference *recordReference) *serializer.ServiceNowRecord {
+	var record *serializer.ServiceNowRecord
+	var err error
+	if reference.SysID != "" {
+		record, _, err = client.GetRecordFromServiceNow(reference.RecordType, reference.SysID)
+	} else {
+		record, _, err = client.GetRecordByNumber(reference.RecordType, reference.Number)
+	}
+
+	if err != nil {
+		p.API.LogDebug(constants.ErrorGetRecord, "RecordType", reference.RecordType, "Error", err.Error())
+		return nil
+	}
+
+	if record == nil {
+		return nil
+	}
+
+	record.RecordType = reference.RecordType
+	if err = record.HandleNestedFields(p.getConfiguration().ServiceNowBaseURL); err != nil {
+		p.API.LogDebug(constants.ErrorHandlingNestedFields, "Error", err.Error())
+		return nil
+	}
+
+	return record
+}
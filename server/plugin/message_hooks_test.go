@@ -0,0 +1,142 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	mock_plugin "github.com/mattermost/mattermost-plugin-servicenow/server/mocks"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/testutils"
+)
+
+func TestMessageHasBeenPosted(t *testing.T) {
+	serviceNowURL := "https://test.service-now.com"
+	for name, test := range map[string]struct {
+		Message             string
+		SetupAPI            func(api *plugintest.API)
+		SetupClient         func(client *mock_plugin.Client)
+		ExpectedAttachments int
+	}{
+		"link to a record of the instance": {
+			Message:  fmt.Sprintf("Please look at %s/nav_to.do?uri=incident.do?sys_id=%s", serviceNowURL, testutils.GetServiceNowSysID()),
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordFromServiceNow", constants.RecordTypeIncident, testutils.GetServiceNowSysID()).Return(&serializer.ServiceNowRecord{
+					SysID:  testutils.GetServiceNowSysID(),
+					Number: "INC0012345",
+				}, http.StatusOK, nil)
+			},
+			ExpectedAttachments: 1,
+		},
+		"record numbers": {
+			Message:  "Is INC0012345 related to CHG0000123?",
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordByNumber", constants.RecordTypeIncident, "INC0012345").Return(&serializer.ServiceNowRecord{Number: "INC0012345"}, http.StatusOK, nil)
+				client.On("GetRecordByNumber", constants.RecordTypeChangeRequest, "CHG0000123").Return(&serializer.ServiceNowRecord{Number: "CHG0000123"}, http.StatusOK, nil)
+			},
+			ExpectedAttachments: 2,
+		},
+		"record restricted by an ACL": {
+			Message: "Is INC0012345 fixed?",
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordByNumber", constants.RecordTypeIncident, "INC0012345").Return(nil, http.StatusNotFound, errors.New(constants.ErrorACLRestrictsRecordRetrieval))
+			},
+		},
+		"record number not found": {
+			Message:  "Is INC0012345 fixed?",
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetRecordByNumber", constants.RecordTypeIncident, "INC0012345").Return(nil, http.StatusOK, nil)
+			},
+		},
+		"link to another instance": {
+			Message:     fmt.Sprintf("https://other.service-now.com/incident.do?sys_id=%s", testutils.GetServiceNowSysID()),
+			SetupAPI:    func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {},
+		},
+		"no references": {
+			Message:     "Hello world",
+			SetupAPI:    func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer monkey.UnpatchAll()
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			p.setConfiguration(&configuration{ServiceNowBaseURL: serviceNowURL})
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			client := mock_plugin.NewClient(t)
+			test.SetupClient(client)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetClientForUser", func(_ *Plugin, _ string) (Client, error) {
+				return client, nil
+			})
+
+			var updatedPost *model.Post
+			if test.ExpectedAttachments > 0 {
+				api.On("UpdatePost", mock.AnythingOfType("*model.Post")).Run(func(args mock.Arguments) {
+					updatedPost = args.Get(0).(*model.Post)
+				}).Return(&model.Post{}, nil).Once()
+			}
+
+			post := &model.Post{Id: testutils.GetID(), UserId: testutils.GetID(), ChannelId: testutils.GetChannelID(), Message: test.Message}
+			p.MessageHasBeenPosted(nil, post)
+
+			if test.ExpectedAttachments == 0 {
+				assert.Nil(t, updatedPost)
+				return
+			}
+
+			require.NotNil(t, updatedPost)
+			assert.Equal(t, test.Message, updatedPost.Message)
+			assert.Len(t, updatedPost.Attachments(), test.ExpectedAttachments)
+		})
+	}
+
+	t.Run("poster is not connected", func(t *testing.T) {
+		defer monkey.UnpatchAll()
+		p, api := setupTestPlugin(&plugintest.API{}, nil)
+		defer api.AssertExpectations(t)
+		monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetClientForUser", func(_ *Plugin, _ string) (Client, error) {
+			return nil, ErrNotFound
+		})
+
+		p.MessageHasBeenPosted(nil, &model.Post{UserId: testutils.GetID(), Message: "INC0012345"})
+	})
+
+	t.Run("post by the bot", func(t *testing.T) {
+		p, api := setupTestPlugin(&plugintest.API{}, nil)
+		p.botID = "mockBotID"
+		defer api.AssertExpectations(t)
+
+		p.MessageHasBeenPosted(nil, &model.Post{UserId: "mockBotID", Message: "INC0012345"})
+	})
+}
+
+func TestGetRecordReferences(t *testing.T) {
+	p, _ := setupTestPlugin(&plugintest.API{}, nil)
+	p.setConfiguration(&configuration{ServiceNowBaseURL: "https://test.service-now.com"})
+
+	references := p.getRecordReferences(fmt.Sprintf("INC0000001 INC0000001 TASK0000002 CTASK0000003 https://test.service-now.com/now/nav/ui/classic/params/target/problem.do%%3Fsys_id%%3D%s", testutils.GetServiceNowSysID()))
+
+	require.Len(t, references, constants.MaxUnfurledRecordsPerPost)
+	assert.Equal(t, &recordReference{RecordType: constants.RecordTypeProblem, SysID: testutils.GetServiceNowSysID()}, references[0])
+	assert.Equal(t, &recordReference{RecordType: constants.RecordTypeIncident, Number: "INC0000001"}, references[1])
+	assert.Equal(t, &recordReference{RecordType: constants.RecordTypeTask, Number: "TASK0000002"}, references[2])
+}
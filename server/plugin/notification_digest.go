@@ -0,0 +1,126 @@
+package plugin
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+)
+
+const (
+	notificationDigestJobKey = "notification_digests"
+
+	// The windows of the digests end on the hour, so the job runs at the start of every hour
+	notificationDigestJobInterval = time.Hour
+
+	// The digests which still can't be posted long after the end of their window are discarded
+	maxNotificationDigestDelay = 24 * time.Hour
+)
+
+// startNotificationDigestJob schedules posting the digests whose window has ended.
+// The scheduled job runs on only one server of the cluster at a time.
+func (p *Plugin) startNotificationDigestJob() error {
+	job, err := cluster.Schedule(p.API, notificationDigestJobKey, cluster.MakeWaitForRoundedInterval(notificationDigestJobInterval), func() {
+		p.postNotificationDigests(time.Now())
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to schedule the notification digest job")
+	}
+
+	p.notificationDigestJob = job
+	return nil
+}
+
+// getSubscriptionDeliveryMode returns the delivery mode of a bulk subscription.
+// The notifications are posted immediately if the delivery mode can't be loaded, so that they are not delayed.
+func (p *Plugin) getSubscriptionDeliveryMode(subscriptionID string) string {
+	deliveryMode, err := p.store.LoadSubscriptionDeliveryMode(subscriptionID)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			p.API.LogError(constants.ErrorLoadSubscriptionDeliveryMode, "SubscriptionID", subscriptionID, "Error", err.Error())
+		}
+		return constants.DeliveryModeImmediate
+	}
+
+	if !constants.ValidDeliveryModes[deliveryMode] {
+		return constants.DeliveryModeImmediate
+	}
+
+	return deliveryMode
+}
+
+// LoadSubscriptionDeliveryMode sets the stored delivery mode on the given subscription if it is a bulk subscription
+func (p *Plugin) LoadSubscriptionDeliveryMode(subscription *serializer.SubscriptionResponse) {
+	if subscription.Type != constants.SubscriptionTypeBulk {
+		return
+	}
+
+	subscription.DeliveryMode = p.getSubscriptionDeliveryMode(subscription.SysID)
+}
+
+// addEventToNotificationDigest buffers the event in the digest of the current window of its subscription
+func (p *Plugin) addEventToNotificationDigest(event *serializer.ServiceNowEvent, deliveryMode string, now time.Time) error {
+	digest := serializer.NewNotificationDigest(event, deliveryMode, now)
+	if err := p.store.AddEventToNotificationDigest(digest, event); err != nil {
+		p.API.LogError(constants.ErrorAddEventToNotificationDigest, "SubscriptionID", event.SubscriptionID, "Error", err.Error())
+		return errors.Wrap(err, constants.ErrorAddEventToNotificationDigest)
+	}
+
+	return nil
+}
+
+// postNotificationDigests posts the digests whose window has ended.
+// A digest which could not be posted is retried on the next run of the job.
+func (p *Plugin) postNotificationDigests(now time.Time) {
+	digests, err := p.store.LoadNotificationDigests()
+	if err != nil {
+		p.API.LogError(constants.ErrorLoadNotificationDigests, "Error", err.Error())
+		return
+	}
+
+	for _, digest := range digests {
+		if !digest.IsDue(now) {
+			continue
+		}
+
+		post := digest.CreateDigestPost(p.botID, p.getConfiguration().ServiceNowBaseURL, p.getConfiguration().RecordTypeRegistry)
+		if _, postErr := p.API.CreatePost(post); postErr != nil {
+			p.API.LogError(constants.ErrorCreatePost, "DigestID", digest.ID, "Error", postErr.Error())
+			if now.Sub(time.Unix(digest.WindowEnd, 0)) < maxNotificationDigestDelay {
+				continue
+			}
+
+			p.API.LogWarn("Discarding the notification digest as it could not be posted", "DigestID", digest.ID)
+		}
+
+		if err = p.store.DeleteNotificationDigest(digest.ID); err != nil {
+			p.API.LogError(constants.ErrorDeleteNotificationDigest, "DigestID", digest.ID, "Error", err.Error())
+		}
+	}
+}
+
+// deleteSubscriptionDigests deletes the delivery mode and discards the pending digests of a deleted subscription
+func (p *Plugin) deleteSubscriptionDigests(subscriptionID string) {
+	if err := p.store.DeleteSubscriptionDeliveryMode(subscriptionID); err != nil {
+		p.API.LogWarn(constants.ErrorDeleteSubscriptionDeliveryMode, "SubscriptionID", subscriptionID, "Error", err.Error())
+	}
+
+	digests, err := p.store.LoadNotificationDigests()
+	if err != nil {
+		p.API.LogWarn(constants.ErrorLoadNotificationDigests, "Error", err.Error())
+		return
+	}
+
+	for _, digest := range digests {
+		if digest.SubscriptionID != subscriptionID {
+			continue
+		}
+
+		if err = p.store.DeleteNotificationDigest(digest.ID); err != nil {
+			p.API.LogWarn(constants.ErrorDeleteNotificationDigest, "DigestID", digest.ID, "Error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package plugin
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	mock_plugin "github.com/mattermost/mattermost-plugin-servicenow/server/mocks"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/testutils"
+)
+
+func TestGetSubscriptionDeliveryMode(t *testing.T) {
+	for name, test := range map[string]struct {
+		SetupAPI             func(*plugintest.API)
+		SetupStore           func(*mock_plugin.Store)
+		ExpectedDeliveryMode string
+	}{
+		"delivery mode is not stored": {
+			SetupAPI: func(api *plugintest.API) {},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionDeliveryMode", "subscriptionID").Return("", ErrNotFound)
+			},
+			ExpectedDeliveryMode: constants.DeliveryModeImmediate,
+		},
+		"delivery mode is stored": {
+			SetupAPI: func(api *plugintest.API) {},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionDeliveryMode", "subscriptionID").Return(constants.DeliveryModeDaily, nil)
+			},
+			ExpectedDeliveryMode: constants.DeliveryModeDaily,
+		},
+		"stored delivery mode is not valid": {
+			SetupAPI: func(api *plugintest.API) {},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionDeliveryMode", "subscriptionID").Return("weekly", nil)
+			},
+			ExpectedDeliveryMode: constants.DeliveryModeImmediate,
+		},
+		"failed to load the delivery mode": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", constants.ErrorLoadSubscriptionDeliveryMode, "SubscriptionID", "subscriptionID", "Error", "kv error").Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionDeliveryMode", "subscriptionID").Return("", errors.New("kv error"))
+			},
+			ExpectedDeliveryMode: constants.DeliveryModeImmediate,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			assert.Equal(t, test.ExpectedDeliveryMode, p.getSubscriptionDeliveryMode("subscriptionID"))
+		})
+	}
+}
+
+func TestProcessNotificationOfDigestSubscription(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for name, test := range map[string]struct {
+		SetupAPI      func(*plugintest.API)
+		AddEventError error
+		ExpectedError string
+	}{
+		"event is added to the digest": {
+			SetupAPI: func(api *plugintest.API) {},
+		},
+		"failed to add the event to the digest": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", constants.ErrorAddEventToNotificationDigest, "SubscriptionID", "subscriptionID", "Error", "kv error").Return()
+			},
+			AddEventError: errors.New("kv error"),
+			ExpectedError: constants.ErrorAddEventToNotificationDigest,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			event := &serializer.ServiceNowEvent{
+				SubscriptionID:   "subscriptionID",
+				SubscriptionType: constants.SubscriptionTypeBulk,
+				ChannelID:        testutils.GetChannelID(),
+			}
+
+			store := mock_plugin.NewStore(t)
+			store.On("LoadSubscriptionDeliveryMode", "subscriptionID").Return(constants.DeliveryModeHourly, nil)
+			store.On("AddEventToNotificationDigest", mock.MatchedBy(func(digest *serializer.NotificationDigest) bool {
+				return strings.HasPrefix(digest.ID, "subscriptionID_") && digest.DeliveryMode == constants.DeliveryModeHourly && digest.ChannelID == testutils.GetChannelID()
+			}), event).Return(test.AddEventError)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "ValidateEventForSubscription", func(_ *Plugin, _ *serializer.ServiceNowEvent) error {
+				return nil
+			})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "EventMatchesSubscriptionFilters", func(_ *Plugin, _ *serializer.ServiceNowEvent) (bool, error) {
+				return true, nil
+			})
+
+			err := p.ProcessNotification(event)
+			if test.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPostNotificationDigests(t *testing.T) {
+	now := time.Date(2024, time.January, 10, 14, 0, 0, 0, time.UTC)
+	getDigest := func(id string, windowEnd time.Time) *serializer.NotificationDigest {
+		return &serializer.NotificationDigest{
+			ID:           id,
+			ChannelID:    id,
+			RecordType:   constants.RecordTypeIncident,
+			DeliveryMode: constants.DeliveryModeHourly,
+			WindowEnd:    windowEnd.Unix(),
+			Events:       []*serializer.ServiceNowEvent{{Number: "INC0000001", EventOccurred: constants.SubscriptionEventState}},
+		}
+	}
+	isPostForChannel := func(channelID string) interface{} {
+		return mock.MatchedBy(func(post *model.Post) bool {
+			return post.ChannelId == channelID
+		})
+	}
+
+	store := mock_plugin.NewStore(t)
+	store.On("LoadNotificationDigests").Return([]*serializer.NotificationDigest{
+		getDigest("dueDigest", now),
+		getDigest("laterDigest", now.Add(time.Hour)),
+		getDigest("failedDigest", now.Add(-time.Hour)),
+		getDigest("expiredDigest", now.Add(-maxNotificationDigestDelay)),
+	}, nil)
+	store.On("DeleteNotificationDigest", "dueDigest").Return(nil)
+	store.On("DeleteNotificationDigest", "expiredDigest").Return(nil)
+
+	p, api := setupTestPlugin(&plugintest.API{}, store)
+	api.On("CreatePost", isPostForChannel("dueDigest")).Return(&model.Post{}, nil)
+	api.On("CreatePost", isPostForChannel("failedDigest")).Return(nil, testutils.GetBadRequestAppError())
+	api.On("CreatePost", isPostForChannel("expiredDigest")).Return(nil, testutils.GetBadRequestAppError())
+	api.On("LogError", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+	api.On("LogWarn", "Discarding the notification digest as it could not be posted", "DigestID", "expiredDigest").Return()
+	defer api.AssertExpectations(t)
+
+	p.postNotificationDigests(now)
+}
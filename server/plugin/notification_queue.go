@@ -0,0 +1,222 @@
+package plugin
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-api/cluster"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+)
+
+const (
+	notificationQueueMutexKey = "notification_queue_mutex"
+
+	// The queue is also checked periodically, for retrying the notifications and posting the ones queued on another server
+	notificationQueuePollInterval = 15 * time.Second
+
+	// The delay before retrying a notification is doubled after every failed attempt, up to the maximum delay
+	notificationRetryDelay      = 10 * time.Second
+	maxNotificationRetryDelay   = 10 * time.Minute
+	maxNotificationPostAttempts = 8
+)
+
+// startNotificationQueueWorker starts posting the queued notifications in the background.
+// A cluster mutex ensures that the queue is processed by only one server at a time.
+func (p *Plugin) startNotificationQueueWorker() error {
+	mutex, err := cluster.NewMutex(p.API, notificationQueueMutexKey)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the mutex for the notification queue")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.notificationQueueSignal = make(chan struct{}, 1)
+	p.stopNotificationQueueWorker = cancel
+
+	go p.runNotificationQueueWorker(ctx, mutex, p.notificationQueueSignal)
+	return nil
+}
+
+func (p *Plugin) runNotificationQueueWorker(ctx context.Context, mutex *cluster.Mutex, signal <-chan struct{}) {
+	ticker := time.NewTicker(notificationQueuePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-signal:
+		}
+
+		if err := mutex.LockWithContext(ctx); err != nil {
+			return
+		}
+
+		p.processNotificationQueue(time.Now())
+		mutex.Unlock()
+	}
+}
+
+// signalNotificationQueue wakes up the worker, so that a notification queued by this server is posted immediately
+func (p *Plugin) signalNotificationQueue() {
+	if p.notificationQueueSignal == nil {
+		return
+	}
+
+	select {
+	case p.notificationQueueSignal <- struct{}{}:
+	default:
+	}
+}
+
+// enqueueNotification adds the event of a webhook request to the queue of the notifications to be posted
+func (p *Plugin) enqueueNotification(event *serializer.ServiceNowEvent) error {
+	if err := p.store.EnqueueNotification(&serializer.QueuedNotification{
+		ID:        model.NewId(),
+		Event:     event,
+		CreatedAt: time.Now().Unix(),
+	}); err != nil {
+		return err
+	}
+
+	p.signalNotificationQueue()
+	return nil
+}
+
+// processNotificationQueue posts the queued notifications which are due to be attempted
+func (p *Plugin) processNotificationQueue(now time.Time) {
+	notifications, err := p.store.LoadQueuedNotifications()
+	if err != nil {
+		p.API.LogError(constants.ErrorLoadNotificationQueue, "Error", err.Error())
+		return
+	}
+
+	for _, notification := range notifications {
+		if notification.NextAttemptAt > now.Unix() {
+			continue
+		}
+
+		p.deliverQueuedNotification(notification, now)
+	}
+}
+
+// deliverQueuedNotification attempts to post a queued notification. If it fails, the notification is retried
+// with an exponential backoff, and it is moved to the dead letters after the maximum number of attempts.
+func (p *Plugin) deliverQueuedNotification(notification *serializer.QueuedNotification, now time.Time) {
+	if notification.Event == nil {
+		if err := p.store.DequeueNotification(notification.ID); err != nil {
+			p.API.LogError(constants.ErrorUpdateNotificationQueue, "NotificationID", notification.ID, "Error", err.Error())
+		}
+		return
+	}
+
+	// The event is copied, as it gets modified while being posted
+	event := *notification.Event
+	postErr := p.ProcessNotification(&event)
+	if postErr == nil {
+		if err := p.store.DequeueNotification(notification.ID); err != nil {
+			p.API.LogError(constants.ErrorUpdateNotificationQueue, "NotificationID", notification.ID, "Error", err.Error())
+		}
+		return
+	}
+
+	notification.Attempts++
+	notification.LastError = postErr.Error()
+	if notification.Attempts >= maxNotificationPostAttempts {
+		p.API.LogError(constants.ErrorNotificationDeadLettered, "NotificationID", notification.ID, "Error", postErr.Error())
+		if err := p.store.MoveNotificationToDeadLetters(notification); err != nil {
+			p.API.LogError(constants.ErrorUpdateNotificationQueue, "NotificationID", notification.ID, "Error", err.Error())
+		}
+		return
+	}
+
+	notification.NextAttemptAt = now.Add(getNotificationRetryDelay(notification.Attempts)).Unix()
+	if err := p.store.UpdateQueuedNotification(notification); err != nil {
+		p.API.LogError(constants.ErrorUpdateNotificationQueue, "NotificationID", notification.ID, "Error", err.Error())
+	}
+}
+
+func getNotificationRetryDelay(attempts int) time.Duration {
+	delay := notificationRetryDelay
+	for i := 1; i < attempts && delay < maxNotificationRetryDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > maxNotificationRetryDelay {
+		return maxNotificationRetryDelay
+	}
+
+	return delay
+}
+
+// ProcessNotification posts the notification of an event received from ServiceNow.
+// An error is returned only if the notification should be retried.
+func (p *Plugin) ProcessNotification(event *serializer.ServiceNowEvent) error {
+	if event.SubscriptionType == constants.SubscriptionTypeApproval {
+		return p.PostApprovalNotification(event)
+	}
+
+	if err := p.ValidateEventForSubscription(event); err != nil {
+		if errors.Is(err, ErrEventSubscriptionMismatch) {
+			p.API.LogWarn(constants.ErrorEventSubscriptionMismatch, "SubscriptionID", event.SubscriptionID, "ChannelID", event.ChannelID, "Error", err.Error())
+			return nil
+		}
+
+		return errors.Wrap(err, "failed to validate the event for its subscription")
+	}
+
+	if event.SubscriptionType == constants.SubscriptionTypePersonal {
+		return p.PostPersonalNotification(event)
+	}
+
+	if event.SubscriptionType == constants.SubscriptionTypeBulk {
+		matches, err := p.EventMatchesSubscriptionFilters(event)
+		if err != nil {
+			return errors.Wrap(err, "failed to check the event against the subscription filters")
+		}
+
+		if !matches {
+			return nil
+		}
+
+		if deliveryMode := p.getSubscriptionDeliveryMode(event.SubscriptionID); deliveryMode != constants.DeliveryModeImmediate {
+			return p.addEventToNotificationDigest(event, deliveryMode, time.Now())
+		}
+	}
+
+	return p.PostNotification(event)
+}
+
+// replayDeadLetterNotifications moves the failed notifications with the given ID, or all of them
+// if the ID is empty, back to the queue to be posted again. It returns the number of replayed notifications.
+func (p *Plugin) replayDeadLetterNotifications(notificationID string) (int, error) {
+	notifications, err := p.store.LoadDeadLetterNotifications()
+	if err != nil {
+		return 0, err
+	}
+
+	replayed := 0
+	for _, notification := range notifications {
+		if notificationID != "" && notification.ID != notificationID {
+			continue
+		}
+
+		notification.Attempts = 0
+		notification.NextAttemptAt = 0
+		notification.LastError = ""
+		if err = p.store.MoveNotificationToQueue(notification); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+
+	if replayed > 0 {
+		p.signalNotificationQueue()
+	}
+
+	return replayed, nil
+}
@@ -0,0 +1,221 @@
+package plugin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	mock_plugin "github.com/mattermost/mattermost-plugin-servicenow/server/mocks"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/testutils"
+)
+
+func TestProcessNotification(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for name, test := range map[string]struct {
+		SetupAPI        func(*plugintest.API)
+		ValidationError error
+		ExpectedError   string
+	}{
+		"success": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, nil)
+			},
+		},
+		"event not matching its subscription": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogWarn", testutils.GetMockArgumentsWithType("string", 7)...).Return()
+			},
+			ValidationError: newEventSubscriptionMismatchError("event is not subscribed"),
+		},
+		"failed to validate the event": {
+			SetupAPI:        func(api *plugintest.API) {},
+			ValidationError: errors.New("failed to get the subscription"),
+			ExpectedError:   "failed to get the subscription",
+		},
+		"failed to create post": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, testutils.GetBadRequestAppError())
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			ExpectedError: constants.ErrorCreatePost,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			test.SetupAPI(api)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "ValidateEventForSubscription", func(_ *Plugin, _ *serializer.ServiceNowEvent) error {
+				return test.ValidationError
+			})
+			defer api.AssertExpectations(t)
+
+			err := p.ProcessNotification(&serializer.ServiceNowEvent{})
+			if test.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.ExpectedError)
+				return
+			}
+
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestDeliverQueuedNotification(t *testing.T) {
+	defer monkey.UnpatchAll()
+	now := time.Now()
+	for name, test := range map[string]struct {
+		Attempts   int
+		PostError  error
+		SetupAPI   func(*plugintest.API)
+		SetupStore func(*mock_plugin.Store)
+	}{
+		"notification is posted": {
+			SetupAPI: func(api *plugintest.API) {},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("DequeueNotification", "notificationID").Return(nil)
+			},
+		},
+		"notification is retried": {
+			Attempts:  1,
+			PostError: errors.New("post error"),
+			SetupAPI:  func(api *plugintest.API) {},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("UpdateQueuedNotification", mock.MatchedBy(func(n *serializer.QueuedNotification) bool {
+					return n.Attempts == 2 && n.LastError == "post error" && n.NextAttemptAt == now.Add(2*notificationRetryDelay).Unix()
+				})).Return(nil)
+			},
+		},
+		"notification is moved to the dead letters after the last attempt": {
+			Attempts:  maxNotificationPostAttempts - 1,
+			PostError: errors.New("post error"),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", constants.ErrorNotificationDeadLettered, "NotificationID", "notificationID", "Error", "post error").Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("MoveNotificationToDeadLetters", mock.MatchedBy(func(n *serializer.QueuedNotification) bool {
+					return n.Attempts == maxNotificationPostAttempts && n.LastError == "post error"
+				})).Return(nil)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			event := &serializer.ServiceNowEvent{Number: "INC0000001"}
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "ProcessNotification", func(_ *Plugin, e *serializer.ServiceNowEvent) error {
+				assert.Equal(t, event, e)
+				assert.NotSame(t, event, e)
+				return test.PostError
+			})
+
+			p.deliverQueuedNotification(&serializer.QueuedNotification{
+				ID:       "notificationID",
+				Event:    event,
+				Attempts: test.Attempts,
+			}, now)
+		})
+	}
+}
+
+func TestProcessNotificationQueue(t *testing.T) {
+	defer monkey.UnpatchAll()
+	now := time.Now()
+	store := mock_plugin.NewStore(t)
+	store.On("LoadQueuedNotifications").Return([]*serializer.QueuedNotification{
+		{ID: "dueNotificationID", Event: &serializer.ServiceNowEvent{}, NextAttemptAt: now.Unix()},
+		{ID: "laterNotificationID", Event: &serializer.ServiceNowEvent{}, NextAttemptAt: now.Add(time.Minute).Unix()},
+	}, nil)
+	store.On("DequeueNotification", "dueNotificationID").Return(nil)
+	p, _ := setupTestPlugin(&plugintest.API{}, store)
+
+	monkey.PatchInstanceMethod(reflect.TypeOf(p), "ProcessNotification", func(_ *Plugin, _ *serializer.ServiceNowEvent) error {
+		return nil
+	})
+
+	p.processNotificationQueue(now)
+}
+
+func TestGetNotificationRetryDelay(t *testing.T) {
+	assert.Equal(t, notificationRetryDelay, getNotificationRetryDelay(1))
+	assert.Equal(t, 2*notificationRetryDelay, getNotificationRetryDelay(2))
+	assert.Equal(t, 8*notificationRetryDelay, getNotificationRetryDelay(4))
+	assert.Equal(t, maxNotificationRetryDelay, getNotificationRetryDelay(100))
+}
+
+func TestReplayDeadLetterNotifications(t *testing.T) {
+	getNotifications := func() []*serializer.QueuedNotification {
+		return []*serializer.QueuedNotification{
+			{ID: "notification1", Attempts: maxNotificationPostAttempts, NextAttemptAt: 1, LastError: "post error"},
+			{ID: "notification2", Attempts: maxNotificationPostAttempts, NextAttemptAt: 1, LastError: "post error"},
+		}
+	}
+	isReset := func(id string) interface{} {
+		return mock.MatchedBy(func(n *serializer.QueuedNotification) bool {
+			return n.ID == id && n.Attempts == 0 && n.NextAttemptAt == 0 && n.LastError == ""
+		})
+	}
+
+	for name, test := range map[string]struct {
+		NotificationID   string
+		SetupStore       func(*mock_plugin.Store)
+		ExpectedReplayed int
+		ExpectedError    string
+	}{
+		"all the notifications are replayed": {
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadDeadLetterNotifications").Return(getNotifications(), nil)
+				s.On("MoveNotificationToQueue", isReset("notification1")).Return(nil)
+				s.On("MoveNotificationToQueue", isReset("notification2")).Return(nil)
+			},
+			ExpectedReplayed: 2,
+		},
+		"one notification is replayed": {
+			NotificationID: "notification2",
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadDeadLetterNotifications").Return(getNotifications(), nil)
+				s.On("MoveNotificationToQueue", isReset("notification2")).Return(nil)
+			},
+			ExpectedReplayed: 1,
+		},
+		"notification not found": {
+			NotificationID: "notification3",
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadDeadLetterNotifications").Return(getNotifications(), nil)
+			},
+		},
+		"failed to load the notifications": {
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadDeadLetterNotifications").Return(nil, errors.New("kv error"))
+			},
+			ExpectedError: "kv error",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, _ := setupTestPlugin(&plugintest.API{}, store)
+
+			replayed, err := p.replayDeadLetterNotifications(test.NotificationID)
+			if test.ExpectedError != "" {
+				assert.EqualError(t, err, test.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedReplayed, replayed)
+		})
+	}
+}
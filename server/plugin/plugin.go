@@ -1,6 +1,7 @@
 package plugin
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/gorilla/mux"
 	pluginapi "github.com/mattermost/mattermost-plugin-api"
+	"github.com/mattermost/mattermost-plugin-api/cluster"
 	"github.com/mattermost/mattermost-server/v6/model"
 	"github.com/mattermost/mattermost-server/v6/plugin"
 	"golang.org/x/oauth2"
@@ -37,6 +39,13 @@ type Plugin struct {
 	store           Store
 	CommandHandlers map[string]CommandHandleFunc
 
+	// notificationQueueSignal wakes up the worker posting the queued notifications
+	notificationQueueSignal     chan struct{}
+	stopNotificationQueueWorker context.CancelFunc
+
+	// notificationDigestJob posts the notification digests at the end of their windows
+	notificationDigestJob *cluster.Job
+
 	// Telemetry package copied inside repository, should be changed
 	// to pluginapi's one (0.1.3+) when min_server_version is safe to point at 7.x
 	telemetryClient telemetry.Client
@@ -53,6 +62,7 @@ func NewPlugin() *Plugin {
 		constants.CommandUnsubscribe:    p.handleDeleteSubscription,
 		constants.CommandSearchAndShare: p.handleSearchAndShare,
 		constants.CommandIncident:       p.handleIncident,
+		constants.CommandKnowledgeBase:  p.handleKnowledgeBase,
 	}
 
 	return p
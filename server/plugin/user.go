@@ -59,7 +59,8 @@ func (p *Plugin) CompleteOAuth2(authedUserID, code, state string) error {
 		return err
 	}
 
-	client := p.NewClient(ctx, token)
+	// The user is not stored yet, so there is nothing to update if the token gets refreshed here
+	client := p.NewClient(ctx, token, "")
 	serviceNowUser, _, err := client.GetMe(user.Email)
 	if err != nil {
 		return err
@@ -91,6 +92,21 @@ func (p *Plugin) GetUser(mattermostUserID string) (*serializer.User, error) {
 	return storedUser, nil
 }
 
+// GetClientForUser returns a client authenticated with the stored token of a connected user
+func (p *Plugin) GetClientForUser(mattermostUserID string) (Client, error) {
+	user, err := p.GetUser(mattermostUserID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := p.ParseAuthToken(user.OAuth2Token)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse oauth token")
+	}
+
+	return p.NewClient(context.Background(), token, mattermostUserID), nil
+}
+
 func (p *Plugin) DisconnectUser(mattermostUserID string) error {
 	err := p.store.DeleteUser(mattermostUserID)
 	return err
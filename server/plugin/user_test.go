@@ -105,7 +105,7 @@ func TestCompleteOAuth2(t *testing.T) {
 				monkey.PatchInstanceMethod(reflect.TypeOf(p), "DM", func(_ *Plugin, _, _ string, _ ...interface{}) (string, error) {
 					return "", nil
 				})
-				monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewClient", func(_ *Plugin, _ context.Context, _ *oauth2.Token) Client {
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewClient", func(_ *Plugin, _ context.Context, _ *oauth2.Token, _ string) Client {
 					return &mock_plugin.Client{}
 				})
 				monkey.PatchInstanceMethod(reflect.TypeOf(client), "GetMe", func(_ *mock_plugin.Client, _ string) (*serializer.ServiceNowUser, int, error) {
@@ -211,7 +211,7 @@ func TestCompleteOAuth2(t *testing.T) {
 				monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewEncodedAuthToken", func(_ *Plugin, _ *oauth2.Token) (string, error) {
 					return "mockToken", nil
 				})
-				monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewClient", func(_ *Plugin, _ context.Context, _ *oauth2.Token) Client {
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewClient", func(_ *Plugin, _ context.Context, _ *oauth2.Token, _ string) Client {
 					return &mock_plugin.Client{}
 				})
 				monkey.PatchInstanceMethod(reflect.TypeOf(client), "GetMe", func(_ *mock_plugin.Client, _ string) (*serializer.ServiceNowUser, int, error) {
@@ -238,7 +238,7 @@ func TestCompleteOAuth2(t *testing.T) {
 				monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewEncodedAuthToken", func(_ *Plugin, _ *oauth2.Token) (string, error) {
 					return "mockToken", nil
 				})
-				monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewClient", func(_ *Plugin, _ context.Context, _ *oauth2.Token) Client {
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "NewClient", func(_ *Plugin, _ context.Context, _ *oauth2.Token, _ string) Client {
 					return &mock_plugin.Client{}
 				})
 				monkey.PatchInstanceMethod(reflect.TypeOf(client), "GetMe", func(_ *mock_plugin.Client, _ string) (*serializer.ServiceNowUser, int, error) {
@@ -5,11 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 
 	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
@@ -85,21 +88,25 @@ func (p *Plugin) writeJSONArray(w http.ResponseWriter, statusCode int, v interfa
 	w.WriteHeader(statusCode)
 }
 
-func ParseSubscriptionsToCommandResponse(subscriptions []*serializer.SubscriptionResponse) string {
+func ParseSubscriptionsToCommandResponse(subscriptions []*serializer.SubscriptionResponse, recordTypes *serializer.RecordTypeRegistry) string {
 	var sb strings.Builder
 	var recordSubscriptions strings.Builder
 	var bulkSubscriptions strings.Builder
+	var personalSubscriptions strings.Builder
 	for _, subscription := range subscriptions {
-		if subscription.Type == constants.SubscriptionTypeRecord {
-			recordSubscriptions.WriteString(subscription.GetFormattedSubscription())
-		} else {
-			bulkSubscriptions.WriteString(subscription.GetFormattedSubscription())
+		switch subscription.Type {
+		case constants.SubscriptionTypeRecord:
+			recordSubscriptions.WriteString(subscription.GetFormattedSubscription(recordTypes))
+		case constants.SubscriptionTypePersonal:
+			personalSubscriptions.WriteString(subscription.GetFormattedSubscription(recordTypes))
+		default:
+			bulkSubscriptions.WriteString(subscription.GetFormattedSubscription(recordTypes))
 		}
 	}
 
 	if bulkSubscriptions.Len() > 0 {
 		sb.WriteString("#### Bulk subscriptions\n")
-		sb.WriteString("| Subscription ID | Record Type | Events | Created By | Channel |\n| :----|:--------| :--------|:--------|:--------|")
+		sb.WriteString("| Subscription ID | Record Type | Events | Filters | Delivery | Created By | Channel |\n| :----|:--------| :--------| :--------| :--------|:--------|:--------|")
 		sb.WriteString(bulkSubscriptions.String())
 	}
 
@@ -109,6 +116,29 @@ func ParseSubscriptionsToCommandResponse(subscriptions []*serializer.Subscriptio
 		sb.WriteString(recordSubscriptions.String())
 	}
 
+	if personalSubscriptions.Len() > 0 {
+		sb.WriteString("\n#### Personal subscriptions\n")
+		sb.WriteString("| Subscription ID | Record Type | Events |\n| :----|:--------| :--------|")
+		sb.WriteString(personalSubscriptions.String())
+	}
+
+	return sb.String()
+}
+
+// ParseDeadLetterNotificationsToCommandResponse lists the latest failed notifications, the latest first
+func ParseDeadLetterNotificationsToCommandResponse(notifications []*serializer.QueuedNotification) string {
+	var sb strings.Builder
+	sb.WriteString("#### Failed notifications\n")
+	if len(notifications) > constants.MaxDeadLetterNotificationsToList {
+		sb.WriteString(fmt.Sprintf("Showing the latest %d of %d failed notifications.\n", constants.MaxDeadLetterNotificationsToList, len(notifications)))
+		notifications = notifications[len(notifications)-constants.MaxDeadLetterNotificationsToList:]
+	}
+
+	sb.WriteString("| Notification ID | Record Number | Event | Received At | Attempts | Error |\n| :----|:--------| :--------| :--------|:--------|:--------|")
+	for i := len(notifications) - 1; i >= 0; i-- {
+		sb.WriteString(notifications[i].GetFormattedNotification())
+	}
+
 	return sb.String()
 }
 
@@ -136,7 +166,13 @@ func GetPageAndPerPage(r *http.Request) (page, perPage int) {
 func (p *Plugin) GetClientFromRequest(r *http.Request) Client {
 	ctx := r.Context()
 	token := ctx.Value(constants.ContextTokenKey).(*oauth2.Token)
-	return p.NewClient(ctx, token)
+	return p.NewClient(ctx, token, r.Header.Get(constants.HeaderMattermostUserID))
+}
+
+// GetUserFromRequest returns the connected user stored in the request context by the OAuth middleware
+func GetUserFromRequest(r *http.Request) *serializer.User {
+	user, _ := r.Context().Value(constants.ContextUserKey).(*serializer.User)
+	return user
 }
 
 func (p *Plugin) GetRecordFromServiceNowForSubscription(subscription *serializer.SubscriptionResponse, client Client, wg *sync.WaitGroup) {
@@ -154,6 +190,222 @@ func (p *Plugin) GetRecordFromServiceNowForSubscription(subscription *serializer
 	subscription.ShortDescription = record.ShortDescription
 }
 
+// SearchKnowledgeArticles searches the published knowledge articles matching the search term and fetches their details,
+// like their text and the links to their knowledge base and category. The articles which can't be fetched are skipped.
+// If matchKeywords is true, the articles containing any of the words of the search term are returned, most relevant first.
+func (p *Plugin) SearchKnowledgeArticles(client Client, searchTerm string, limit int, matchKeywords bool) ([]*serializer.ServiceNowRecord, int, error) {
+	filters := &serializer.RecordSearchFilters{
+		PublishedOnly: true,
+		MatchKeywords: matchKeywords,
+	}
+
+	results, statusCode, err := client.SearchRecordsInServiceNow(constants.RecordTypeKnowledge, searchTerm, fmt.Sprint(limit), "0", filters)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	fetchedArticles := make([]*serializer.ServiceNowRecord, len(results))
+	wg := sync.WaitGroup{}
+	for i, result := range results {
+		wg.Add(1)
+		go func(i int, sysID string) {
+			defer wg.Done()
+			article, _, err := client.GetRecordFromServiceNow(constants.RecordTypeKnowledge, sysID)
+			if err != nil {
+				p.API.LogDebug(constants.ErrorGetRecord, "SysID", sysID, "Error", err.Error())
+				return
+			}
+
+			article.RecordType = constants.RecordTypeKnowledge
+			if err = article.HandleNestedFields(p.getConfiguration().ServiceNowBaseURL); err != nil {
+				p.API.LogDebug(constants.ErrorHandlingNestedFields, "SysID", sysID, "Error", err.Error())
+				return
+			}
+
+			fetchedArticles[i] = article
+		}(i, result.SysID)
+	}
+	wg.Wait()
+
+	var articles []*serializer.ServiceNowRecord
+	for _, article := range fetchedArticles {
+		if article != nil {
+			articles = append(articles, article)
+		}
+	}
+
+	return articles, statusCode, nil
+}
+
+// LoadSubscriptionFilters sets the stored filters on the given subscription if it is a bulk subscription
+func (p *Plugin) LoadSubscriptionFilters(subscription *serializer.SubscriptionResponse) {
+	if subscription.Type != constants.SubscriptionTypeBulk {
+		return
+	}
+
+	filters, err := p.store.LoadSubscriptionFilters(subscription.SysID)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			p.API.LogError(constants.ErrorLoadSubscriptionFilters, "SubscriptionID", subscription.SysID, "Error", err.Error())
+		}
+		return
+	}
+
+	subscription.Filters = filters
+}
+
+// EventMatchesSubscriptionFilters checks if the record of a bulk subscription event matches the filters of the subscription.
+// The record is checked in ServiceNow using the token of the user who created the subscription.
+// The event is never posted without checking the filters, as they can be used to keep records out of a channel.
+// If the filters can't be checked due to a failure in loading them or in calling ServiceNow, an error is returned
+// so that the notification is retried. If the user who created the subscription is no longer connected,
+// the filters can never be checked, so the event is dropped.
+func (p *Plugin) EventMatchesSubscriptionFilters(event *serializer.ServiceNowEvent) (bool, error) {
+	filters, err := p.store.LoadSubscriptionFilters(event.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return true, nil
+		}
+		return false, errors.Wrap(err, "failed to load the subscription filters")
+	}
+
+	if filters.IsEmpty() {
+		return true, nil
+	}
+
+	client, err := p.GetClientForUser(event.UserID)
+	if err != nil {
+		p.API.LogWarn("Dropping the event as the client for checking the subscription filters can't be created", "SubscriptionID", event.SubscriptionID, "UserID", event.UserID, "Error", err.Error())
+		return false, nil
+	}
+
+	matches, _, err := client.CheckRecordMatchesQuery(event.RecordType, event.RecordID, filters.ToEncodedQuery())
+	if err != nil {
+		return false, errors.Wrap(err, "unable to check the subscription filters")
+	}
+
+	return matches, nil
+}
+
+// IsDuplicateSubscription returns true if an active subscription for the same channel, type and record
+// exists in ServiceNow with the same filters. The same record can be subscribed to with different filters.
+func (p *Plugin) IsDuplicateSubscription(client Client, subscription *serializer.SubscriptionPayload, filters *serializer.SubscriptionFilters) (bool, int, error) {
+	subscriptions, statusCode, err := client.GetDuplicateSubscriptions(subscription)
+	if err != nil {
+		return false, statusCode, err
+	}
+
+	for _, existingSubscription := range subscriptions {
+		existingFilters, err := p.store.LoadSubscriptionFilters(existingSubscription.SysID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return false, http.StatusInternalServerError, errors.Wrap(err, "failed to load the subscription filters")
+		}
+
+		if filters.Equals(existingFilters) {
+			return true, statusCode, nil
+		}
+	}
+
+	return false, statusCode, nil
+}
+
+// ErrEventSubscriptionMismatch is returned when a notification event doesn't match an active subscription of this server
+var ErrEventSubscriptionMismatch = errors.New("event does not match an active subscription")
+
+// newEventSubscriptionMismatchError returns an error wrapping ErrEventSubscriptionMismatch with the reason of the mismatch
+func newEventSubscriptionMismatchError(reason string) error {
+	return fmt.Errorf("%w: %s", ErrEventSubscriptionMismatch, reason)
+}
+
+// ValidateEventForSubscription checks if the notification event matches an active subscription of this server
+// and if the subscriber can still post in the subscribed channel. The events not matching their subscription
+// are dropped, so that notifications can't be posted in the channels which were not subscribed, and the returned
+// error wraps ErrEventSubscriptionMismatch for them. Any other error is transient and the event should be retried.
+func (p *Plugin) ValidateEventForSubscription(event *serializer.ServiceNowEvent) error {
+	subscription, err := p.getSubscriptionForEvent(event)
+	if err != nil {
+		return err
+	}
+
+	if err = subscription.MatchesEvent(event, p.getConfiguration().MattermostSiteURL); err != nil {
+		return newEventSubscriptionMismatchError(err.Error())
+	}
+
+	// The notifications of personal subscriptions are sent in the DM of the subscriber with the bot
+	if subscription.Type == constants.SubscriptionTypePersonal {
+		return nil
+	}
+
+	if statusCode, permissionErr := p.HasPublicOrPrivateChannelPermissions(subscription.UserID, subscription.ChannelID); permissionErr != nil {
+		if statusCode >= http.StatusInternalServerError {
+			return errors.Wrap(permissionErr, "failed to check the permissions of the subscriber")
+		}
+
+		return newEventSubscriptionMismatchError(permissionErr.Error())
+	}
+
+	return nil
+}
+
+// getSubscriptionForEvent returns the subscription of the event, fetching it from ServiceNow as the subscriber
+// if it's not cached. The subscription can't be fetched if the subscriber has disconnected their account.
+// The returned error wraps ErrEventSubscriptionMismatch unless the subscription may be fetched on a retry.
+func (p *Plugin) getSubscriptionForEvent(event *serializer.ServiceNowEvent) (*serializer.SubscriptionResponse, error) {
+	if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), event.SubscriptionID); err != nil || !valid {
+		return nil, newEventSubscriptionMismatchError("subscription ID is not valid")
+	}
+
+	subscription, err := p.store.LoadCachedSubscription(event.SubscriptionID)
+	if err == nil {
+		return subscription, nil
+	}
+
+	if !errors.Is(err, ErrNotFound) {
+		p.API.LogWarn("Unable to load the cached subscription", "SubscriptionID", event.SubscriptionID, "Error", err.Error())
+	}
+
+	client, err := p.GetClientForUser(event.UserID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, newEventSubscriptionMismatchError("subscriber is not connected")
+		}
+
+		return nil, errors.Wrap(err, "failed to get the client of the subscriber")
+	}
+
+	subscription, statusCode, err := client.GetSubscription(event.SubscriptionID)
+	if err != nil {
+		if statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests {
+			return nil, errors.Wrap(err, "failed to get the subscription")
+		}
+
+		return nil, newEventSubscriptionMismatchError(err.Error())
+	}
+
+	if err = p.store.StoreCachedSubscription(subscription); err != nil {
+		p.API.LogWarn("Unable to cache the subscription", "SubscriptionID", event.SubscriptionID, "Error", err.Error())
+	}
+
+	return subscription, nil
+}
+
+// deleteSubscriptionData deletes the data kept by the plugin for a subscription deleted in ServiceNow
+func (p *Plugin) deleteSubscriptionData(subscriptionID string) {
+	if err := p.store.DeleteSubscriptionFilters(subscriptionID); err != nil {
+		p.API.LogWarn(constants.ErrorDeleteSubscriptionFilters, "SubscriptionID", subscriptionID, "Error", err.Error())
+	}
+
+	p.deleteSubscriptionDigests(subscriptionID)
+	p.deleteCachedSubscription(subscriptionID)
+}
+
+// deleteCachedSubscription removes the cached copy of a subscription after it is changed or deleted
+func (p *Plugin) deleteCachedSubscription(subscriptionID string) {
+	if err := p.store.DeleteCachedSubscription(subscriptionID); err != nil {
+		p.API.LogWarn("Unable to delete the cached subscription", "SubscriptionID", subscriptionID, "Error", err.Error())
+	}
+}
+
 func (p *Plugin) getHelpMessage(header string, isSysAdmin bool) string {
 	var sb strings.Builder
 	sb.WriteString(header)
@@ -337,3 +589,289 @@ func (p *Plugin) HasPublicOrPrivateChannelPermissions(userID, channelID string)
 
 	return http.StatusOK, nil
 }
+
+// ShareCreatedIncident converts the incident created in ServiceNow into a record and shares it in the given channel
+func (p *Plugin) ShareCreatedIncident(channelID string, incident *serializer.IncidentResponse) (*serializer.ServiceNowRecord, error) {
+	record := &serializer.ServiceNowRecord{
+		SysID:            incident.SysID,
+		Number:           incident.Number,
+		ShortDescription: incident.ShortDescription,
+		Description:      incident.Description,
+		RecordType:       constants.RecordTypeIncident,
+		State:            incident.State,
+		Priority:         incident.Priority,
+		AssignedTo:       incident.AssignedTo,
+		AssignmentGroup:  incident.AssignmentGroup,
+	}
+
+	if err := p.ShareCreatedRecord(channelID, "", record); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// SuggestKnowledgeArticles sends the published knowledge articles related to the short description of a newly created
+// incident to its reporter, if suggesting knowledge articles is enabled. The suggestions are sent as an ephemeral post,
+// as the articles are searched with the token of the reporter and may not be visible to the other members of the channel.
+func (p *Plugin) SuggestKnowledgeArticles(client Client, userID, channelID string, incident *serializer.IncidentResponse) {
+	if !p.getConfiguration().SuggestKnowledgeArticles || len(incident.ShortDescription) < constants.CharacterThresholdForSearchingRecords {
+		return
+	}
+
+	articles, _, err := p.SearchKnowledgeArticles(client, incident.ShortDescription, constants.MaxSuggestedKnowledgeArticles, true)
+	if err != nil {
+		p.API.LogDebug(constants.ErrorSuggestKnowledgeArticles, "Number", incident.Number, "Error", err.Error())
+		return
+	}
+
+	if len(articles) == 0 {
+		return
+	}
+
+	attachments := make([]*model.SlackAttachment, 0, len(articles))
+	for _, article := range articles {
+		attachments = append(attachments, article.CreateKnowledgeSearchResultAttachment(p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL()))
+	}
+
+	post := &model.Post{
+		UserId:    p.botID,
+		ChannelId: channelID,
+		Message:   fmt.Sprintf(constants.SuggestedKnowledgeArticlesMessage, incident.Number),
+	}
+	model.ParseSlackAttachment(post, attachments)
+	_ = p.API.SendEphemeralPost(userID, post)
+}
+
+// ShareCreatedRecord posts a newly created record in the channel, as a reply in the thread if rootID is not empty.
+func (p *Plugin) ShareCreatedRecord(channelID, rootID string, record *serializer.ServiceNowRecord) error {
+	if err := record.HandleNestedFields(p.getConfiguration().ServiceNowBaseURL); err != nil {
+		return err
+	}
+
+	post := record.CreateSharingPost(channelID, p.botID, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL(), "", p.getConfiguration().RecordTypeRegistry)
+	post.RootId = rootID
+	if _, postErr := p.API.CreatePost(post); postErr != nil {
+		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
+	}
+
+	return nil
+}
+
+// GetRecordDetailsFromPost returns the first line of the post's message as the short description
+// and the conversation in the post's thread as the description of a record
+func (p *Plugin) GetRecordDetailsFromPost(post *model.Post) (shortDescription, description string, err error) {
+	for _, line := range strings.Split(post.Message, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			shortDescription = line
+			break
+		}
+	}
+
+	if runes := []rune(shortDescription); len(runes) > constants.MaxShortDescriptionLength {
+		shortDescription = string(runes[:constants.MaxShortDescriptionLength-3]) + "..."
+	}
+
+	rootID := post.RootId
+	if rootID == "" {
+		rootID = post.Id
+	}
+
+	postList, appErr := p.API.GetPostThread(rootID)
+	if appErr != nil {
+		return "", "", errors.Wrap(appErr, constants.ErrorGetPostThread)
+	}
+
+	posts := make([]*model.Post, 0, len(postList.Posts))
+	for _, threadPost := range postList.Posts {
+		posts = append(posts, threadPost)
+	}
+
+	sort.Slice(posts, func(i, j int) bool {
+		return posts[i].CreateAt < posts[j].CreateAt
+	})
+
+	usernames := map[string]string{}
+	var sb strings.Builder
+	for _, threadPost := range posts {
+		if threadPost.IsSystemMessage() || threadPost.DeleteAt != 0 || strings.TrimSpace(threadPost.Message) == "" {
+			continue
+		}
+
+		username, ok := usernames[threadPost.UserId]
+		if !ok {
+			username = threadPost.UserId
+			if user, userErr := p.API.GetUser(threadPost.UserId); userErr == nil {
+				username = user.Username
+			}
+			usernames[threadPost.UserId] = username
+		}
+
+		createdAt := model.GetTimeForMillis(threadPost.CreateAt).UTC().Format(constants.ThreadMessageTimeFormat)
+		sb.WriteString(fmt.Sprintf("[%s] @%s: %s\n", createdAt, username, strings.TrimSpace(threadPost.Message)))
+	}
+
+	return shortDescription, strings.TrimSpace(sb.String()), nil
+}
+
+// PostNotification posts the notification of an event in the channel of its subscription.
+// The first notification for a record in a channel starts a thread, and the later notifications
+// for the same record are posted as replies in that thread while the root post is updated with the current values of the record.
+// An error is returned only if the notification could not be posted, so that it can be retried.
+func (p *Plugin) PostNotification(event *serializer.ServiceNowEvent) error {
+	post := event.CreateNotificationPost(p.botID, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL(), p.getConfiguration().RecordTypeRegistry)
+	if event.ChannelID == "" || event.RecordID == "" {
+		if _, postErr := p.API.CreatePost(post); postErr != nil {
+			p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
+			return errors.Wrap(postErr, constants.ErrorCreatePost)
+		}
+		return nil
+	}
+
+	if rootPost := p.getNotificationThreadRootPost(event.ChannelID, event.RecordID); rootPost != nil {
+		post.RootId = rootPost.Id
+		if event.UpdateNotificationThreadRootPost(rootPost) {
+			if _, updateErr := p.API.UpdatePost(rootPost); updateErr != nil {
+				p.API.LogError(constants.ErrorUpdateNotificationThread, "PostID", rootPost.Id, "Error", updateErr.Error())
+			}
+		}
+	}
+
+	createdPost, postErr := p.API.CreatePost(post)
+	if postErr != nil {
+		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
+		return errors.Wrap(postErr, constants.ErrorCreatePost)
+	}
+
+	if post.RootId == "" {
+		if err := p.store.StoreNotificationThread(event.ChannelID, event.RecordID, createdPost.Id); err != nil {
+			p.API.LogError(constants.ErrorStoreNotificationThread, "ChannelID", event.ChannelID, "RecordID", event.RecordID, "Error", err.Error())
+		}
+	}
+
+	return nil
+}
+
+// PostPersonalNotification sends the notification of a personal subscription to the subscriber in their DM with the bot.
+// The notification is sent only if the event concerns the ServiceNow account connected by the subscriber.
+func (p *Plugin) PostPersonalNotification(event *serializer.ServiceNowEvent) error {
+	user, err := p.GetUser(event.UserID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			p.API.LogDebug("Discarding the personal notification as the subscriber is not connected", "SubscriptionID", event.SubscriptionID)
+			return nil
+		}
+
+		p.API.LogError(constants.ErrorGetUser, "UserID", event.UserID, "Error", err.Error())
+		return errors.Wrap(err, constants.ErrorGetUser)
+	}
+
+	if user.ServiceNowUser == nil || !p.isEventForServiceNowUser(event, user) {
+		return nil
+	}
+
+	channel, appErr := p.API.GetDirectChannel(event.UserID, p.botID)
+	if appErr != nil {
+		p.API.LogError(constants.ErrorGetBotChannel, "UserID", event.UserID, "Error", appErr.Error())
+		return errors.Wrap(appErr, constants.ErrorGetBotChannel)
+	}
+
+	event.ChannelID = channel.Id
+	return p.PostNotification(event)
+}
+
+// isEventForServiceNowUser checks if the record is assigned to the user or one of their groups,
+// or was opened by the user in case of a new comment on the record
+func (p *Plugin) isEventForServiceNowUser(event *serializer.ServiceNowEvent, user *serializer.User) bool {
+	switch event.EventOccurred {
+	case constants.SubscriptionEventAssignedTo:
+		return event.AssignedToID == user.ServiceNowUser.UserID
+	case constants.SubscriptionEventCommented:
+		return event.OpenedByID == user.ServiceNowUser.UserID
+	case constants.SubscriptionEventAssignmentGroup:
+		client, err := p.GetClientForUser(user.MattermostUserID)
+		if err != nil {
+			p.API.LogWarn("Unable to get the client for checking the groups of the subscriber", "SubscriptionID", event.SubscriptionID, "UserID", user.MattermostUserID, "Error", err.Error())
+			return false
+		}
+
+		// The groups are checked by ServiceNow as the subscriber, as the membership of the groups is not known to the plugin
+		matches, _, err := client.CheckRecordMatchesQuery(event.RecordType, event.RecordID, constants.QueryAssignedToMyGroups)
+		if err != nil {
+			p.API.LogWarn("Unable to check the groups of the subscriber", "SubscriptionID", event.SubscriptionID, "Error", err.Error())
+			return false
+		}
+
+		return matches
+	}
+
+	return false
+}
+
+// PostApprovalNotification sends an approval request to the approver in their DM with the bot.
+// The request is sent only if the approver has connected their ServiceNow account.
+func (p *Plugin) PostApprovalNotification(event *serializer.ServiceNowEvent) error {
+	mmUser, appErr := p.API.GetUserByEmail(event.ApproverEmail)
+	if appErr != nil {
+		p.API.LogDebug(constants.ErrorApproverNotFound, "ApprovalID", event.ApprovalID, "Error", appErr.Error())
+		return nil
+	}
+
+	user, err := p.GetUser(mmUser.Id)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			p.API.LogDebug(constants.ErrorApproverNotConnected, "ApprovalID", event.ApprovalID)
+			return nil
+		}
+
+		p.API.LogError(constants.ErrorGetUser, "UserID", mmUser.Id, "Error", err.Error())
+		return errors.Wrap(err, constants.ErrorGetUser)
+	}
+
+	// The email of the Mattermost user can be changed after connecting, so it is verified that
+	// the approval is actually addressed to the ServiceNow account connected by the user
+	if user.ServiceNowUser == nil || user.ServiceNowUser.UserID != event.ApproverID {
+		p.API.LogDebug(constants.ErrorApproverMismatch, "ApprovalID", event.ApprovalID, "UserID", mmUser.Id)
+		return nil
+	}
+
+	channel, appErr := p.API.GetDirectChannel(mmUser.Id, p.botID)
+	if appErr != nil {
+		p.API.LogError(constants.ErrorGetBotChannel, "UserID", mmUser.Id, "Error", appErr.Error())
+		return errors.Wrap(appErr, constants.ErrorGetBotChannel)
+	}
+
+	post := event.CreateApprovalPost(p.botID, channel.Id, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL())
+	if _, postErr := p.API.CreatePost(post); postErr != nil {
+		p.API.LogError(constants.ErrorCreatePost, "Error", postErr.Error())
+		return errors.Wrap(postErr, constants.ErrorCreatePost)
+	}
+
+	return nil
+}
+
+// getNotificationThreadRootPost returns the root post of the notification thread of a record in a channel,
+// or nil if the thread has not been started yet or its root post has been deleted
+func (p *Plugin) getNotificationThreadRootPost(channelID, recordID string) *model.Post {
+	rootID, err := p.store.LoadNotificationThread(channelID, recordID)
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			p.API.LogError(constants.ErrorLoadNotificationThread, "ChannelID", channelID, "RecordID", recordID, "Error", err.Error())
+		}
+		return nil
+	}
+
+	rootPost, appErr := p.API.GetPost(rootID)
+	if appErr != nil || rootPost.DeleteAt != 0 {
+		if appErr != nil {
+			p.API.LogDebug(constants.ErrorGetPost, "PostID", rootID, "Error", appErr.Error())
+		}
+
+		if err = p.store.DeleteNotificationThread(channelID, recordID); err != nil {
+			p.API.LogError(constants.ErrorDeleteNotificationThread, "ChannelID", channelID, "RecordID", recordID, "Error", err.Error())
+		}
+		return nil
+	}
+
+	return rootPost
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 
 	"bou.ke/monkey"
@@ -16,6 +17,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	mock_plugin "github.com/mattermost/mattermost-plugin-servicenow/server/mocks"
 	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
 	"github.com/mattermost/mattermost-plugin-servicenow/server/testutils"
 )
@@ -54,13 +56,13 @@ func TestParseSubscriptionsToCommandResponse(t *testing.T) {
 					SubscriptionEvents: constants.SubscriptionEventState,
 				},
 			},
-			expectedResult: "#### Bulk subscriptions\n| Subscription ID | Record Type | Events | Created By | Channel |\n| :----|:--------| :--------|:--------|:--------|\n|mockSysID|Incident|State changed|mockUser||\n#### Record subscriptions\n| Subscription ID | Record Type | Record Number | Record Short Description | Events | Created By | Channel |\n| :----|:--------| :--------| :-----| :--------|:--------|:--------|\n|mockSysID|Incident|mockNumber|mockDescription|State changed|mockUser||",
+			expectedResult: "#### Bulk subscriptions\n| Subscription ID | Record Type | Events | Filters | Delivery | Created By | Channel |\n| :----|:--------| :--------| :--------| :--------|:--------|:--------|\n|mockSysID|Incident|State changed|N/A|Immediate|mockUser||\n#### Record subscriptions\n| Subscription ID | Record Type | Record Number | Record Short Description | Events | Created By | Channel |\n| :----|:--------| :--------| :-----| :--------|:--------|:--------|\n|mockSysID|Incident|mockNumber|mockDescription|State changed|mockUser||",
 		},
 	} {
 		t.Run(testCase.description, func(t *testing.T) {
 			assert := assert.New(t)
 
-			resp := ParseSubscriptionsToCommandResponse(testCase.subscripitons)
+			resp := ParseSubscriptionsToCommandResponse(testCase.subscripitons, serializer.NewRecordTypeRegistry(serializer.DefaultRecordTypes()))
 			assert.EqualValues(testCase.expectedResult, resp)
 		})
 	}
@@ -214,6 +216,428 @@ func TestFilterSubscriptionsOnRecordData(t *testing.T) {
 	}
 }
 
+func TestEventMatchesSubscriptionFilters(t *testing.T) {
+	defer monkey.UnpatchAll()
+	event := &serializer.ServiceNowEvent{
+		SubscriptionID: testutils.GetServiceNowSysID(),
+		RecordID:       testutils.GetServiceNowSysID(),
+		RecordType:     constants.RecordTypeIncident,
+		UserID:         testutils.GetID(),
+	}
+	filters := &serializer.SubscriptionFilters{
+		Priorities: []string{"1", "2"},
+	}
+	for _, testCase := range []struct {
+		description    string
+		setupAPI       func(*plugintest.API)
+		setupStore     func(*mock_plugin.Store)
+		setupClient    func(*mock_plugin.Client)
+		clientErr      error
+		expectedResult bool
+		expectedErr    string
+	}{
+		{
+			description: "EventMatchesSubscriptionFilters: No filters stored",
+			setupAPI:    func(a *plugintest.API) {},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+			},
+			setupClient:    func(c *mock_plugin.Client) {},
+			expectedResult: true,
+		},
+		{
+			description: "EventMatchesSubscriptionFilters: Unable to load the filters",
+			setupAPI:    func(a *plugintest.API) {},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil, errors.New("unable to load the filters"))
+			},
+			setupClient:    func(c *mock_plugin.Client) {},
+			expectedResult: false,
+			expectedErr:    "failed to load the subscription filters: unable to load the filters",
+		},
+		{
+			description: "EventMatchesSubscriptionFilters: Unable to get the client of the subscription creator",
+			setupAPI: func(a *plugintest.API) {
+				a.On("LogWarn", testutils.GetMockArgumentsWithType("string", 7)...).Return()
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(filters, nil)
+			},
+			setupClient:    func(c *mock_plugin.Client) {},
+			clientErr:      ErrNotFound,
+			expectedResult: false,
+		},
+		{
+			description: "EventMatchesSubscriptionFilters: Unable to check the filters",
+			setupAPI:    func(a *plugintest.API) {},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(filters, nil)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("CheckRecordMatchesQuery", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), filters.ToEncodedQuery()).Return(
+					false, http.StatusInternalServerError, errors.New("unable to check the filters"),
+				)
+			},
+			expectedResult: false,
+			expectedErr:    "unable to check the subscription filters: unable to check the filters",
+		},
+		{
+			description: "EventMatchesSubscriptionFilters: Record does not match the filters",
+			setupAPI:    func(a *plugintest.API) {},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(filters, nil)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("CheckRecordMatchesQuery", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), filters.ToEncodedQuery()).Return(
+					false, http.StatusOK, nil,
+				)
+			},
+			expectedResult: false,
+		},
+		{
+			description: "EventMatchesSubscriptionFilters: Record matches the filters",
+			setupAPI:    func(a *plugintest.API) {},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(filters, nil)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("CheckRecordMatchesQuery", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), filters.ToEncodedQuery()).Return(
+					true, http.StatusOK, nil,
+				)
+			},
+			expectedResult: true,
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert := assert.New(t)
+			mockAPI := &plugintest.API{}
+			defer mockAPI.AssertExpectations(t)
+			s := mock_plugin.NewStore(t)
+			c := mock_plugin.NewClient(t)
+			testCase.setupAPI(mockAPI)
+			testCase.setupStore(s)
+			testCase.setupClient(c)
+
+			p := &Plugin{store: s}
+			p.SetAPI(mockAPI)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetClientForUser", func(_ *Plugin, _ string) (Client, error) {
+				if testCase.clientErr != nil {
+					return nil, testCase.clientErr
+				}
+				return c, nil
+			})
+
+			matches, err := p.EventMatchesSubscriptionFilters(event)
+			if testCase.expectedErr != "" {
+				assert.EqualError(err, testCase.expectedErr)
+			} else {
+				assert.NoError(err)
+			}
+
+			assert.Equal(testCase.expectedResult, matches)
+		})
+	}
+}
+
+func TestIsDuplicateSubscription(t *testing.T) {
+	recordType := constants.RecordTypeIncident
+	subscription := &serializer.SubscriptionPayload{RecordType: &recordType}
+	filters := &serializer.SubscriptionFilters{
+		Priorities: []string{"1", "2"},
+	}
+	for _, testCase := range []struct {
+		description        string
+		setupStore         func(*mock_plugin.Store)
+		setupClient        func(*mock_plugin.Client)
+		filters            *serializer.SubscriptionFilters
+		expectedResult     bool
+		expectedStatusCode int
+		expectedErr        string
+	}{
+		{
+			description: "IsDuplicateSubscription: No subscription exists",
+			setupStore:  func(s *mock_plugin.Store) {},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetDuplicateSubscriptions", subscription).Return(nil, http.StatusOK, nil)
+			},
+			filters:            filters,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "IsDuplicateSubscription: Unable to get the subscriptions",
+			setupStore:  func(s *mock_plugin.Store) {},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetDuplicateSubscriptions", subscription).Return(nil, http.StatusForbidden, errors.New("unable to get the subscriptions"))
+			},
+			expectedStatusCode: http.StatusForbidden,
+			expectedErr:        "unable to get the subscriptions",
+		},
+		{
+			description: "IsDuplicateSubscription: Subscription without filters exists",
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetDuplicateSubscriptions", subscription).Return([]*serializer.SubscriptionResponse{testutils.GetSubscription(constants.SubscriptionTypeBulk)}, http.StatusOK, nil)
+			},
+			expectedResult:     true,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "IsDuplicateSubscription: Subscription with different filters exists",
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(&serializer.SubscriptionFilters{Priorities: []string{"1"}}, nil)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetDuplicateSubscriptions", subscription).Return([]*serializer.SubscriptionResponse{testutils.GetSubscription(constants.SubscriptionTypeBulk)}, http.StatusOK, nil)
+			},
+			filters:            filters,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "IsDuplicateSubscription: Subscription with the same filters exists",
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(&serializer.SubscriptionFilters{Priorities: []string{"2", "1"}}, nil)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetDuplicateSubscriptions", subscription).Return([]*serializer.SubscriptionResponse{testutils.GetSubscription(constants.SubscriptionTypeBulk)}, http.StatusOK, nil)
+			},
+			filters:            filters,
+			expectedResult:     true,
+			expectedStatusCode: http.StatusOK,
+		},
+		{
+			description: "IsDuplicateSubscription: Unable to load the filters of the existing subscription",
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadSubscriptionFilters", testutils.GetServiceNowSysID()).Return(nil, errors.New("unable to load the filters"))
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetDuplicateSubscriptions", subscription).Return([]*serializer.SubscriptionResponse{testutils.GetSubscription(constants.SubscriptionTypeBulk)}, http.StatusOK, nil)
+			},
+			filters:            filters,
+			expectedStatusCode: http.StatusInternalServerError,
+			expectedErr:        "failed to load the subscription filters: unable to load the filters",
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert := assert.New(t)
+			s := mock_plugin.NewStore(t)
+			c := mock_plugin.NewClient(t)
+			testCase.setupStore(s)
+			testCase.setupClient(c)
+
+			p := &Plugin{store: s}
+			exists, statusCode, err := p.IsDuplicateSubscription(c, subscription, testCase.filters)
+			if testCase.expectedErr != "" {
+				assert.EqualError(err, testCase.expectedErr)
+			} else {
+				assert.NoError(err)
+			}
+
+			assert.Equal(testCase.expectedResult, exists)
+			assert.Equal(testCase.expectedStatusCode, statusCode)
+		})
+	}
+}
+
+func TestValidateEventForSubscription(t *testing.T) {
+	defer monkey.UnpatchAll()
+	errServiceNowUnavailable := errors.New("service unavailable")
+	siteURL := "https://test.mattermost.com"
+	getSubscription := func() *serializer.SubscriptionResponse {
+		subscription := testutils.GetSubscription(constants.SubscriptionTypeRecord)
+		subscription.ChannelID = testutils.GetChannelID()
+		subscription.RecordID = testutils.GetServiceNowSysID()
+		subscription.ServerURL = siteURL
+		return subscription
+	}
+	getEvent := func() *serializer.ServiceNowEvent {
+		return &serializer.ServiceNowEvent{
+			SubscriptionID:   testutils.GetServiceNowSysID(),
+			SubscriptionType: constants.SubscriptionTypeRecord,
+			RecordID:         testutils.GetServiceNowSysID(),
+			RecordType:       constants.RecordTypeProblem,
+			ChannelID:        testutils.GetChannelID(),
+			UserID:           testutils.GetID(),
+			EventOccurred:    constants.SubscriptionEventState,
+		}
+	}
+	for _, testCase := range []struct {
+		description   string
+		getEvent      func() *serializer.ServiceNowEvent
+		setupAPI      func(*plugintest.API)
+		setupStore    func(*mock_plugin.Store)
+		setupClient   func(*mock_plugin.Client)
+		expectedError error
+	}{
+		{
+			description: "ValidateEventForSubscription: Cached subscription matches the event",
+			getEvent:    getEvent,
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetChannel", testutils.GetChannelID()).Return(&model.Channel{Type: model.ChannelTypeOpen}, nil)
+				a.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(getSubscription(), nil)
+			},
+			setupClient: func(c *mock_plugin.Client) {},
+		},
+		{
+			description: "ValidateEventForSubscription: Subscription fetched from ServiceNow and cached",
+			getEvent:    getEvent,
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetChannel", testutils.GetChannelID()).Return(&model.Channel{Type: model.ChannelTypePrivate}, nil)
+				a.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(true)
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+				s.On("StoreCachedSubscription", getSubscription()).Return(nil)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetSubscription", testutils.GetServiceNowSysID()).Return(getSubscription(), http.StatusOK, nil)
+			},
+		},
+		{
+			description: "ValidateEventForSubscription: Channel injected in the event",
+			getEvent: func() *serializer.ServiceNowEvent {
+				event := getEvent()
+				event.ChannelID = "injectedChannelID"
+				return event
+			},
+			setupAPI: func(a *plugintest.API) {
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(getSubscription(), nil)
+			},
+			setupClient:   func(c *mock_plugin.Client) {},
+			expectedError: ErrEventSubscriptionMismatch,
+		},
+		{
+			description: "ValidateEventForSubscription: Event not subscribed",
+			getEvent: func() *serializer.ServiceNowEvent {
+				event := getEvent()
+				event.EventOccurred = constants.SubscriptionEventCommented
+				return event
+			},
+			setupAPI: func(a *plugintest.API) {
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(getSubscription(), nil)
+			},
+			setupClient:   func(c *mock_plugin.Client) {},
+			expectedError: ErrEventSubscriptionMismatch,
+		},
+		{
+			description: "ValidateEventForSubscription: Event occurred missing",
+			getEvent: func() *serializer.ServiceNowEvent {
+				event := getEvent()
+				event.EventOccurred = ""
+				return event
+			},
+			setupAPI: func(a *plugintest.API) {
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(getSubscription(), nil)
+			},
+			setupClient:   func(c *mock_plugin.Client) {},
+			expectedError: ErrEventSubscriptionMismatch,
+		},
+		{
+			description: "ValidateEventForSubscription: Subscriber can't post in the channel anymore",
+			getEvent:    getEvent,
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetChannel", testutils.GetChannelID()).Return(&model.Channel{Type: model.ChannelTypeOpen}, nil)
+				a.On("HasPermissionToChannel", testutils.GetID(), testutils.GetChannelID(), model.PermissionCreatePost).Return(false)
+				a.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(getSubscription(), nil)
+			},
+			setupClient:   func(c *mock_plugin.Client) {},
+			expectedError: ErrEventSubscriptionMismatch,
+		},
+		{
+			description: "ValidateEventForSubscription: Unable to get the subscription",
+			getEvent:    getEvent,
+			setupAPI:    func(a *plugintest.API) {},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetSubscription", testutils.GetServiceNowSysID()).Return(nil, http.StatusNotFound, errors.New("subscription not found"))
+			},
+			expectedError: ErrEventSubscriptionMismatch,
+		},
+		{
+			description: "ValidateEventForSubscription: ServiceNow is unavailable",
+			getEvent:    getEvent,
+			setupAPI:    func(a *plugintest.API) {},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(nil, ErrNotFound)
+			},
+			setupClient: func(c *mock_plugin.Client) {
+				c.On("GetSubscription", testutils.GetServiceNowSysID()).Return(nil, http.StatusServiceUnavailable, errServiceNowUnavailable)
+			},
+			expectedError: errServiceNowUnavailable,
+		},
+		{
+			description: "ValidateEventForSubscription: Unable to get the subscribed channel",
+			getEvent:    getEvent,
+			setupAPI: func(a *plugintest.API) {
+				a.On("GetChannel", testutils.GetChannelID()).Return(nil, &model.AppError{StatusCode: http.StatusInternalServerError})
+				a.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			setupStore: func(s *mock_plugin.Store) {
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(getSubscription(), nil)
+			},
+			setupClient:   func(c *mock_plugin.Client) {},
+			expectedError: errors.New(constants.ErrorChannelPermissionsForUser),
+		},
+		{
+			description: "ValidateEventForSubscription: Personal subscription",
+			getEvent: func() *serializer.ServiceNowEvent {
+				event := getEvent()
+				event.SubscriptionType = constants.SubscriptionTypePersonal
+				return event
+			},
+			setupAPI: func(a *plugintest.API) {},
+			setupStore: func(s *mock_plugin.Store) {
+				subscription := getSubscription()
+				subscription.Type = constants.SubscriptionTypePersonal
+				s.On("LoadCachedSubscription", testutils.GetServiceNowSysID()).Return(subscription, nil)
+			},
+			setupClient: func(c *mock_plugin.Client) {},
+		},
+	} {
+		t.Run(testCase.description, func(t *testing.T) {
+			assert := assert.New(t)
+			mockAPI := &plugintest.API{}
+			defer mockAPI.AssertExpectations(t)
+			s := mock_plugin.NewStore(t)
+			c := mock_plugin.NewClient(t)
+			testCase.setupAPI(mockAPI)
+			testCase.setupStore(s)
+			testCase.setupClient(c)
+
+			p := &Plugin{store: s}
+			p.SetAPI(mockAPI)
+			p.setConfiguration(&configuration{MattermostSiteURL: siteURL})
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetClientForUser", func(_ *Plugin, _ string) (Client, error) {
+				return c, nil
+			})
+
+			err := p.ValidateEventForSubscription(testCase.getEvent())
+			if testCase.expectedError == nil {
+				assert.NoError(err)
+				return
+			}
+
+			assert.Error(err)
+			assert.Equal(errors.Is(testCase.expectedError, ErrEventSubscriptionMismatch), errors.Is(err, ErrEventSubscriptionMismatch))
+		})
+	}
+}
+
 func TestHandleClientError(t *testing.T) {
 	defer monkey.UnpatchAll()
 	requestURL := fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathCreateSubscription)
@@ -310,3 +734,411 @@ func TestHandleClientError(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRecordDetailsFromPost(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for name, test := range map[string]struct {
+		Post                     *model.Post
+		SetupAPI                 func(api *plugintest.API)
+		ExpectedShortDescription string
+		ExpectedDescription      string
+		ExpectedErr              bool
+	}{
+		"root post with replies": {
+			Post: &model.Post{Id: "root", UserId: "user1", Message: "\n  Email is down\nfor everyone", CreateAt: 1000},
+			SetupAPI: func(api *plugintest.API) {
+				postList := model.NewPostList()
+				postList.AddPost(&model.Post{Id: "reply", RootId: "root", UserId: "user2", Message: "Looking into it", CreateAt: 2000})
+				postList.AddPost(&model.Post{Id: "root", UserId: "user1", Message: "\n  Email is down\nfor everyone", CreateAt: 1000})
+				postList.AddPost(&model.Post{Id: "system", RootId: "root", UserId: "user2", Type: model.PostTypeJoinChannel, Message: "joined", CreateAt: 1500})
+				api.On("GetPostThread", "root").Return(postList, nil)
+				api.On("GetUser", "user1").Return(&model.User{Username: "alice"}, nil)
+				api.On("GetUser", "user2").Return(nil, testutils.GetNotFoundAppError())
+			},
+			ExpectedShortDescription: "Email is down",
+			ExpectedDescription:      "[1970-01-01 00:00 UTC] @alice: Email is down\nfor everyone\n[1970-01-01 00:00 UTC] @user2: Looking into it",
+		},
+		"reply uses the thread of its root post": {
+			Post: &model.Post{Id: "reply", RootId: "root", UserId: "user1", Message: "Still broken"},
+			SetupAPI: func(api *plugintest.API) {
+				postList := model.NewPostList()
+				postList.AddPost(&model.Post{Id: "reply", RootId: "root", UserId: "user1", Message: "Still broken"})
+				api.On("GetPostThread", "root").Return(postList, nil)
+				api.On("GetUser", "user1").Return(&model.User{Username: "alice"}, nil)
+			},
+			ExpectedShortDescription: "Still broken",
+			ExpectedDescription:      "[1970-01-01 00:00 UTC] @alice: Still broken",
+		},
+		"long first line is truncated": {
+			Post: &model.Post{Id: "root", UserId: "user1", Message: strings.Repeat("a", constants.MaxShortDescriptionLength+10)},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPostThread", "root").Return(model.NewPostList(), nil)
+			},
+			ExpectedShortDescription: strings.Repeat("a", constants.MaxShortDescriptionLength-3) + "...",
+		},
+		"failed to get the thread": {
+			Post: &model.Post{Id: "root", UserId: "user1", Message: "message"},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPostThread", "root").Return(nil, testutils.GetInternalServerAppError())
+			},
+			ExpectedErr: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			shortDescription, description, err := p.GetRecordDetailsFromPost(test.Post)
+			if test.ExpectedErr {
+				assert.NotNil(err)
+				return
+			}
+
+			assert.Nil(err)
+			assert.Equal(test.ExpectedShortDescription, shortDescription)
+			assert.Equal(test.ExpectedDescription, description)
+		})
+	}
+}
+
+func TestPostNotification(t *testing.T) {
+	defer monkey.UnpatchAll()
+	event := &serializer.ServiceNowEvent{
+		ChannelID:     testutils.GetChannelID(),
+		RecordID:      testutils.GetServiceNowSysID(),
+		RecordType:    constants.RecordTypeIncident,
+		State:         "In Progress",
+		EventOccurred: constants.SubscriptionEventState,
+	}
+	rootPost := &model.Post{Id: "mockRootPostID", ChannelId: testutils.GetChannelID()}
+	model.ParseSlackAttachment(rootPost, []*model.SlackAttachment{{Title: "mockTitle"}})
+	for name, test := range map[string]struct {
+		SetupAPI       func(api *plugintest.API)
+		SetupStore     func(s *mock_plugin.Store)
+		ExpectedRootID string
+	}{
+		"first notification for the record starts a thread": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{Id: "mockPostID"}, nil)
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+				s.On("StoreNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID(), "mockPostID").Return(nil)
+			},
+		},
+		"later notification is posted in the thread and updates the root post": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", "mockRootPostID").Return(rootPost, nil)
+				api.On("UpdatePost", mock.MatchedBy(func(post *model.Post) bool {
+					return post.Id == "mockRootPostID" && post.Attachments()[0].Fields[1].Value == "In Progress"
+				})).Return(rootPost, nil)
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{Id: "mockPostID"}, nil)
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID()).Return("mockRootPostID", nil)
+			},
+			ExpectedRootID: "mockRootPostID",
+		},
+		"deleted root post starts a new thread": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetPost", "mockRootPostID").Return(nil, testutils.GetNotFoundAppError())
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{Id: "mockPostID"}, nil)
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID()).Return("mockRootPostID", nil)
+				s.On("DeleteNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID()).Return(nil)
+				s.On("StoreNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID(), "mockPostID").Return(nil)
+			},
+		},
+		"failed to load the thread": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 7)...).Return()
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{Id: "mockPostID"}, nil)
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID()).Return("", errors.New("load error"))
+				s.On("StoreNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID(), "mockPostID").Return(nil)
+			},
+		},
+		"failed to create the post": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, testutils.GetBadRequestAppError())
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			test.SetupAPI(api)
+			test.SetupStore(store)
+			defer api.AssertExpectations(t)
+
+			p.PostNotification(event)
+
+			for _, call := range api.Calls {
+				if call.Method == "CreatePost" {
+					assert.Equal(t, test.ExpectedRootID, call.Arguments.Get(0).(*model.Post).RootId)
+				}
+			}
+		})
+	}
+}
+
+func TestPostApprovalNotification(t *testing.T) {
+	defer monkey.UnpatchAll()
+	event := &serializer.ServiceNowEvent{
+		SubscriptionType: constants.SubscriptionTypeApproval,
+		ApprovalID:       testutils.GetServiceNowSysID(),
+		ApproverID:       testutils.GetServiceNowSysID(),
+		ApproverEmail:    "approver@example.com",
+		RecordID:         testutils.GetServiceNowSysID(),
+		RecordType:       constants.RecordTypeChangeRequest,
+		Number:           testutils.GetServiceNowNumber(),
+	}
+	mmUser := &model.User{Id: testutils.GetID()}
+	for name, test := range map[string]struct {
+		SetupAPI   func(api *plugintest.API)
+		SetupStore func(s *mock_plugin.Store)
+	}{
+		"approval request is sent to the approver": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetUserByEmail", "approver@example.com").Return(mmUser, nil)
+				api.On("GetDirectChannel", testutils.GetID(), mock.AnythingOfType("string")).Return(&model.Channel{Id: testutils.GetChannelID()}, nil)
+				api.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+					return post.ChannelId == testutils.GetChannelID() && len(post.Attachments()[0].Actions) == 2
+				})).Return(&model.Post{Id: "mockPostID"}, nil)
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(testutils.GetSerializerUser(), nil)
+			},
+		},
+		"approver is not a Mattermost user": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetUserByEmail", "approver@example.com").Return(nil, testutils.GetNotFoundAppError())
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {},
+		},
+		"approver is not connected": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetUserByEmail", "approver@example.com").Return(mmUser, nil)
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(nil, ErrNotFound)
+			},
+		},
+		"connected ServiceNow account is not the approver": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetUserByEmail", "approver@example.com").Return(mmUser, nil)
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(&serializer.User{
+					ServiceNowUser: &serializer.ServiceNowUser{UserID: "mockOtherSysID"},
+				}, nil)
+			},
+		},
+		"failed to create the post": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetUserByEmail", "approver@example.com").Return(mmUser, nil)
+				api.On("GetDirectChannel", testutils.GetID(), mock.AnythingOfType("string")).Return(&model.Channel{Id: testutils.GetChannelID()}, nil)
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(nil, testutils.GetBadRequestAppError())
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(testutils.GetSerializerUser(), nil)
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			test.SetupAPI(api)
+			test.SetupStore(store)
+			defer api.AssertExpectations(t)
+
+			p.PostApprovalNotification(event)
+		})
+	}
+}
+
+func TestPostPersonalNotification(t *testing.T) {
+	for name, test := range map[string]struct {
+		EventOccurred string
+		AssignedToID  string
+		OpenedByID    string
+		SetupAPI      func(api *plugintest.API)
+		SetupStore    func(s *mock_plugin.Store)
+		SetupPlugin   func(p *Plugin)
+	}{
+		"record assigned to the subscriber": {
+			EventOccurred: constants.SubscriptionEventAssignedTo,
+			AssignedToID:  testutils.GetServiceNowSysID(),
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetDirectChannel", testutils.GetID(), mock.AnythingOfType("string")).Return(&model.Channel{Id: testutils.GetChannelID()}, nil)
+				api.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+					return post.ChannelId == testutils.GetChannelID()
+				})).Return(&model.Post{Id: "mockPostID"}, nil)
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(testutils.GetSerializerUser(), nil)
+				s.On("LoadNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+				s.On("StoreNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID(), "mockPostID").Return(nil)
+			},
+			SetupPlugin: func(p *Plugin) {},
+		},
+		"record assigned to someone else": {
+			EventOccurred: constants.SubscriptionEventAssignedTo,
+			AssignedToID:  "mockOtherSysID",
+			SetupAPI:      func(api *plugintest.API) {},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(testutils.GetSerializerUser(), nil)
+			},
+			SetupPlugin: func(p *Plugin) {},
+		},
+		"comment on a record opened by someone else": {
+			EventOccurred: constants.SubscriptionEventCommented,
+			OpenedByID:    "mockOtherSysID",
+			SetupAPI:      func(api *plugintest.API) {},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(testutils.GetSerializerUser(), nil)
+			},
+			SetupPlugin: func(p *Plugin) {},
+		},
+		"record assigned to a group of the subscriber": {
+			EventOccurred: constants.SubscriptionEventAssignmentGroup,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetDirectChannel", testutils.GetID(), mock.AnythingOfType("string")).Return(&model.Channel{Id: testutils.GetChannelID()}, nil)
+				api.On("CreatePost", mock.AnythingOfType("*model.Post")).Return(&model.Post{Id: "mockPostID"}, nil)
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(testutils.GetSerializerUser(), nil)
+				s.On("LoadNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+				s.On("StoreNotificationThread", testutils.GetChannelID(), testutils.GetServiceNowSysID(), "mockPostID").Return(nil)
+			},
+			SetupPlugin: func(p *Plugin) {
+				client := mock_plugin.NewClient(t)
+				client.On("CheckRecordMatchesQuery", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), constants.QueryAssignedToMyGroups).Return(true, http.StatusOK, nil)
+				monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetClientForUser", func(_ *Plugin, _ string) (Client, error) {
+					return client, nil
+				})
+			},
+		},
+		"subscriber is not connected": {
+			EventOccurred: constants.SubscriptionEventAssignedTo,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadUser", testutils.GetID()).Return(nil, ErrNotFound)
+			},
+			SetupPlugin: func(p *Plugin) {},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			defer monkey.UnpatchAll()
+			store := mock_plugin.NewStore(t)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			test.SetupAPI(api)
+			test.SetupStore(store)
+			test.SetupPlugin(p)
+			defer api.AssertExpectations(t)
+
+			p.PostPersonalNotification(&serializer.ServiceNowEvent{
+				SubscriptionID:   testutils.GetServiceNowSysID(),
+				UserID:           testutils.GetID(),
+				SubscriptionType: constants.SubscriptionTypePersonal,
+				RecordID:         testutils.GetServiceNowSysID(),
+				RecordType:       constants.RecordTypeIncident,
+				EventOccurred:    test.EventOccurred,
+				AssignedToID:     test.AssignedToID,
+				OpenedByID:       test.OpenedByID,
+			})
+		})
+	}
+}
+
+func TestSuggestKnowledgeArticles(t *testing.T) {
+	incident := &serializer.IncidentResponse{Number: "INC0000001", ShortDescription: "Unable to reset the password"}
+	for name, test := range map[string]struct {
+		Enabled          bool
+		Incident         *serializer.IncidentResponse
+		SetupAPI         func(api *plugintest.API)
+		SetupClient      func(client *mock_plugin.Client)
+		ExpectSuggestion bool
+	}{
+		"articles found": {
+			Enabled:  true,
+			Incident: incident,
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeKnowledge, incident.ShortDescription, fmt.Sprint(constants.MaxSuggestedKnowledgeArticles), "0", &serializer.RecordSearchFilters{PublishedOnly: true, MatchKeywords: true}).Return(
+					[]*serializer.ServiceNowPartialRecord{{SysID: "article1"}}, http.StatusOK, nil,
+				)
+				client.On("GetRecordFromServiceNow", constants.RecordTypeKnowledge, "article1").Return(
+					&serializer.ServiceNowRecord{SysID: "article1", Number: "KB0000001"}, http.StatusOK, nil,
+				)
+			},
+			ExpectSuggestion: true,
+		},
+		"no articles found": {
+			Enabled:  true,
+			Incident: incident,
+			SetupAPI: func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeKnowledge, incident.ShortDescription, fmt.Sprint(constants.MaxSuggestedKnowledgeArticles), "0", mock.AnythingOfType("*serializer.RecordSearchFilters")).Return(
+					nil, http.StatusOK, nil,
+				)
+			},
+		},
+		"failed to search the articles": {
+			Enabled:  true,
+			Incident: incident,
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("SearchRecordsInServiceNow", constants.RecordTypeKnowledge, incident.ShortDescription, fmt.Sprint(constants.MaxSuggestedKnowledgeArticles), "0", mock.AnythingOfType("*serializer.RecordSearchFilters")).Return(
+					nil, http.StatusForbidden, errors.New("search error"),
+				)
+			},
+		},
+		"short description too short": {
+			Enabled:     true,
+			Incident:    &serializer.IncidentResponse{Number: "INC0000001", ShortDescription: "DB"},
+			SetupAPI:    func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {},
+		},
+		"suggestions disabled": {
+			Incident:    incident,
+			SetupAPI:    func(api *plugintest.API) {},
+			SetupClient: func(client *mock_plugin.Client) {},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p, api := setupTestPlugin(&plugintest.API{}, nil)
+			p.setConfiguration(&configuration{SuggestKnowledgeArticles: test.Enabled})
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			client := mock_plugin.NewClient(t)
+			test.SetupClient(client)
+
+			if test.ExpectSuggestion {
+				api.On("SendEphemeralPost", testutils.GetID(), mock.MatchedBy(func(post *model.Post) bool {
+					return post.ChannelId == testutils.GetChannelID() && len(post.Attachments()) == 1
+				})).Return(&model.Post{})
+			}
+
+			p.SuggestKnowledgeArticles(client, testutils.GetID(), testutils.GetChannelID(), test.Incident)
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package plugin
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+)
+
+var incidentNumberRegex = regexp.MustCompile(`^INC[0-9]+$`)
+
+// ErrWarRoomNotPermitted is returned when the user is not permitted to create channels in the team of the war room
+var ErrWarRoomNotPermitted = errors.New("user is not permitted to create public channels in the team")
+
+// ErrWarRoomJoinNotPermitted is returned when the user is not permitted to join the existing war room
+var ErrWarRoomJoinNotPermitted = errors.New("user is not permitted to join the war room")
+
+// CreateWarRoom creates a channel named after the number of the incident in the team and sets it up for handling the
+// incident. The connected users who are the assignee or members of the assignment group of the incident are added to
+// the channel, the channel is subscribed to the changes of the incident, the incident is posted and pinned in the
+// channel and the link to the channel is added to the incident as a work note.
+// If the war room already exists, the user is added to it. The returned bool is true if the war room already existed.
+func (p *Plugin) CreateWarRoom(client Client, userID, teamID string, incident *serializer.ServiceNowRecord) (*model.Channel, bool, error) {
+	channel, err := p.getWarRoom(teamID, incident.SysID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if channel != nil {
+		// The war room may have been converted to a private channel, which can only be joined when invited by its members
+		if channel.Type != model.ChannelTypeOpen || !p.API.HasPermissionToTeam(userID, teamID, model.PermissionJoinPublicChannels) {
+			return nil, false, ErrWarRoomJoinNotPermitted
+		}
+
+		if _, appErr := p.API.AddChannelMember(channel.Id, userID); appErr != nil {
+			return nil, false, errors.Wrap(appErr, "failed to add the user to the war room")
+		}
+
+		return channel, true, nil
+	}
+
+	if !p.API.HasPermissionToTeam(userID, teamID, model.PermissionCreatePublicChannel) {
+		return nil, false, ErrWarRoomNotPermitted
+	}
+
+	// A channel named after the incident which was not created as its war room is not reused,
+	// so the sys ID of the incident is added to the name of the war room
+	channelName := strings.ToLower(incident.Number)
+	if _, appErr := p.API.GetChannelByName(teamID, channelName, true); appErr == nil {
+		channelName = fmt.Sprintf("%s-%s", channelName, incident.SysID)
+	}
+
+	// The sys IDs of the assignee and the assignment group are read before their fields are converted to links
+	assigneeID := serializer.GetNestedFieldSysID(incident.AssignedTo)
+	assignmentGroupID := serializer.GetNestedFieldSysID(incident.AssignmentGroup)
+
+	channel, appErr := p.API.CreateChannel(&model.Channel{
+		TeamId:      teamID,
+		Name:        channelName,
+		DisplayName: serializer.Truncate(fmt.Sprintf("%s: %s", incident.Number, incident.ShortDescription), model.ChannelDisplayNameMaxRunes),
+		Purpose:     fmt.Sprintf(constants.WarRoomPurpose, incident.Number),
+		Type:        model.ChannelTypeOpen,
+		CreatorId:   userID,
+	})
+	if appErr != nil {
+		return nil, false, errors.Wrap(appErr, "failed to create the channel")
+	}
+
+	if err = p.store.StoreWarRoomChannelID(teamID, incident.SysID, channel.Id); err != nil {
+		p.API.LogWarn("Unable to store the war room of the incident", "Number", incident.Number, "Error", err.Error())
+	}
+
+	for _, memberID := range append([]string{userID}, p.getWarRoomMembers(client, assigneeID, assignmentGroupID)...) {
+		if _, appErr = p.API.AddChannelMember(channel.Id, memberID); appErr != nil {
+			// The users who are not members of the team can't be added to the channel
+			p.API.LogDebug("Unable to add the user to the war room", "UserID", memberID, "ChannelID", channel.Id, "Error", appErr.Error())
+		}
+	}
+
+	p.subscribeWarRoom(client, userID, channel.Id, incident)
+
+	incident.RecordType = constants.RecordTypeIncident
+	if err = incident.HandleNestedFields(p.getConfiguration().ServiceNowBaseURL); err != nil {
+		p.API.LogWarn(constants.ErrorHandlingNestedFields, "Error", err.Error())
+	} else {
+		post := incident.CreateSharingPost(channel.Id, p.botID, p.getConfiguration().ServiceNowBaseURL, p.GetPluginURL(), "", p.getConfiguration().RecordTypeRegistry)
+		post.IsPinned = true
+		if _, postErr := p.API.CreatePost(post); postErr != nil {
+			p.API.LogWarn(constants.ErrorCreatePost, "Error", postErr.Error())
+		}
+	}
+
+	if team, teamErr := p.API.GetTeam(teamID); teamErr != nil {
+		p.API.LogWarn("Unable to get the team of the war room", "TeamID", teamID, "Error", teamErr.Error())
+	} else {
+		channelURL := fmt.Sprintf("%s/%s/channels/%s", p.getConfiguration().MattermostSiteURL, team.Name, channel.Name)
+		payload := &serializer.ServiceNowCommentPayload{WorkNotes: fmt.Sprintf(constants.WarRoomWorkNote, channelURL)}
+		if _, err = client.AddComment(constants.RecordTypeIncident, incident.SysID, payload); err != nil {
+			p.API.LogWarn("Unable to add the link of the war room to the incident", "Number", incident.Number, "Error", err.Error())
+		}
+	}
+
+	return channel, false, nil
+}
+
+// getWarRoom returns the war room created for the incident in the team.
+// It returns nil if no war room was created for the incident or the war room was archived or deleted.
+func (p *Plugin) getWarRoom(teamID, incidentID string) (*model.Channel, error) {
+	channelID, err := p.store.LoadWarRoomChannelID(teamID, incidentID)
+	if err != nil {
+		if err == ErrNotFound {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "failed to load the war room of the incident")
+	}
+
+	channel, appErr := p.API.GetChannel(channelID)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(appErr, "failed to get the war room of the incident")
+	}
+
+	if channel.DeleteAt != 0 {
+		return nil, nil
+	}
+
+	return channel, nil
+}
+
+// getWarRoomMembers returns the IDs of the connected Mattermost users who are the assignee or members of the assignment group of an incident
+func (p *Plugin) getWarRoomMembers(client Client, assigneeID, assignmentGroupID string) []string {
+	serviceNowUserIDs := map[string]bool{}
+	if assigneeID != "" {
+		serviceNowUserIDs[assigneeID] = true
+	}
+
+	if assignmentGroupID != "" {
+		memberIDs, _, err := client.GetGroupMembers(assignmentGroupID)
+		if err != nil {
+			p.API.LogWarn("Unable to get the members of the assignment group", "GroupID", assignmentGroupID, "Error", err.Error())
+		}
+
+		for _, memberID := range memberIDs {
+			serviceNowUserIDs[memberID] = true
+		}
+	}
+
+	if len(serviceNowUserIDs) == 0 {
+		return nil
+	}
+
+	users, err := p.store.GetAllUsers()
+	if err != nil {
+		p.API.LogWarn("Unable to get the connected users", "Error", err.Error())
+		return nil
+	}
+
+	var mattermostUserIDs []string
+	for _, user := range users {
+		if user.ServiceNowUser != nil && serviceNowUserIDs[user.ServiceNowUser.UserID] {
+			mattermostUserIDs = append(mattermostUserIDs, user.MattermostUserID)
+		}
+	}
+
+	return mattermostUserIDs
+}
+
+// subscribeWarRoom subscribes the war room to the changes of the incident
+func (p *Plugin) subscribeWarRoom(client Client, userID, channelID string, incident *serializer.ServiceNowRecord) {
+	subscriptionType := constants.SubscriptionTypeRecord
+	recordType := constants.RecordTypeIncident
+	subscriptionEvents := constants.WarRoomSubscriptionEvents
+	serverURL := p.getConfiguration().MattermostSiteURL
+	isActive := true
+	subscription := &serializer.SubscriptionPayload{
+		UserID:             &userID,
+		ChannelID:          &channelID,
+		Type:               &subscriptionType,
+		RecordType:         &recordType,
+		RecordID:           &incident.SysID,
+		RecordNumber:       &incident.Number,
+		IsActive:           &isActive,
+		SubscriptionEvents: &subscriptionEvents,
+		ServerURL:          &serverURL,
+	}
+
+	if err := subscription.IsValidForCreation(serverURL, p.getConfiguration().RecordTypeRegistry); err != nil {
+		p.API.LogWarn("Invalid subscription for the war room", "Number", incident.Number, "Error", err.Error())
+		return
+	}
+
+	if _, _, err := client.CreateSubscription(subscription); err != nil {
+		p.API.LogWarn("Unable to subscribe the war room to the incident", "Number", incident.Number, "Error", err.Error())
+	}
+}
+
+// createWarRoomResponse creates the war room for the incident and returns the message to be shown to the user
+func (p *Plugin) createWarRoomResponse(client Client, userID, teamID string, incident *serializer.ServiceNowRecord) string {
+	channel, existed, err := p.CreateWarRoom(client, userID, teamID, incident)
+	if errors.Is(err, ErrWarRoomNotPermitted) || errors.Is(err, ErrWarRoomJoinNotPermitted) {
+		return fmt.Sprintf("%s: %s.", constants.ErrorCreateWarRoom, err.Error())
+	}
+
+	if err != nil {
+		p.API.LogError(constants.ErrorCreateWarRoom, "Number", incident.Number, "Error", err.Error())
+		return genericErrorMessage
+	}
+
+	if existed {
+		return fmt.Sprintf(constants.WarRoomExistsMessage, channel.Name)
+	}
+
+	return fmt.Sprintf(constants.WarRoomCreatedMessage, channel.Name)
+}
@@ -0,0 +1,191 @@
+package plugin
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	mock_plugin "github.com/mattermost/mattermost-plugin-servicenow/server/mocks"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/testutils"
+)
+
+func TestCreateWarRoom(t *testing.T) {
+	siteURL := "https://test.mattermost.com"
+	teamID := "mockTeamID"
+	channel := &model.Channel{Id: testutils.GetChannelID(), Name: "inc0000001", Type: model.ChannelTypeOpen}
+	getIncident := func() *serializer.ServiceNowRecord {
+		return &serializer.ServiceNowRecord{
+			SysID:            testutils.GetServiceNowSysID(),
+			Number:           "INC0000001",
+			ShortDescription: "Database is down",
+			AssignedTo:       map[string]interface{}{"display_value": "Assignee", "link": "https://test.service-now.com/api/now/table/sys_user/assigneeID"},
+			AssignmentGroup:  map[string]interface{}{"display_value": "Database", "link": "https://test.service-now.com/api/now/table/sys_user_group/groupID"},
+		}
+	}
+
+	for name, test := range map[string]struct {
+		SetupAPI       func(api *plugintest.API)
+		SetupClient    func(client *mock_plugin.Client)
+		SetupStore     func(store *mock_plugin.Store)
+		ExpectedExists bool
+		ExpectedError  error
+	}{
+		"war room is created": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetChannelByName", teamID, "inc0000001", true).Return(nil, &model.AppError{})
+				api.On("HasPermissionToTeam", testutils.GetID(), teamID, model.PermissionCreatePublicChannel).Return(true)
+				api.On("CreateChannel", mock.MatchedBy(func(c *model.Channel) bool {
+					return c.Name == "inc0000001" && c.DisplayName == "INC0000001: Database is down" && c.Type == model.ChannelTypeOpen
+				})).Return(channel, nil)
+				api.On("AddChannelMember", channel.Id, testutils.GetID()).Return(&model.ChannelMember{}, nil)
+				api.On("AddChannelMember", channel.Id, "assigneeMattermostID").Return(&model.ChannelMember{}, nil)
+				api.On("AddChannelMember", channel.Id, "memberMattermostID").Return(nil, &model.AppError{})
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 7)...).Return()
+				api.On("CreatePost", mock.MatchedBy(func(post *model.Post) bool {
+					return post.ChannelId == channel.Id && post.IsPinned
+				})).Return(&model.Post{}, nil)
+				api.On("GetTeam", teamID).Return(&model.Team{Name: "test-team"}, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetGroupMembers", "groupID").Return([]string{"assigneeID", "memberID"}, http.StatusOK, nil)
+				client.On("CreateSubscription", mock.MatchedBy(func(s *serializer.SubscriptionPayload) bool {
+					return *s.ChannelID == channel.Id && *s.RecordID == testutils.GetServiceNowSysID() && *s.SubscriptionEvents == constants.WarRoomSubscriptionEvents
+				})).Return(nil, http.StatusCreated, nil)
+				client.On("AddComment", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), &serializer.ServiceNowCommentPayload{
+					WorkNotes: "A war room has been created for this incident in Mattermost: https://test.mattermost.com/test-team/channels/inc0000001",
+				}).Return(http.StatusOK, nil)
+			},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadWarRoomChannelID", teamID, testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+				store.On("StoreWarRoomChannelID", teamID, testutils.GetServiceNowSysID(), channel.Id).Return(nil)
+				store.On("GetAllUsers").Return([]*serializer.IncidentCaller{
+					{MattermostUserID: "assigneeMattermostID", ServiceNowUser: &serializer.ServiceNowUser{UserID: "assigneeID"}},
+					{MattermostUserID: "memberMattermostID", ServiceNowUser: &serializer.ServiceNowUser{UserID: "memberID"}},
+					{MattermostUserID: "otherMattermostID", ServiceNowUser: &serializer.ServiceNowUser{UserID: "otherID"}},
+				}, nil)
+			},
+		},
+		"war room already exists": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetChannel", channel.Id).Return(channel, nil)
+				api.On("HasPermissionToTeam", testutils.GetID(), teamID, model.PermissionJoinPublicChannels).Return(true)
+				api.On("AddChannelMember", channel.Id, testutils.GetID()).Return(&model.ChannelMember{}, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadWarRoomChannelID", teamID, testutils.GetServiceNowSysID()).Return(channel.Id, nil)
+			},
+			ExpectedExists: true,
+		},
+		"war room was archived": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetChannel", "archivedChannelID").Return(&model.Channel{Id: "archivedChannelID", Name: "inc0000001", DeleteAt: 1}, nil)
+				api.On("HasPermissionToTeam", testutils.GetID(), teamID, model.PermissionCreatePublicChannel).Return(true)
+				api.On("GetChannelByName", teamID, "inc0000001", true).Return(&model.Channel{Id: "archivedChannelID", Name: "inc0000001", DeleteAt: 1}, nil)
+				api.On("CreateChannel", mock.MatchedBy(func(c *model.Channel) bool {
+					return c.Name == "inc0000001-"+testutils.GetServiceNowSysID()
+				})).Return(channel, nil)
+				api.On("AddChannelMember", channel.Id, testutils.GetID()).Return(&model.ChannelMember{}, nil)
+				api.On("CreatePost", mock.Anything).Return(&model.Post{}, nil)
+				api.On("GetTeam", teamID).Return(&model.Team{Name: "test-team"}, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetGroupMembers", "groupID").Return([]string{}, http.StatusOK, nil)
+				client.On("CreateSubscription", mock.Anything).Return(nil, http.StatusCreated, nil)
+				client.On("AddComment", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), mock.Anything).Return(http.StatusOK, nil)
+			},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadWarRoomChannelID", teamID, testutils.GetServiceNowSysID()).Return("archivedChannelID", nil)
+				store.On("StoreWarRoomChannelID", teamID, testutils.GetServiceNowSysID(), channel.Id).Return(nil)
+				store.On("GetAllUsers").Return([]*serializer.IncidentCaller{}, nil)
+			},
+		},
+		"channel named after the incident was not created by the plugin": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToTeam", testutils.GetID(), teamID, model.PermissionCreatePublicChannel).Return(true)
+				api.On("GetChannelByName", teamID, "inc0000001", true).Return(&model.Channel{Id: "otherChannelID", Name: "inc0000001", Type: model.ChannelTypePrivate}, nil)
+				api.On("CreateChannel", mock.MatchedBy(func(c *model.Channel) bool {
+					return c.Name == "inc0000001-"+testutils.GetServiceNowSysID() && c.Type == model.ChannelTypeOpen
+				})).Return(channel, nil)
+				api.On("AddChannelMember", channel.Id, testutils.GetID()).Return(&model.ChannelMember{}, nil)
+				api.On("CreatePost", mock.Anything).Return(&model.Post{}, nil)
+				api.On("GetTeam", teamID).Return(&model.Team{Name: "test-team"}, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {
+				client.On("GetGroupMembers", "groupID").Return([]string{}, http.StatusOK, nil)
+				client.On("CreateSubscription", mock.Anything).Return(nil, http.StatusCreated, nil)
+				client.On("AddComment", constants.RecordTypeIncident, testutils.GetServiceNowSysID(), mock.Anything).Return(http.StatusOK, nil)
+			},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadWarRoomChannelID", teamID, testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+				store.On("StoreWarRoomChannelID", teamID, testutils.GetServiceNowSysID(), channel.Id).Return(nil)
+				store.On("GetAllUsers").Return([]*serializer.IncidentCaller{}, nil)
+			},
+		},
+		"war room was converted to a private channel": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetChannel", channel.Id).Return(&model.Channel{Id: channel.Id, Name: "inc0000001", Type: model.ChannelTypePrivate}, nil)
+			},
+			SetupClient: func(client *mock_plugin.Client) {},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadWarRoomChannelID", teamID, testutils.GetServiceNowSysID()).Return(channel.Id, nil)
+			},
+			ExpectedError: ErrWarRoomJoinNotPermitted,
+		},
+		"user is not permitted to join the war room": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetChannel", channel.Id).Return(channel, nil)
+				api.On("HasPermissionToTeam", testutils.GetID(), teamID, model.PermissionJoinPublicChannels).Return(false)
+			},
+			SetupClient: func(client *mock_plugin.Client) {},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadWarRoomChannelID", teamID, testutils.GetServiceNowSysID()).Return(channel.Id, nil)
+			},
+			ExpectedError: ErrWarRoomJoinNotPermitted,
+		},
+		"user is not permitted to create channels": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("HasPermissionToTeam", testutils.GetID(), teamID, model.PermissionCreatePublicChannel).Return(false)
+			},
+			SetupClient: func(client *mock_plugin.Client) {},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("LoadWarRoomChannelID", teamID, testutils.GetServiceNowSysID()).Return("", ErrNotFound)
+			},
+			ExpectedError: ErrWarRoomNotPermitted,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			p.setConfiguration(&configuration{
+				MattermostSiteURL:  siteURL,
+				ServiceNowBaseURL:  "https://test.service-now.com",
+				RecordTypeRegistry: serializer.NewRecordTypeRegistry(serializer.DefaultRecordTypes()),
+			})
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			client := mock_plugin.NewClient(t)
+			test.SetupClient(client)
+
+			warRoom, exists, err := p.CreateWarRoom(client, testutils.GetID(), teamID, getIncident())
+			if test.ExpectedError != nil {
+				assert.True(t, errors.Is(err, test.ExpectedError))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, channel, warRoom)
+			assert.Equal(t, test.ExpectedExists, exists)
+		})
+	}
+}
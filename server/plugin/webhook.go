@@ -0,0 +1,197 @@
+package plugin
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+)
+
+// webhookSignatureTolerance is the maximum difference between the time a signed request was sent and the time it is
+// received. The older requests are rejected, so a captured request can't be replayed after the window has passed.
+const webhookSignatureTolerance = 5 * time.Minute
+
+var webhookEventIDRegex = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,64}$`)
+
+// verifyWebhookRequest verifies the HMAC-SHA256 signature of a webhook request. The signature is computed with the
+// webhook secret over the timestamp, the event ID and the body of the request, joined by dots, and the timestamp
+// is the Unix time in seconds at which the request was sent.
+// The body of the request is restored after being read, so it can be read again by the handler.
+func verifyWebhookRequest(r *http.Request, secret string, now time.Time) (int, error) {
+	timestamp := r.Header.Get(constants.HeaderWebhookTimestamp)
+	sentAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return http.StatusUnauthorized, errors.New("timestamp is not valid")
+	}
+
+	if age := now.Sub(time.Unix(sentAt, 0)); age > webhookSignatureTolerance || age < -webhookSignatureTolerance {
+		return http.StatusUnauthorized, errors.New("timestamp is outside the allowed window")
+	}
+
+	signature, err := decodeWebhookSignature(r.Header.Get(constants.HeaderWebhookSignature))
+	if err != nil {
+		return http.StatusUnauthorized, errors.New("signature is not valid")
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return http.StatusBadRequest, errors.Wrap(err, "failed to read the request body")
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if !hmac.Equal(signature, signWebhookPayload(secret, timestamp, r.Header.Get(constants.HeaderWebhookEventID), body)) {
+		return http.StatusUnauthorized, errors.New("signature did not match")
+	}
+
+	return 0, nil
+}
+
+// decodeWebhookSignature decodes the signature encoded either in hex or in base64,
+// as the MAC generated by the scripts of ServiceNow is encoded in base64
+func decodeWebhookSignature(encodedSignature string) ([]byte, error) {
+	encodedSignature = strings.TrimPrefix(encodedSignature, constants.WebhookSignaturePrefix)
+	if len(encodedSignature) == hex.EncodedLen(sha256.Size) {
+		return hex.DecodeString(encodedSignature)
+	}
+
+	return base64.StdEncoding.DecodeString(encodedSignature)
+}
+
+func signWebhookPayload(secret, timestamp, eventID string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(fmt.Sprintf("%s.%s.", timestamp, eventID)))
+	_, _ = mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// isDuplicateWebhookEvent checks if the event of the webhook request has already been received.
+// Requests without an event ID are never treated as duplicates. If the processed events can't be checked,
+// the event is processed anyway, as a duplicate notification is better than a missing one.
+func (p *Plugin) isDuplicateWebhookEvent(eventID string) bool {
+	if eventID == "" {
+		return false
+	}
+
+	isNew, err := p.store.StoreWebhookEvent(eventID)
+	if err != nil {
+		p.API.LogWarn("Unable to check if the webhook event is already processed", "EventID", eventID, "Error", err.Error())
+		return false
+	}
+
+	return !isNew
+}
+
+// verifyWithWebhookSecrets verifies a webhook request with the current webhook secret and, if it doesn't match,
+// with the previous secret if the secret was regenerated within the grace period, so that the notifications
+// are not interrupted until the new secret is updated in ServiceNow
+func (p *Plugin) verifyWithWebhookSecrets(verify func(secret string) (int, error)) (int, error) {
+	status, err := verify(p.getConfiguration().WebhookSecret)
+	if err == nil {
+		return 0, nil
+	}
+
+	previousSecret := p.getPreviousWebhookSecret()
+	if previousSecret == "" {
+		return status, err
+	}
+
+	if _, previousErr := verify(previousSecret); previousErr != nil {
+		return status, err
+	}
+
+	p.API.LogDebug("Webhook request verified with the previous webhook secret")
+	return 0, nil
+}
+
+// getPreviousWebhookSecret returns the webhook secret replaced by the last rotation,
+// or an empty string if the grace period after the rotation has passed
+func (p *Plugin) getPreviousWebhookSecret() string {
+	previousSecret, err := p.store.LoadPreviousWebhookSecret()
+	if err != nil {
+		if !errors.Is(err, ErrNotFound) {
+			p.API.LogWarn("Unable to load the previous webhook secret", "Error", err.Error())
+		}
+		return ""
+	}
+
+	gracePeriod := time.Duration(p.getConfiguration().WebhookSecretGracePeriod) * time.Hour
+	if time.Since(time.Unix(previousSecret.RotatedAt, 0)) > gracePeriod {
+		return ""
+	}
+
+	return previousSecret.Secret
+}
+
+// handleWebhookSecretRotation keeps the previous webhook secret for the grace period
+// and updates the new secret in ServiceNow
+func (p *Plugin) handleWebhookSecretRotation(previousSecret string) {
+	if p.store == nil {
+		return
+	}
+
+	// The configuration change is received by all the servers of the cluster, so the rotation is handled only by the
+	// server which records it first. If it can't be recorded, it is handled anyway, as updating the secret in
+	// ServiceNow more than once is better than not updating it.
+	secretHash := sha256.Sum256([]byte(p.getConfiguration().WebhookSecret))
+	isFirst, err := p.store.StoreWebhookSecretRotation(hex.EncodeToString(secretHash[:]))
+	if err != nil {
+		p.API.LogWarn("Unable to record the rotation of the webhook secret", "Error", err.Error())
+	} else if !isFirst {
+		return
+	}
+
+	if gracePeriod := p.getConfiguration().WebhookSecretGracePeriod; gracePeriod > 0 {
+		if err := p.store.StorePreviousWebhookSecret(&serializer.PreviousWebhookSecret{
+			Secret:    previousSecret,
+			RotatedAt: time.Now().Unix(),
+		}, int64(gracePeriod)*60*60); err != nil {
+			p.API.LogError("Unable to store the previous webhook secret", "Error", err.Error())
+		}
+	}
+
+	go p.pushWebhookSecret()
+}
+
+// pushWebhookSecret updates the webhook secret in ServiceNow using the account of a connected system admin.
+// If it can't be updated now, it is updated when the subscriptions are activated next by any user.
+func (p *Plugin) pushWebhookSecret() {
+	users, err := p.store.GetAllUsers()
+	if err != nil {
+		p.API.LogError(constants.ErrorPushWebhookSecret, "Error", err.Error())
+		return
+	}
+
+	for _, user := range users {
+		if isSysAdmin, _ := p.IsAuthorizedSysAdmin(user.MattermostUserID); !isSysAdmin {
+			continue
+		}
+
+		client, err := p.GetClientForUser(user.MattermostUserID)
+		if err != nil {
+			continue
+		}
+
+		if _, err = client.ActivateSubscriptions(); err != nil {
+			p.API.LogDebug(constants.ErrorPushWebhookSecret, "UserID", user.MattermostUserID, "Error", err.Error())
+			continue
+		}
+
+		p.API.LogInfo("Updated the webhook secret in ServiceNow", "UserID", user.MattermostUserID)
+		return
+	}
+
+	p.API.LogWarn(constants.ErrorPushWebhookSecret, "Error", "no connected system admin is permitted to update it")
+}
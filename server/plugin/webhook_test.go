@@ -0,0 +1,391 @@
+package plugin
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"bou.ke/monkey"
+	"github.com/mattermost/mattermost-server/v6/model"
+	"github.com/mattermost/mattermost-server/v6/plugin/plugintest"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+	mock_plugin "github.com/mattermost/mattermost-plugin-servicenow/server/mocks"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/serializer"
+	"github.com/mattermost/mattermost-plugin-servicenow/server/testutils"
+)
+
+func getSignedWebhookRequest(secret, eventID string, sentAt time.Time, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathProcessNotification), bytes.NewBufferString(body))
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	r.Header.Set(constants.HeaderWebhookTimestamp, timestamp)
+	r.Header.Set(constants.HeaderWebhookEventID, eventID)
+	r.Header.Set(constants.HeaderWebhookSignature, constants.WebhookSignaturePrefix+hex.EncodeToString(signWebhookPayload(secret, timestamp, eventID, []byte(body))))
+	return r
+}
+
+func TestVerifyWebhookRequest(t *testing.T) {
+	now := time.Now()
+	for name, test := range map[string]struct {
+		GetRequest     func() *http.Request
+		ExpectedStatus int
+		ExpectedError  string
+	}{
+		"valid signature": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest(testutils.GetSecret(), "event1", now, "{}")
+			},
+		},
+		"signature without the prefix": {
+			GetRequest: func() *http.Request {
+				r := getSignedWebhookRequest(testutils.GetSecret(), "event1", now, "{}")
+				r.Header.Set(constants.HeaderWebhookSignature, r.Header.Get(constants.HeaderWebhookSignature)[len(constants.WebhookSignaturePrefix):])
+				return r
+			},
+		},
+		"signature encoded in base64": {
+			GetRequest: func() *http.Request {
+				r := getSignedWebhookRequest(testutils.GetSecret(), "event1", now, "{}")
+				signature, _ := hex.DecodeString(r.Header.Get(constants.HeaderWebhookSignature)[len(constants.WebhookSignaturePrefix):])
+				r.Header.Set(constants.HeaderWebhookSignature, base64.StdEncoding.EncodeToString(signature))
+				return r
+			},
+		},
+		"signed with another secret": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest("anotherSecret", "event1", now, "{}")
+			},
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedError:  "signature did not match",
+		},
+		"event ID changed after signing": {
+			GetRequest: func() *http.Request {
+				r := getSignedWebhookRequest(testutils.GetSecret(), "event1", now, "{}")
+				r.Header.Set(constants.HeaderWebhookEventID, "event2")
+				return r
+			},
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedError:  "signature did not match",
+		},
+		"timestamp outside the window": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest(testutils.GetSecret(), "event1", now.Add(-webhookSignatureTolerance-time.Minute), "{}")
+			},
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedError:  "timestamp is outside the allowed window",
+		},
+		"invalid timestamp": {
+			GetRequest: func() *http.Request {
+				r := getSignedWebhookRequest(testutils.GetSecret(), "event1", now, "{}")
+				r.Header.Set(constants.HeaderWebhookTimestamp, "yesterday")
+				return r
+			},
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedError:  "timestamp is not valid",
+		},
+		"invalid signature": {
+			GetRequest: func() *http.Request {
+				r := getSignedWebhookRequest(testutils.GetSecret(), "event1", now, "{}")
+				r.Header.Set(constants.HeaderWebhookSignature, "sha256=invalid")
+				return r
+			},
+			ExpectedStatus: http.StatusUnauthorized,
+			ExpectedError:  "signature is not valid",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := test.GetRequest()
+			status, err := verifyWebhookRequest(r, testutils.GetSecret(), now)
+			assert.Equal(t, test.ExpectedStatus, status)
+			if test.ExpectedError != "" {
+				require.Error(t, err)
+				assert.Equal(t, test.ExpectedError, err.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			body, _ := io.ReadAll(r.Body)
+			assert.Equal(t, "{}", string(body))
+		})
+	}
+}
+
+func TestHandleSignedNotification(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for name, test := range map[string]struct {
+		RequireSignature   bool
+		GetRequest         func() *http.Request
+		SetupAPI           func(*plugintest.API)
+		SetupStore         func(*mock_plugin.Store)
+		ExpectedStatusCode int
+	}{
+		"signed notification": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest(testutils.GetSecret(), "event1", time.Now(), "{}")
+			},
+			SetupAPI: func(api *plugintest.API) {
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("StoreWebhookEvent", "event1").Return(true, nil)
+				s.On("EnqueueNotification", mock.AnythingOfType("*serializer.QueuedNotification")).Return(nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"repeated delivery": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest(testutils.GetSecret(), "event1", time.Now(), "{}")
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("StoreWebhookEvent", "event1").Return(false, nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"failed to check the processed events": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest(testutils.GetSecret(), "event1", time.Now(), "{}")
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogWarn", testutils.GetMockArgumentsWithType("string", 5)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("StoreWebhookEvent", "event1").Return(false, errors.New("kv error"))
+				s.On("EnqueueNotification", mock.AnythingOfType("*serializer.QueuedNotification")).Return(nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"failed to queue the notification": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest(testutils.GetSecret(), "event1", time.Now(), "{}")
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("StoreWebhookEvent", "event1").Return(true, nil)
+				s.On("EnqueueNotification", mock.AnythingOfType("*serializer.QueuedNotification")).Return(errors.New("kv error"))
+				s.On("DeleteWebhookEvent", "event1").Return(nil)
+			},
+			ExpectedStatusCode: http.StatusInternalServerError,
+		},
+		"invalid event ID": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest(testutils.GetSecret(), "event 1", time.Now(), "{}")
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore:         func(s *mock_plugin.Store) {},
+			ExpectedStatusCode: http.StatusBadRequest,
+		},
+		"invalid signature": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest("anotherSecret", "event1", time.Now(), "{}")
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadPreviousWebhookSecret").Return(nil, ErrNotFound)
+			},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		"signed with the previous secret within the grace period": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest("previousSecret", "event1", time.Now(), "{}")
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", "Webhook request verified with the previous webhook secret").Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadPreviousWebhookSecret").Return(&serializer.PreviousWebhookSecret{Secret: "previousSecret", RotatedAt: time.Now().Add(-time.Hour).Unix()}, nil)
+				s.On("StoreWebhookEvent", "event1").Return(true, nil)
+				s.On("EnqueueNotification", mock.AnythingOfType("*serializer.QueuedNotification")).Return(nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"signed with the previous secret after the grace period": {
+			GetRequest: func() *http.Request {
+				return getSignedWebhookRequest("previousSecret", "event1", time.Now(), "{}")
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", testutils.GetMockArgumentsWithType("string", 3)...).Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadPreviousWebhookSecret").Return(&serializer.PreviousWebhookSecret{Secret: "previousSecret", RotatedAt: time.Now().Add(-25 * time.Hour).Unix()}, nil)
+			},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+		"secret in the URL is the previous secret": {
+			GetRequest: func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathProcessNotification), bytes.NewBufferString("{}"))
+				r.URL.RawQuery = url.Values{"secret": {"previousSecret"}}.Encode()
+				return r
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogDebug", "Webhook request verified with the previous webhook secret").Return()
+			},
+			SetupStore: func(s *mock_plugin.Store) {
+				s.On("LoadPreviousWebhookSecret").Return(&serializer.PreviousWebhookSecret{Secret: "previousSecret", RotatedAt: time.Now().Unix()}, nil)
+				s.On("EnqueueNotification", mock.AnythingOfType("*serializer.QueuedNotification")).Return(nil)
+			},
+			ExpectedStatusCode: http.StatusOK,
+		},
+		"unsigned notification when the signature is required": {
+			RequireSignature: true,
+			GetRequest: func() *http.Request {
+				r := httptest.NewRequest(http.MethodPost, fmt.Sprintf("%s%s", constants.PathPrefix, constants.PathProcessNotification), bytes.NewBufferString("{}"))
+				r.URL.RawQuery = url.Values{"secret": {testutils.GetSecret()}}.Encode()
+				return r
+			},
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", constants.ErrorMissingSignature).Return()
+			},
+			SetupStore:         func(s *mock_plugin.Store) {},
+			ExpectedStatusCode: http.StatusUnauthorized,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			p.setConfiguration(&configuration{
+				WebhookSecret:            testutils.GetSecret(),
+				WebhookSecretGracePeriod: 24,
+				RequireWebhookSignature:  test.RequireSignature,
+			})
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			w := httptest.NewRecorder()
+			p.ServeHTTP(nil, w, test.GetRequest())
+
+			result := w.Result()
+			require.NotNil(t, result)
+			defer result.Body.Close()
+
+			assert.Equal(t, test.ExpectedStatusCode, result.StatusCode)
+		})
+	}
+}
+
+func TestHandleWebhookSecretRotation(t *testing.T) {
+	secretHash := sha256.Sum256([]byte(testutils.GetSecret()))
+	for name, test := range map[string]struct {
+		SetupAPI   func(*plugintest.API)
+		SetupStore func(*mock_plugin.Store)
+	}{
+		"rotation is handled": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogError", constants.ErrorPushWebhookSecret, "Error", "error in getting the users").Return()
+			},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("StoreWebhookSecretRotation", hex.EncodeToString(secretHash[:])).Return(true, nil)
+				store.On("StorePreviousWebhookSecret", mock.MatchedBy(func(previousSecret *serializer.PreviousWebhookSecret) bool {
+					return previousSecret.Secret == "previousSecret"
+				}), int64(24*60*60)).Return(nil)
+				store.On("GetAllUsers").Return(nil, errors.New("error in getting the users"))
+			},
+		},
+		"rotation is already handled by another server": {
+			SetupAPI: func(api *plugintest.API) {},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("StoreWebhookSecretRotation", hex.EncodeToString(secretHash[:])).Return(false, nil)
+			},
+		},
+		"rotation can't be recorded": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("LogWarn", "Unable to record the rotation of the webhook secret", "Error", "error in storing the rotation").Return()
+				api.On("LogError", constants.ErrorPushWebhookSecret, "Error", "error in getting the users").Return()
+			},
+			SetupStore: func(store *mock_plugin.Store) {
+				store.On("StoreWebhookSecretRotation", hex.EncodeToString(secretHash[:])).Return(false, errors.New("error in storing the rotation"))
+				store.On("StorePreviousWebhookSecret", mock.AnythingOfType("*serializer.PreviousWebhookSecret"), int64(24*60*60)).Return(nil)
+				store.On("GetAllUsers").Return(nil, errors.New("error in getting the users"))
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			test.SetupStore(store)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			p.setConfiguration(&configuration{
+				WebhookSecret:            testutils.GetSecret(),
+				WebhookSecretGracePeriod: 24,
+			})
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			p.handleWebhookSecretRotation("previousSecret")
+
+			// This is used to wait for goroutine to finish.
+			time.Sleep(100 * time.Millisecond)
+		})
+	}
+}
+
+func TestPushWebhookSecret(t *testing.T) {
+	defer monkey.UnpatchAll()
+	for name, test := range map[string]struct {
+		SetupAPI    func(*plugintest.API)
+		SetupClient func(*mock_plugin.Client)
+	}{
+		"secret updated by a connected system admin": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetUser", "userID").Return(&model.User{Roles: model.SystemUserRoleId}, nil)
+				api.On("GetUser", "adminID").Return(&model.User{Roles: model.SystemAdminRoleId}, nil)
+				api.On("LogInfo", "Updated the webhook secret in ServiceNow", "UserID", "adminID").Return()
+			},
+			SetupClient: func(c *mock_plugin.Client) {
+				c.On("ActivateSubscriptions").Return(http.StatusOK, nil)
+			},
+		},
+		"system admin not permitted to update the secret": {
+			SetupAPI: func(api *plugintest.API) {
+				api.On("GetUser", "userID").Return(&model.User{Roles: model.SystemUserRoleId}, nil)
+				api.On("GetUser", "adminID").Return(&model.User{Roles: model.SystemAdminRoleId}, nil)
+				api.On("LogDebug", constants.ErrorPushWebhookSecret, "UserID", "adminID", "Error", constants.APIErrorIDSubscriptionsNotAuthorized).Return()
+				api.On("LogWarn", constants.ErrorPushWebhookSecret, "Error", mock.AnythingOfType("string")).Return()
+			},
+			SetupClient: func(c *mock_plugin.Client) {
+				c.On("ActivateSubscriptions").Return(http.StatusForbidden, errors.New(constants.APIErrorIDSubscriptionsNotAuthorized))
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			store := mock_plugin.NewStore(t)
+			store.On("GetAllUsers").Return([]*serializer.IncidentCaller{
+				{MattermostUserID: "userID"},
+				{MattermostUserID: "adminID"},
+			}, nil)
+			p, api := setupTestPlugin(&plugintest.API{}, store)
+			test.SetupAPI(api)
+			defer api.AssertExpectations(t)
+
+			client := mock_plugin.NewClient(t)
+			test.SetupClient(client)
+			monkey.PatchInstanceMethod(reflect.TypeOf(p), "GetClientForUser", func(_ *Plugin, mattermostUserID string) (Client, error) {
+				assert.Equal(t, "adminID", mattermostUserID)
+				return client, nil
+			})
+
+			p.pushWebhookSecret()
+		})
+	}
+}
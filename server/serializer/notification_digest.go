@@ -0,0 +1,96 @@
+package serializer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+)
+
+// NotificationDigest buffers the events of a bulk subscription delivered as a digest until the end of its window
+type NotificationDigest struct {
+	ID             string             `json:"id"`
+	SubscriptionID string             `json:"subscription_id"`
+	ChannelID      string             `json:"channel_id"`
+	RecordType     string             `json:"record_type"`
+	DeliveryMode   string             `json:"delivery_mode"`
+	WindowEnd      int64              `json:"window_end"`
+	Events         []*ServiceNowEvent `json:"events"`
+	OmittedEvents  int                `json:"omitted_events,omitempty"`
+}
+
+// NewNotificationDigest creates the digest of the window containing the given time for the subscription of the event.
+// The hourly windows end at the start of every hour and the daily windows end at midnight UTC.
+func NewNotificationDigest(event *ServiceNowEvent, deliveryMode string, now time.Time) *NotificationDigest {
+	window := time.Hour
+	if deliveryMode == constants.DeliveryModeDaily {
+		window = 24 * time.Hour
+	}
+
+	windowEnd := now.Truncate(window).Add(window).Unix()
+	return &NotificationDigest{
+		ID:             fmt.Sprintf("%s_%d", event.SubscriptionID, windowEnd),
+		SubscriptionID: event.SubscriptionID,
+		ChannelID:      event.ChannelID,
+		RecordType:     event.RecordType,
+		DeliveryMode:   deliveryMode,
+		WindowEnd:      windowEnd,
+	}
+}
+
+// AddEvent adds an event to the digest. The events after the maximum number of events are only counted.
+func (d *NotificationDigest) AddEvent(event *ServiceNowEvent) {
+	if len(d.Events) >= constants.MaxNotificationDigestEvents {
+		d.OmittedEvents++
+		return
+	}
+
+	d.Events = append(d.Events, event)
+}
+
+// IsDue returns true if the window of the digest has ended
+func (d *NotificationDigest) IsDue(now time.Time) bool {
+	return d.WindowEnd <= now.Unix()
+}
+
+// CreateDigestPost creates the post summarizing the events of the digest in a table
+func (d *NotificationDigest) CreateDigestPost(botID, serviceNowURL string, recordTypes *RecordTypeRegistry) *model.Post {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("#### %s of the [%s](%s) notifications\n", GetFormattedDeliveryMode(d.DeliveryMode), recordTypes.GetDisplayName(d.RecordType), fmt.Sprintf(constants.PathRecordList, serviceNowURL, d.RecordType)))
+	sb.WriteString(fmt.Sprintf("%d event(s) received until %s\n", len(d.Events)+d.OmittedEvents, time.Unix(d.WindowEnd, 0).UTC().Format(time.RFC1123)))
+	sb.WriteString("| Number | Event | State | Priority | Assigned To |\n| :----|:--------| :--------| :--------|:--------|")
+	for _, event := range d.Events {
+		number := escapeTableCell(event.Number)
+		if event.RecordID != "" {
+			number = fmt.Sprintf("[%s](%s)", number, fmt.Sprintf(constants.PathRecord, serviceNowURL, event.RecordType, event.RecordID, event.RecordType))
+		}
+
+		eventName, ok := constants.FormattedEventNames[event.EventOccurred]
+		if !ok {
+			eventName = "N/A"
+		}
+
+		sb.WriteString(fmt.Sprintf("\n|%s|%s|%s|%s|%s|", number, eventName, escapeTableCell(event.State), escapeTableCell(event.Priority), escapeTableCell(event.AssignedTo)))
+	}
+
+	if d.OmittedEvents > 0 {
+		sb.WriteString(fmt.Sprintf("\n\n_...and %d more event(s)_", d.OmittedEvents))
+	}
+
+	return &model.Post{
+		ChannelId: d.ChannelID,
+		UserId:    botID,
+		Message:   sb.String(),
+	}
+}
+
+func escapeTableCell(value string) string {
+	if value == "" {
+		return "N/A"
+	}
+
+	return strings.ReplaceAll(value, "|", "\\|")
+}
@@ -0,0 +1,45 @@
+package serializer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+)
+
+const maxFormattedErrorLength = 100
+
+// QueuedNotification is a notification event waiting in the queue to be posted,
+// or in the dead letters if it could not be posted after all the attempts
+type QueuedNotification struct {
+	ID            string           `json:"id"`
+	Event         *ServiceNowEvent `json:"event"`
+	CreatedAt     int64            `json:"created_at"`
+	Attempts      int              `json:"attempts"`
+	NextAttemptAt int64            `json:"next_attempt_at"`
+	LastError     string           `json:"last_error,omitempty"`
+}
+
+// GetFormattedNotification formats the notification as a row of the table of the failed notifications
+func (n *QueuedNotification) GetFormattedNotification() string {
+	record, event := "N/A", "N/A"
+	if n.Event != nil {
+		if n.Event.Number != "" {
+			record = n.Event.Number
+		}
+
+		if n.Event.SubscriptionType == constants.SubscriptionTypeApproval {
+			event = "Approval requested"
+		} else if eventName, ok := constants.FormattedEventNames[n.Event.EventOccurred]; ok {
+			event = eventName
+		}
+	}
+
+	lastError := strings.ReplaceAll(n.LastError, "|", "\\|")
+	if runes := []rune(lastError); len(runes) > maxFormattedErrorLength {
+		lastError = string(runes[:maxFormattedErrorLength]) + "..."
+	}
+
+	return fmt.Sprintf("\n|%s|%s|%s|%s|%d|%s|", n.ID, record, event, time.Unix(n.CreatedAt, 0).UTC().Format(time.RFC1123), n.Attempts, lastError)
+}
@@ -0,0 +1,177 @@
+package serializer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+)
+
+var tableNameRegex = regexp.MustCompile(constants.ServiceNowTableNameRegex)
+
+// RecordType describes a ServiceNow table and the features of the plugin which can be used with its records
+type RecordType struct {
+	Name        string `json:"name"`
+	DisplayName string `json:"display_name"`
+	Search      bool   `json:"search"`
+	Share       bool   `json:"share"`
+	Subscribe   bool   `json:"subscribe"`
+	Comment     bool   `json:"comment"`
+	WorkNotes   bool   `json:"work_notes"`
+	UpdateState bool   `json:"update_state"`
+	Assign      bool   `json:"assign"`
+	Create      bool   `json:"create"`
+}
+
+// RecordTypeRegistry holds the record types which can be used with the plugin.
+// A nil registry behaves like the registry of the default record types.
+type RecordTypeRegistry struct {
+	recordTypes []*RecordType
+	byName      map[string]*RecordType
+}
+
+var defaultRecordTypeRegistry = NewRecordTypeRegistry(DefaultRecordTypes())
+
+// DefaultRecordTypes returns the record types supported by the plugin out of the box
+func DefaultRecordTypes() []*RecordType {
+	return []*RecordType{
+		{Name: constants.RecordTypeIncident, DisplayName: "Incident", Search: true, Share: true, Subscribe: true, Comment: true, WorkNotes: true, UpdateState: true, Assign: true, Create: true},
+		{Name: constants.RecordTypeProblem, DisplayName: "Problem", Search: true, Share: true, Subscribe: true, Comment: true, WorkNotes: true, UpdateState: true, Assign: true, Create: true},
+		{Name: constants.RecordTypeChangeRequest, DisplayName: "Change Request", Search: true, Share: true, Subscribe: true, Comment: true, WorkNotes: true, UpdateState: true, Assign: true, Create: true},
+		{Name: constants.RecordTypeKnowledge, DisplayName: "Knowledge Article", Search: true, Share: true},
+		{Name: constants.RecordTypeTask, DisplayName: "Task", Search: true, Share: true, Comment: true, WorkNotes: true, UpdateState: true, Assign: true},
+		{Name: constants.RecordTypeChangeTask, DisplayName: "Change Task", Search: true, Share: true, Comment: true, WorkNotes: true, UpdateState: true, Assign: true},
+		{Name: constants.RecordTypeFollowOnTask, DisplayName: "Follow On Task", Search: true, Share: true, Comment: true, WorkNotes: true, UpdateState: true, Assign: true},
+		{Name: constants.RecordTypeCatalogRequest, DisplayName: "Catalog Request", Search: true, Share: true, Comment: true, WorkNotes: true, Assign: true, Create: true},
+		{Name: constants.RecordTypeRequestedItem, DisplayName: "Requested Item", Search: true, Share: true, Subscribe: true, Comment: true, WorkNotes: true, Assign: true},
+	}
+}
+
+func NewRecordTypeRegistry(recordTypes []*RecordType) *RecordTypeRegistry {
+	r := &RecordTypeRegistry{
+		byName: make(map[string]*RecordType, len(recordTypes)),
+	}
+
+	for _, recordType := range recordTypes {
+		if existing, ok := r.byName[recordType.Name]; ok {
+			*existing = *recordType
+			continue
+		}
+
+		rt := *recordType
+		r.recordTypes = append(r.recordTypes, &rt)
+		r.byName[rt.Name] = &rt
+	}
+
+	return r
+}
+
+// RecordTypeRegistryFromJSON creates a registry from the default record types and the record types configured by the admin.
+// A configured record type having the same name as a default one overrides it.
+func RecordTypeRegistryFromJSON(data string) (*RecordTypeRegistry, error) {
+	recordTypes := DefaultRecordTypes()
+	if strings.TrimSpace(data) == "" {
+		return NewRecordTypeRegistry(recordTypes), nil
+	}
+
+	var configured []*RecordType
+	if err := json.Unmarshal([]byte(data), &configured); err != nil {
+		return nil, err
+	}
+
+	for _, recordType := range configured {
+		if recordType == nil {
+			continue
+		}
+
+		if err := recordType.IsValid(); err != nil {
+			return nil, err
+		}
+
+		recordTypes = append(recordTypes, recordType)
+	}
+
+	return NewRecordTypeRegistry(recordTypes), nil
+}
+
+func (rt *RecordType) IsValid() error {
+	rt.Name = strings.TrimSpace(rt.Name)
+	if !tableNameRegex.MatchString(rt.Name) {
+		return fmt.Errorf("record type name %q is not a valid ServiceNow table name", rt.Name)
+	}
+
+	rt.DisplayName = strings.TrimSpace(rt.DisplayName)
+	if rt.DisplayName == "" {
+		rt.DisplayName = rt.Name
+	}
+
+	return nil
+}
+
+func (r *RecordTypeRegistry) get(name string) *RecordType {
+	if r == nil {
+		r = defaultRecordTypeRegistry
+	}
+
+	return r.byName[name]
+}
+
+// List returns all the record types present in the registry
+func (r *RecordTypeRegistry) List() []*RecordType {
+	if r == nil {
+		r = defaultRecordTypeRegistry
+	}
+
+	return r.recordTypes
+}
+
+func (r *RecordTypeRegistry) IsSearchable(name string) bool {
+	rt := r.get(name)
+	return rt != nil && rt.Search
+}
+
+func (r *RecordTypeRegistry) IsShareable(name string) bool {
+	rt := r.get(name)
+	return rt != nil && rt.Share
+}
+
+func (r *RecordTypeRegistry) IsSubscribable(name string) bool {
+	rt := r.get(name)
+	return rt != nil && rt.Subscribe
+}
+
+func (r *RecordTypeRegistry) SupportsComments(name string) bool {
+	rt := r.get(name)
+	return rt != nil && rt.Comment
+}
+
+func (r *RecordTypeRegistry) SupportsWorkNotes(name string) bool {
+	rt := r.get(name)
+	return rt != nil && rt.WorkNotes
+}
+
+func (r *RecordTypeRegistry) SupportsAssignment(name string) bool {
+	rt := r.get(name)
+	return rt != nil && rt.Assign
+}
+
+func (r *RecordTypeRegistry) SupportsStateUpdation(name string) bool {
+	rt := r.get(name)
+	return rt != nil && rt.UpdateState
+}
+
+func (r *RecordTypeRegistry) SupportsCreation(name string) bool {
+	rt := r.get(name)
+	return rt != nil && rt.Create
+}
+
+// GetDisplayName returns the display name of the record type, or its name if it is not present in the registry
+func (r *RecordTypeRegistry) GetDisplayName(name string) string {
+	if rt := r.get(name); rt != nil {
+		return rt.DisplayName
+	}
+
+	return name
+}
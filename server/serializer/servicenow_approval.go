@@ -0,0 +1,124 @@
+package serializer
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+)
+
+// ServiceNowApprovalPayload contains the decision of the approver on an approval
+type ServiceNowApprovalPayload struct {
+	State    string `json:"state"`
+	Comments string `json:"comments,omitempty"`
+}
+
+func (ap *ServiceNowApprovalPayload) Validate() error {
+	ap.Comments = strings.TrimSpace(ap.Comments)
+	switch ap.State {
+	case constants.ApprovalStateApproved:
+		return nil
+	case constants.ApprovalStateRejected:
+		if ap.Comments == "" {
+			return errors.New(constants.ErrorEmptyRejectionComment)
+		}
+		return nil
+	}
+
+	return errors.New(constants.ErrorInvalidApprovalState)
+}
+
+// IsValidApprovalID checks if the approval ID is a valid sys ID
+func IsValidApprovalID(approvalID string) bool {
+	valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), approvalID)
+	return err == nil && valid
+}
+
+// CreateApprovalPost creates the post sent to the approver in their DM with the bot
+func (se *ServiceNowEvent) CreateApprovalPost(botID, channelID, serviceNowURL, pluginURL string) *model.Post {
+	post := &model.Post{
+		ChannelId: channelID,
+		UserId:    botID,
+	}
+
+	context := map[string]interface{}{
+		constants.ContextNameApprovalID:   se.ApprovalID,
+		constants.ContextNameRecordType:   se.RecordType,
+		constants.ContextNameRecordID:     se.RecordID,
+		constants.ContextNameRecordNumber: se.Number,
+	}
+
+	titleLink := fmt.Sprintf(constants.PathRecord, serviceNowURL, se.RecordType, se.RecordID, se.RecordType)
+	slackAttachment := &model.SlackAttachment{
+		Title: fmt.Sprintf("[%s](%s): %s", se.Number, titleLink, se.ShortDescription),
+		Text:  fmt.Sprintf("**%s**", constants.ApprovalRequestedMessage),
+		Fields: []*model.SlackAttachmentField{
+			{
+				Title: "Record",
+				Value: se.RecordTypeName,
+				Short: true,
+			},
+			{
+				Title: "State",
+				Value: se.State,
+				Short: true,
+			},
+		},
+		Actions: []*model.PostAction{
+			{
+				Type: model.PostActionTypeButton,
+				Name: "Approve",
+				Integration: &model.PostActionIntegration{
+					URL:     fmt.Sprintf("%s%s", pluginURL, constants.PathApproveRecord),
+					Context: context,
+				},
+			},
+			{
+				Type: model.PostActionTypeButton,
+				Name: "Reject",
+				Integration: &model.PostActionIntegration{
+					URL:     fmt.Sprintf("%s%s", pluginURL, constants.PathOpenRejectDialog),
+					Context: context,
+				},
+			},
+		},
+	}
+
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{slackAttachment})
+	return post
+}
+
+// UpdateApprovalPost records the decision taken on the approval in its post and removes the buttons from it,
+// so that the decision can't be taken again from the same post
+func UpdateApprovalPost(post *model.Post, state, comments string) bool {
+	attachments := post.Attachments()
+	if len(attachments) == 0 {
+		return false
+	}
+
+	decision := "Approved"
+	if state == constants.ApprovalStateRejected {
+		decision = "Rejected"
+	}
+
+	attachments[0].Actions = nil
+	attachments[0].Fields = append(attachments[0].Fields, &model.SlackAttachmentField{
+		Title: "Decision",
+		Value: decision,
+		Short: true,
+	})
+
+	if comments != "" {
+		attachments[0].Fields = append(attachments[0].Fields, &model.SlackAttachmentField{
+			Title: "Comment",
+			Value: comments,
+		})
+	}
+
+	model.ParseSlackAttachment(post, attachments)
+	return true
+}
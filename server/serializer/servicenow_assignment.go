@@ -0,0 +1,49 @@
+package serializer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+)
+
+// ServiceNowAssignmentPayload contains the user and/or the group a record is to be assigned to
+type ServiceNowAssignmentPayload struct {
+	AssignedTo      string `json:"assigned_to,omitempty"`
+	AssignmentGroup string `json:"assignment_group,omitempty"`
+}
+
+func ServiceNowAssignmentPayloadFromJSON(data io.Reader) (*ServiceNowAssignmentPayload, error) {
+	var ap *ServiceNowAssignmentPayload
+	if err := json.NewDecoder(data).Decode(&ap); err != nil {
+		return nil, err
+	}
+
+	return ap, nil
+}
+
+func (ap *ServiceNowAssignmentPayload) Validate() error {
+	ap.AssignedTo = strings.TrimSpace(ap.AssignedTo)
+	ap.AssignmentGroup = strings.TrimSpace(ap.AssignmentGroup)
+	if ap.AssignedTo == "" && ap.AssignmentGroup == "" {
+		return errors.New(constants.ErrorEmptyAssignment)
+	}
+
+	if ap.AssignedTo != "" {
+		if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), ap.AssignedTo); err != nil || !valid {
+			return errors.New(constants.ErrorInvalidAssignedTo)
+		}
+	}
+
+	if ap.AssignmentGroup != "" {
+		if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), ap.AssignmentGroup); err != nil || !valid {
+			return errors.New(constants.ErrorInvalidAssignmentGroup)
+		}
+	}
+
+	return nil
+}
@@ -1,5 +1,17 @@
 package serializer
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+)
+
 type ServiceNowCatalogItem struct {
 	SysID            string   `json:"sys_id"`
 	Name             string   `json:"name"`
@@ -16,3 +28,211 @@ type Category struct {
 type ServiceNowCatalogItemsResult struct {
 	Result []*ServiceNowCatalogItem `json:"result"`
 }
+
+// ServiceNowCatalogItemDetails is a catalog item along with the variables which can be filled while ordering it
+type ServiceNowCatalogItemDetails struct {
+	SysID            string                       `json:"sys_id"`
+	Name             string                       `json:"name"`
+	ShortDescription string                       `json:"short_description"`
+	Variables        []*ServiceNowCatalogVariable `json:"variables"`
+}
+
+type ServiceNowCatalogItemDetailsResult struct {
+	Result *ServiceNowCatalogItemDetails `json:"result"`
+}
+
+type ServiceNowCatalogVariable struct {
+	Name         string                             `json:"name"`
+	Label        string                             `json:"label"`
+	FriendlyType string                             `json:"friendly_type"`
+	Mandatory    bool                               `json:"mandatory"`
+	ReadOnly     bool                               `json:"read_only"`
+	Value        string                             `json:"value"`
+	HelpText     string                             `json:"help_text"`
+	Reference    string                             `json:"reference"`
+	Choices      []*ServiceNowCatalogVariableChoice `json:"choices"`
+}
+
+type ServiceNowCatalogVariableChoice struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// CatalogItemForm is the definition of the form to be filled for ordering a catalog item
+type CatalogItemForm struct {
+	SysID            string                  `json:"sys_id"`
+	Name             string                  `json:"name"`
+	ShortDescription string                  `json:"short_description"`
+	Fields           []*CatalogItemFormField `json:"fields"`
+}
+
+type CatalogItemFormField struct {
+	Name           string                             `json:"name"`
+	Label          string                             `json:"label"`
+	Type           string                             `json:"type"`
+	Required       bool                               `json:"required"`
+	Default        string                             `json:"default,omitempty"`
+	HelpText       string                             `json:"help_text,omitempty"`
+	ReferenceTable string                             `json:"reference_table,omitempty"`
+	Options        []*ServiceNowCatalogVariableChoice `json:"options,omitempty"`
+}
+
+type CatalogItemOrderPayload struct {
+	ChannelID string            `json:"channel_id"`
+	Quantity  int               `json:"quantity"`
+	Variables map[string]string `json:"variables"`
+}
+
+type ServiceNowCatalogOrderRequest struct {
+	Quantity  string            `json:"sysparm_quantity"`
+	Variables map[string]string `json:"variables"`
+}
+
+type ServiceNowCatalogOrderResponse struct {
+	SysID         string `json:"sys_id"`
+	Number        string `json:"number"`
+	RequestNumber string `json:"request_number"`
+	RequestID     string `json:"request_id"`
+	Table         string `json:"table"`
+}
+
+type ServiceNowCatalogOrderResult struct {
+	Result *ServiceNowCatalogOrderResponse `json:"result"`
+}
+
+// CatalogItemOrder is the response of ordering a catalog item from Mattermost
+type CatalogItemOrder struct {
+	RequestNumber  string                     `json:"request_number"`
+	RequestID      string                     `json:"request_id"`
+	RequestedItems []*ServiceNowPartialRecord `json:"requested_items"`
+}
+
+// ToForm converts the variables of the catalog item to form fields.
+// Variables used only for laying out the form in ServiceNow, like containers and labels, are skipped.
+func (cd *ServiceNowCatalogItemDetails) ToForm() *CatalogItemForm {
+	form := &CatalogItemForm{
+		SysID:            cd.SysID,
+		Name:             cd.Name,
+		ShortDescription: cd.ShortDescription,
+		Fields:           []*CatalogItemFormField{},
+	}
+
+	for _, variable := range cd.Variables {
+		fieldType, ok := constants.CatalogVariableFormFieldTypes[variable.FriendlyType]
+		if !ok || variable.ReadOnly || variable.Name == "" {
+			continue
+		}
+
+		field := &CatalogItemFormField{
+			Name:     variable.Name,
+			Label:    variable.Label,
+			Type:     fieldType,
+			Required: variable.Mandatory,
+			Default:  variable.Value,
+			HelpText: variable.HelpText,
+		}
+
+		switch fieldType {
+		case constants.FormFieldTypeSelect, constants.FormFieldTypeRadio:
+			field.Options = variable.Choices
+		case constants.FormFieldTypeReference:
+			field.ReferenceTable = variable.Reference
+		}
+
+		form.Fields = append(form.Fields, field)
+	}
+
+	return form
+}
+
+// ValidateVariables checks that the values of all the required fields of the form are present
+func (f *CatalogItemForm) ValidateVariables(variables map[string]string) error {
+	for _, field := range f.Fields {
+		if field.Required && strings.TrimSpace(variables[field.Name]) == "" {
+			return fmt.Errorf(constants.ErrorMissingRequiredField, field.Label)
+		}
+	}
+
+	return nil
+}
+
+func CatalogItemOrderPayloadFromJSON(data io.Reader) (*CatalogItemOrderPayload, error) {
+	var op *CatalogItemOrderPayload
+	if err := json.NewDecoder(data).Decode(&op); err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func (op *CatalogItemOrderPayload) IsValid() error {
+	if !model.IsValidId(op.ChannelID) {
+		return errors.New(constants.ErrorInvalidChannelID)
+	}
+
+	if op.Quantity == 0 {
+		op.Quantity = 1
+	}
+
+	if op.Quantity < 0 {
+		return errors.New(constants.ErrorInvalidQuantity)
+	}
+
+	return nil
+}
+
+// ToServiceNowRequest returns the request body for the "order_now" API of ServiceNow
+func (op *CatalogItemOrderPayload) ToServiceNowRequest() *ServiceNowCatalogOrderRequest {
+	variables := op.Variables
+	if variables == nil {
+		variables = map[string]string{}
+	}
+
+	return &ServiceNowCatalogOrderRequest{
+		Quantity:  fmt.Sprint(op.Quantity),
+		Variables: variables,
+	}
+}
+
+// CreateOrderPost creates the post announcing the order of a catalog item in a channel.
+// The post offers subscribing to the requested items when the requested item record type supports subscriptions.
+func (o *CatalogItemOrder) CreateOrderPost(channelID, botID, itemName, orderedByUsername, serviceNowURL, pluginURL string, recordTypes *RecordTypeRegistry) *model.Post {
+	post := &model.Post{
+		ChannelId: channelID,
+		UserId:    botID,
+	}
+
+	requestLink := fmt.Sprintf("%s/nav_to.do?uri=%s.do?sys_id=%s", serviceNowURL, constants.RecordTypeCatalogRequest, o.RequestID)
+	var sb strings.Builder
+	var actions []*model.PostAction
+	for _, item := range o.RequestedItems {
+		itemLink := fmt.Sprintf("%s/nav_to.do?uri=%s.do?sys_id=%s", serviceNowURL, constants.RecordTypeRequestedItem, item.SysID)
+		sb.WriteString(fmt.Sprintf("- [%s](%s): %s\n", item.Number, itemLink, item.ShortDescription))
+
+		if recordTypes.IsSubscribable(constants.RecordTypeRequestedItem) {
+			actions = append(actions, &model.PostAction{
+				Type: model.PostActionTypeButton,
+				Name: fmt.Sprintf("Subscribe to %s", item.Number),
+				Integration: &model.PostActionIntegration{
+					URL: fmt.Sprintf("%s%s", pluginURL, constants.PathOpenSubscriptionModal),
+					Context: map[string]interface{}{
+						constants.ContextNameRecordType:   constants.RecordTypeRequestedItem,
+						constants.ContextNameRecordID:     item.SysID,
+						constants.ContextNameRecordNumber: item.Number,
+						constants.ContextNameChannelID:    channelID,
+					},
+				},
+			})
+		}
+	}
+
+	slackAttachment := &model.SlackAttachment{
+		Pretext: fmt.Sprintf("@%s ordered **%s**", orderedByUsername, itemName),
+		Title:   fmt.Sprintf("[%s](%s)", o.RequestNumber, requestLink),
+		Text:    strings.TrimSpace(sb.String()),
+		Actions: actions,
+	}
+
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{slackAttachment})
+	return post
+}
@@ -13,8 +13,36 @@ type ServiceNowComment struct {
 	CommentsAndWorkNotes string `json:"comments_and_work_notes"`
 }
 
+// ServiceNowJournalEntry is a comment or work note of a record stored in the "sys_journal_field" table
+type ServiceNowJournalEntry struct {
+	Element   string `json:"element"`
+	Value     string `json:"value"`
+	CreatedBy string `json:"sys_created_by"`
+	CreatedOn string `json:"sys_created_on"`
+}
+
+type ServiceNowJournalEntriesResult struct {
+	Result []*ServiceNowJournalEntry `json:"result"`
+}
+
+type CommentEntry struct {
+	Author    string `json:"author"`
+	CreatedOn string `json:"created_on"`
+	Type      string `json:"type"`
+	Value     string `json:"value"`
+}
+
+// CommentsResponse contains the comments and work notes of a record.
+// CommentsAndWorkNotes is only used when the journal entries of the record can't be fetched.
+type CommentsResponse struct {
+	Entries              []*CommentEntry `json:"entries"`
+	CommentsAndWorkNotes string          `json:"comments_and_work_notes,omitempty"`
+}
+
+// ServiceNowCommentPayload contains either a customer-visible comment or an internal work note to be added on a record
 type ServiceNowCommentPayload struct {
-	Comments string `json:"comments"`
+	Comments  string `json:"comments,omitempty"`
+	WorkNotes string `json:"work_notes,omitempty"`
 }
 
 type ServiceNowCommentsResult struct {
@@ -32,9 +60,32 @@ func ServiceNowCommentPayloadFromJSON(data io.Reader) (*ServiceNowCommentPayload
 
 func (s *ServiceNowCommentPayload) Validate() error {
 	s.Comments = strings.TrimSpace(s.Comments)
-	if s.Comments == "" {
+	s.WorkNotes = strings.TrimSpace(s.WorkNotes)
+	if s.Comments == "" && s.WorkNotes == "" {
 		return errors.New(constants.ErrorEmptyComment)
 	}
 
+	if s.Comments != "" && s.WorkNotes != "" {
+		return errors.New(constants.ErrorCommentAndWorkNotes)
+	}
+
 	return nil
 }
+
+func (s *ServiceNowCommentPayload) IsWorkNote() bool {
+	return s.WorkNotes != ""
+}
+
+func (je *ServiceNowJournalEntry) ToCommentEntry() *CommentEntry {
+	entryType := constants.CommentEntryTypeComment
+	if je.Element == constants.FieldWorkNotes {
+		entryType = constants.CommentEntryTypeWorkNote
+	}
+
+	return &CommentEntry{
+		Author:    je.CreatedBy,
+		CreatedOn: je.CreatedOn,
+		Type:      entryType,
+		Value:     je.Value,
+	}
+}
@@ -25,7 +25,12 @@ type ServiceNowEvent struct {
 	Priority         string `json:"priority"`
 	AssignedTo       string `json:"assigned_to"`
 	AssignmentGroup  string `json:"assignment_group"`
+	AssignedToID     string `json:"assigned_to_id"`
+	OpenedByID       string `json:"opened_by_id"`
 	EventOccurred    string `json:"event_occurred"`
+	ApprovalID       string `json:"approval_id"`
+	ApproverID       string `json:"approver_id"`
+	ApproverEmail    string `json:"approver_email"`
 }
 
 func ServiceNowEventFromJSON(data io.Reader) (*ServiceNowEvent, error) {
@@ -37,7 +42,7 @@ func ServiceNowEventFromJSON(data io.Reader) (*ServiceNowEvent, error) {
 	return se, nil
 }
 
-func (se *ServiceNowEvent) CreateNotificationPost(botID, serviceNowURL, pluginURL string) *model.Post {
+func (se *ServiceNowEvent) CreateNotificationPost(botID, serviceNowURL, pluginURL string, recordTypes *RecordTypeRegistry) *model.Post {
 	post := &model.Post{
 		ChannelId: se.ChannelID,
 		UserId:    botID,
@@ -51,7 +56,7 @@ func (se *ServiceNowEvent) CreateNotificationPost(botID, serviceNowURL, pluginUR
 	}
 
 	var actions []*model.PostAction
-	if constants.RecordTypesSupportingComments[se.RecordType] {
+	if recordTypes.SupportsComments(se.RecordType) {
 		actions = append(actions, &model.PostAction{
 			Type: model.PostActionTypeButton,
 			Name: "Add and view comments",
@@ -65,7 +70,22 @@ func (se *ServiceNowEvent) CreateNotificationPost(botID, serviceNowURL, pluginUR
 		})
 	}
 
-	if constants.RecordTypesSupportingStateUpdation[se.RecordType] {
+	if recordTypes.SupportsWorkNotes(se.RecordType) {
+		actions = append(actions, &model.PostAction{
+			Type: model.PostActionTypeButton,
+			Name: "Add work note",
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("%s%s", pluginURL, constants.PathOpenCommentModal),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordType:  se.RecordType,
+					constants.ContextNameRecordID:    se.RecordID,
+					constants.ContextNameCommentType: constants.CommentEntryTypeWorkNote,
+				},
+			},
+		})
+	}
+
+	if recordTypes.SupportsStateUpdation(se.RecordType) {
 		actions = append(actions, &model.PostAction{
 			Type: model.PostActionTypeButton,
 			Name: "Update State",
@@ -79,40 +99,88 @@ func (se *ServiceNowEvent) CreateNotificationPost(botID, serviceNowURL, pluginUR
 		})
 	}
 
-	titleLink := fmt.Sprintf(constants.PathRecord, serviceNowURL, se.RecordType, se.RecordID, se.RecordType)
-	slackAttachment := &model.SlackAttachment{
-		Title: fmt.Sprintf("[%s](%s): %s", se.Number, titleLink, se.ShortDescription),
-		Text:  fmt.Sprintf("**Event: %s**", constants.FormattedEventNames[se.EventOccurred]),
-		Fields: []*model.SlackAttachmentField{
-			{
-				Title: "Record",
-				Value: se.RecordTypeName,
-				Short: true,
-			},
-			{
-				Title: "State",
-				Value: se.State,
-				Short: true,
-			},
-			{
-				Title: "Priority",
-				Value: se.Priority,
-				Short: true,
-			},
-			{
-				Title: "Assigned to",
-				Value: se.AssignedTo,
-				Short: true,
+	if recordTypes.SupportsAssignment(se.RecordType) {
+		actions = append(actions, &model.PostAction{
+			Type: model.PostActionTypeButton,
+			Name: "Assign to me",
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("%s%s", pluginURL, constants.PathAssignToMe),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordType: se.RecordType,
+					constants.ContextNameRecordID:   se.RecordID,
+				},
 			},
-			{
-				Title: "Assignment group",
-				Value: se.AssignmentGroup,
-				Short: true,
+		}, &model.PostAction{
+			Type: model.PostActionTypeButton,
+			Name: "Reassign",
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("%s%s", pluginURL, constants.PathOpenReassignModal),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordType: se.RecordType,
+					constants.ContextNameRecordID:   se.RecordID,
+				},
 			},
-		},
+		})
+	}
+
+	titleLink := fmt.Sprintf(constants.PathRecord, serviceNowURL, se.RecordType, se.RecordID, se.RecordType)
+	slackAttachment := &model.SlackAttachment{
+		Title:   fmt.Sprintf("[%s](%s): %s", se.Number, titleLink, se.ShortDescription),
+		Text:    fmt.Sprintf("**Event: %s**", constants.FormattedEventNames[se.EventOccurred]),
+		Fields:  se.getNotificationFields(),
 		Actions: actions,
 	}
 
 	model.ParseSlackAttachment(post, []*model.SlackAttachment{slackAttachment})
 	return post
 }
+
+// UpdateNotificationThreadRootPost updates the fields of the root post of a record's notification thread
+// with the current values of the record, so that the root post always shows the latest state of the record
+func (se *ServiceNowEvent) UpdateNotificationThreadRootPost(rootPost *model.Post) bool {
+	attachments := rootPost.Attachments()
+	if len(attachments) == 0 {
+		return false
+	}
+
+	if se.AssignedTo == "" {
+		se.AssignedTo = "N/A"
+	}
+	if se.AssignmentGroup == "" {
+		se.AssignmentGroup = "N/A"
+	}
+
+	attachments[0].Fields = se.getNotificationFields()
+	model.ParseSlackAttachment(rootPost, attachments)
+	return true
+}
+
+func (se *ServiceNowEvent) getNotificationFields() []*model.SlackAttachmentField {
+	return []*model.SlackAttachmentField{
+		{
+			Title: "Record",
+			Value: se.RecordTypeName,
+			Short: true,
+		},
+		{
+			Title: "State",
+			Value: se.State,
+			Short: true,
+		},
+		{
+			Title: "Priority",
+			Value: se.Priority,
+			Short: true,
+		},
+		{
+			Title: "Assigned to",
+			Value: se.AssignedTo,
+			Short: true,
+		},
+		{
+			Title: "Assignment group",
+			Value: se.AssignmentGroup,
+			Short: true,
+		},
+	}
+}
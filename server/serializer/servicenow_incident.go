@@ -3,7 +3,9 @@ package serializer
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"regexp"
 	"strings"
 
 	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
@@ -23,6 +25,12 @@ type IncidentPayload struct {
 	ShortDescription string `json:"short_description"`
 	Description      string `json:"description"`
 	Caller           string `json:"caller_id"`
+	Urgency          string `json:"urgency,omitempty"`
+	Impact           string `json:"impact,omitempty"`
+	Category         string `json:"category,omitempty"`
+	Subcategory      string `json:"subcategory,omitempty"`
+	AssignmentGroup  string `json:"assignment_group,omitempty"`
+	CI               string `json:"cmdb_ci,omitempty"`
 	ChannelID        string `json:"channel_id"`
 }
 
@@ -52,5 +60,31 @@ func (ip *IncidentPayload) IsValid() error {
 		return errors.New(constants.ErrorEmptyShortDescription)
 	}
 
+	if ip.Urgency != "" && !constants.ValidUrgencies[ip.Urgency] {
+		return errors.New(constants.ErrorInvalidUrgency)
+	}
+
+	if ip.Impact != "" && !constants.ValidImpacts[ip.Impact] {
+		return errors.New(constants.ErrorInvalidImpact)
+	}
+
+	ip.Category = strings.TrimSpace(ip.Category)
+	ip.Subcategory = strings.TrimSpace(ip.Subcategory)
+	if ip.Subcategory != "" && ip.Category == "" {
+		return errors.New(constants.ErrorSubcategoryWithoutCategory)
+	}
+
+	if ip.AssignmentGroup != "" {
+		if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), ip.AssignmentGroup); err != nil || !valid {
+			return errors.New(constants.ErrorInvalidAssignmentGroup)
+		}
+	}
+
+	if ip.CI != "" {
+		if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), ip.CI); err != nil || !valid {
+			return errors.New(constants.ErrorInvalidConfigurationItem)
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,344 @@
+package serializer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mattermost/mattermost-server/v6/model"
+	"golang.org/x/net/html"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+)
+
+var (
+	whitespaceRegex     = regexp.MustCompile(`[ \t\r\n\f]+`)
+	extraNewLinesRegex  = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*\n)+`)
+	skippedHTMLElements = map[string]bool{
+		"script": true,
+		"style":  true,
+		"head":   true,
+		"img":    true,
+	}
+)
+
+// IsPublished checks if the knowledge article is published.
+// The workflow state is fetched along with its display value, so it is compared case insensitively.
+func (sr *ServiceNowRecord) IsPublished() bool {
+	return strings.EqualFold(sr.Workflow, constants.KnowledgeWorkflowStatePublished)
+}
+
+// CreateKnowledgeSearchResultAttachment creates the attachment of a knowledge article shown in the results of
+// the "/servicenow kb" command, with a snippet of the article and a button for posting the article in the channel
+func (sr *ServiceNowRecord) CreateKnowledgeSearchResultAttachment(serviceNowURL, pluginURL string) *model.SlackAttachment {
+	return &model.SlackAttachment{
+		Title: fmt.Sprintf("[%s](%s): %s", sr.Number, sr.getKnowledgeArticleLink(serviceNowURL), sr.ShortDescription),
+		Text:  Truncate(HTMLToPlainText(sr.Text), constants.KnowledgeArticleSnippetLength),
+		Fields: []*model.SlackAttachmentField{
+			{
+				Title: "Knowledge Base",
+				Value: sr.KnowledgeBase,
+				Short: true,
+			},
+			{
+				Title: "Category",
+				Value: sr.Category,
+				Short: true,
+			},
+		},
+		Actions: []*model.PostAction{
+			{
+				Type: model.PostActionTypeButton,
+				Name: "Post to channel",
+				Integration: &model.PostActionIntegration{
+					URL: fmt.Sprintf("%s%s", pluginURL, constants.PathPostKnowledgeArticle),
+					Context: map[string]interface{}{
+						constants.ContextNameRecordID: sr.SysID,
+					},
+				},
+			},
+		},
+	}
+}
+
+// CreateKnowledgeArticlePost creates the post containing the text of the knowledge article converted to Markdown.
+// Articles longer than MaxKnowledgeArticleLength are truncated and link to the complete article in ServiceNow.
+func (sr *ServiceNowRecord) CreateKnowledgeArticlePost(channelID, botID, serviceNowURL, postedByUsername string) *model.Post {
+	post := &model.Post{
+		ChannelId: channelID,
+		UserId:    botID,
+	}
+
+	articleLink := sr.getKnowledgeArticleLink(serviceNowURL)
+	text := HTMLToMarkdown(sr.Text, serviceNowURL)
+	if len(text) > constants.MaxKnowledgeArticleLength {
+		text = fmt.Sprintf("%s\n\n[Read the complete article in ServiceNow](%s)", Truncate(text, constants.MaxKnowledgeArticleLength), articleLink)
+	}
+
+	slackAttachment := &model.SlackAttachment{
+		Pretext: fmt.Sprintf("Posted by @%s", postedByUsername),
+		Title:   fmt.Sprintf("[%s](%s): %s", sr.Number, articleLink, sr.ShortDescription),
+		Text:    text,
+		Fields: []*model.SlackAttachmentField{
+			{
+				Title: "Knowledge Base",
+				Value: sr.KnowledgeBase,
+				Short: true,
+			},
+			{
+				Title: "Category",
+				Value: sr.Category,
+				Short: true,
+			},
+		},
+	}
+
+	model.ParseSlackAttachment(post, []*model.SlackAttachment{slackAttachment})
+	return post
+}
+
+func (sr *ServiceNowRecord) getKnowledgeArticleLink(serviceNowURL string) string {
+	return fmt.Sprintf("%s/nav_to.do?uri=%s.do?sys_id=%s", serviceNowURL, constants.RecordTypeKnowledge, sr.SysID)
+}
+
+// Truncate shortens the text to at most maxLength characters, ending it with an ellipsis if it was shortened
+func Truncate(text string, maxLength int) string {
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+
+	return strings.TrimSpace(string(runes[:maxLength-3])) + "..."
+}
+
+// HTMLToPlainText returns the text content of the HTML with the whitespace collapsed
+func HTMLToPlainText(htmlText string) string {
+	return strings.TrimSpace(whitespaceRegex.ReplaceAllString(stripHTMLTags(htmlText), " "))
+}
+
+func stripHTMLTags(htmlText string) string {
+	node, err := html.Parse(strings.NewReader(htmlText))
+	if err != nil {
+		return htmlText
+	}
+
+	var sb strings.Builder
+	var walk func(*html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && skippedHTMLElements[n.Data] {
+			return
+		}
+
+		if n.Type == html.TextNode {
+			sb.WriteString(n.Data)
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+
+		// Keep the words of adjacent blocks apart
+		if n.Type == html.ElementNode {
+			sb.WriteString(" ")
+		}
+	}
+	walk(node)
+
+	return sb.String()
+}
+
+// HTMLToMarkdown converts the HTML text of a knowledge article to Markdown.
+// Relative links are resolved against the URL of the ServiceNow instance and images are left out,
+// as they can't be viewed without signing in to ServiceNow.
+func HTMLToMarkdown(htmlText, serviceNowURL string) string {
+	node, err := html.Parse(strings.NewReader(htmlText))
+	if err != nil {
+		return HTMLToPlainText(htmlText)
+	}
+
+	c := &markdownConverter{serviceNowURL: strings.TrimSuffix(serviceNowURL, "/")}
+	c.convertChildren(node)
+
+	markdown := extraNewLinesRegex.ReplaceAllString(c.sb.String(), "\n\n")
+	return strings.TrimSpace(markdown)
+}
+
+type markdownConverter struct {
+	sb            strings.Builder
+	serviceNowURL string
+	listPrefixes  []string
+	inPre         bool
+	tableRows     int
+}
+
+func (c *markdownConverter) convertChildren(n *html.Node) {
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		c.convert(child)
+	}
+}
+
+func (c *markdownConverter) convert(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		if c.inPre {
+			c.sb.WriteString(n.Data)
+			return
+		}
+
+		c.sb.WriteString(whitespaceRegex.ReplaceAllString(n.Data, " "))
+		return
+	case html.ElementNode:
+	default:
+		c.convertChildren(n)
+		return
+	}
+
+	if skippedHTMLElements[n.Data] {
+		return
+	}
+
+	switch n.Data {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		c.sb.WriteString("\n\n" + strings.Repeat("#", int(n.Data[1]-'0')) + " ")
+		c.convertChildren(n)
+		c.sb.WriteString("\n\n")
+	case "table":
+		tableRows := c.tableRows
+		c.tableRows = 0
+		c.sb.WriteString("\n\n")
+		c.convertChildren(n)
+		c.sb.WriteString("\n\n")
+		c.tableRows = tableRows
+	case "p", "div", "section", "article", "blockquote":
+		c.sb.WriteString("\n\n")
+		c.convertChildren(n)
+		c.sb.WriteString("\n\n")
+	case "br":
+		c.sb.WriteString("\n")
+	case "hr":
+		c.sb.WriteString("\n\n---\n\n")
+	case "strong", "b":
+		c.wrapChildren(n, "**")
+	case "em", "i":
+		c.wrapChildren(n, "_")
+	case "code":
+		if c.inPre {
+			c.convertChildren(n)
+			return
+		}
+		c.wrapChildren(n, "`")
+	case "pre":
+		c.inPre = true
+		c.sb.WriteString("\n\n```\n")
+		c.convertChildren(n)
+		c.sb.WriteString("\n```\n\n")
+		c.inPre = false
+	case "a":
+		c.convertLink(n)
+	case "ul", "ol":
+		prefix := "- "
+		if n.Data == "ol" {
+			prefix = "1. "
+		}
+		// Nested lists continue right after the item containing them
+		if len(c.listPrefixes) == 0 {
+			c.sb.WriteString("\n")
+		}
+		c.listPrefixes = append(c.listPrefixes, prefix)
+		c.convertChildren(n)
+		c.sb.WriteString("\n")
+		c.listPrefixes = c.listPrefixes[:len(c.listPrefixes)-1]
+	case "li":
+		depth := len(c.listPrefixes)
+		prefix := "- "
+		if depth > 0 {
+			prefix = c.listPrefixes[depth-1]
+			depth--
+		}
+		c.sb.WriteString("\n" + strings.Repeat("  ", depth) + prefix)
+		c.convertChildren(n)
+	case "tr":
+		c.sb.WriteString("\n|")
+		c.convertChildren(n)
+		// Markdown tables need a delimiter row after the header row
+		if c.tableRows == 0 {
+			c.sb.WriteString("\n|" + strings.Repeat(" --- |", countTableCells(n)))
+		}
+		c.tableRows++
+	case "td", "th":
+		c.sb.WriteString(" ")
+		c.convertChildren(n)
+		c.sb.WriteString(" |")
+	default:
+		c.convertChildren(n)
+	}
+}
+
+func (c *markdownConverter) wrapChildren(n *html.Node, delimiter string) {
+	if HTMLToPlainText(renderHTMLChildren(n)) == "" {
+		return
+	}
+
+	trimChildrenText(n)
+	c.sb.WriteString(delimiter)
+	c.convertChildren(n)
+	c.sb.WriteString(delimiter)
+}
+
+func (c *markdownConverter) convertLink(n *html.Node) {
+	href := ""
+	for _, attr := range n.Attr {
+		if attr.Key == "href" {
+			href = strings.TrimSpace(attr.Val)
+		}
+	}
+
+	if href == "" || strings.HasPrefix(href, "#") || strings.HasPrefix(strings.ToLower(href), "javascript:") {
+		c.convertChildren(n)
+		return
+	}
+
+	if !strings.Contains(href, "://") && !strings.HasPrefix(href, "mailto:") {
+		href = c.serviceNowURL + "/" + strings.TrimPrefix(href, "/")
+	}
+
+	text := HTMLToPlainText(renderHTMLChildren(n))
+	if text == "" {
+		text = href
+	}
+
+	c.sb.WriteString(fmt.Sprintf("[%s](%s)", text, href))
+}
+
+func countTableCells(row *html.Node) int {
+	count := 0
+	for child := row.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && (child.Data == "td" || child.Data == "th") {
+			count++
+		}
+	}
+
+	return count
+}
+
+// renderHTMLChildren returns the HTML of the children of the node
+func renderHTMLChildren(n *html.Node) string {
+	var sb strings.Builder
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		_ = html.Render(&sb, child)
+	}
+
+	return sb.String()
+}
+
+// trimChildrenText trims the leading and trailing whitespace of the text of the node,
+// so that the Markdown delimiters wrapping the text are placed right next to it
+func trimChildrenText(n *html.Node) {
+	if first := n.FirstChild; first != nil && first.Type == html.TextNode {
+		first.Data = strings.TrimLeft(first.Data, " \t\r\n")
+	}
+
+	if last := n.LastChild; last != nil && last.Type == html.TextNode {
+		last.Data = strings.TrimRight(last.Data, " \t\r\n")
+	}
+}
@@ -0,0 +1,22 @@
+package serializer
+
+// ServiceNowChoice is an entry of the choice list of a ServiceNow field
+type ServiceNowChoice struct {
+	Label          string `json:"label"`
+	Value          string `json:"value"`
+	DependentValue string `json:"dependent_value,omitempty"`
+}
+
+type ServiceNowChoicesResult struct {
+	Result []*ServiceNowChoice `json:"result"`
+}
+
+// ServiceNowReference is a record which can be referenced by a field of another record, like an assignment group or a configuration item
+type ServiceNowReference struct {
+	SysID string `json:"sys_id"`
+	Name  string `json:"name"`
+}
+
+type ServiceNowReferencesResult struct {
+	Result []*ServiceNowReference `json:"result"`
+}
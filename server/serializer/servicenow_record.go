@@ -2,6 +2,7 @@ package serializer
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -15,6 +16,8 @@ type ServiceNowPartialRecord struct {
 	SysID            string `json:"sys_id"`
 	Number           string `json:"number"`
 	ShortDescription string `json:"short_description"`
+	UpdatedOn        string `json:"sys_updated_on,omitempty"`
+	RecordType       string `json:"record_type,omitempty"`
 }
 
 type ServiceNowRecord struct {
@@ -22,6 +25,7 @@ type ServiceNowRecord struct {
 	Number           string      `json:"number"`
 	ShortDescription string      `json:"short_description"`
 	Description      string      `json:"description"`
+	Text             string      `json:"text,omitempty"`
 	RecordType       string      `json:"record_type,omitempty"`
 	State            string      `json:"state,omitempty"`
 	Priority         string      `json:"priority,omitempty"`
@@ -33,6 +37,21 @@ type ServiceNowRecord struct {
 	Author           interface{} `json:"author,omitempty"`
 }
 
+// CreateRecordFromPostPayload is the request body for creating a record from a Mattermost post.
+// The short description and description are derived from the post and its thread when they are not provided.
+type CreateRecordFromPostPayload struct {
+	PostID           string `json:"post_id"`
+	RecordType       string `json:"record_type"`
+	ShortDescription string `json:"short_description"`
+	Description      string `json:"description"`
+}
+
+type ServiceNowRecordPayload struct {
+	ShortDescription string `json:"short_description"`
+	Description      string `json:"description"`
+	Caller           string `json:"caller_id,omitempty"`
+}
+
 type NestedField struct {
 	DisplayValue string `json:"display_value"`
 	Link         string `json:"link"`
@@ -46,6 +65,10 @@ type ServiceNowRecordResult struct {
 	Result *ServiceNowRecord `json:"result"`
 }
 
+type ServiceNowRecordsResult struct {
+	Result []*ServiceNowRecord `json:"result"`
+}
+
 func (nf *NestedField) LoadFromMap(m map[string]interface{}) error {
 	data, err := json.Marshal(m)
 	if err == nil {
@@ -64,7 +87,30 @@ func ServiceNowRecordFromJSON(data io.Reader) (*ServiceNowRecord, error) {
 	return sr, nil
 }
 
-func (sr *ServiceNowRecord) CreateSharingPost(channelID, botID, serviceNowURL, pluginURL, sharedByUsername string) *model.Post {
+func CreateRecordFromPostPayloadFromJSON(data io.Reader) (*CreateRecordFromPostPayload, error) {
+	var cp *CreateRecordFromPostPayload
+	if err := json.NewDecoder(data).Decode(&cp); err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+func (cp *CreateRecordFromPostPayload) IsValid(recordTypes *RecordTypeRegistry) error {
+	if !model.IsValidId(cp.PostID) {
+		return errors.New(constants.ErrorInvalidPostID)
+	}
+
+	if !recordTypes.SupportsCreation(cp.RecordType) {
+		return errors.New(constants.ErrorRecordTypeNotCreatable)
+	}
+
+	cp.ShortDescription = strings.TrimSpace(cp.ShortDescription)
+	cp.Description = strings.TrimSpace(cp.Description)
+	return nil
+}
+
+func (sr *ServiceNowRecord) CreateSharingPost(channelID, botID, serviceNowURL, pluginURL, sharedByUsername string, recordTypes *RecordTypeRegistry) *model.Post {
 	post := &model.Post{
 		ChannelId: channelID,
 		UserId:    botID,
@@ -114,7 +160,7 @@ func (sr *ServiceNowRecord) CreateSharingPost(channelID, botID, serviceNowURL, p
 	}
 
 	var actions []*model.PostAction
-	if constants.RecordTypesSupportingComments[sr.RecordType] {
+	if recordTypes.SupportsComments(sr.RecordType) {
 		actions = append(actions, &model.PostAction{
 			Type: model.PostActionTypeButton,
 			Name: "Add and view comments",
@@ -128,7 +174,22 @@ func (sr *ServiceNowRecord) CreateSharingPost(channelID, botID, serviceNowURL, p
 		})
 	}
 
-	if constants.RecordTypesSupportingStateUpdation[sr.RecordType] {
+	if recordTypes.SupportsWorkNotes(sr.RecordType) {
+		actions = append(actions, &model.PostAction{
+			Type: model.PostActionTypeButton,
+			Name: "Add work note",
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("%s%s", pluginURL, constants.PathOpenCommentModal),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordType:  sr.RecordType,
+					constants.ContextNameRecordID:    sr.SysID,
+					constants.ContextNameCommentType: constants.CommentEntryTypeWorkNote,
+				},
+			},
+		})
+	}
+
+	if recordTypes.SupportsStateUpdation(sr.RecordType) {
 		actions = append(actions, &model.PostAction{
 			Type: model.PostActionTypeButton,
 			Name: "Update State",
@@ -142,6 +203,43 @@ func (sr *ServiceNowRecord) CreateSharingPost(channelID, botID, serviceNowURL, p
 		})
 	}
 
+	if recordTypes.SupportsAssignment(sr.RecordType) {
+		actions = append(actions, &model.PostAction{
+			Type: model.PostActionTypeButton,
+			Name: "Assign to me",
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("%s%s", pluginURL, constants.PathAssignToMe),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordType: sr.RecordType,
+					constants.ContextNameRecordID:   sr.SysID,
+				},
+			},
+		}, &model.PostAction{
+			Type: model.PostActionTypeButton,
+			Name: "Reassign",
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("%s%s", pluginURL, constants.PathOpenReassignModal),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordType: sr.RecordType,
+					constants.ContextNameRecordID:   sr.SysID,
+				},
+			},
+		})
+	}
+
+	if sr.RecordType == constants.RecordTypeIncident {
+		actions = append(actions, &model.PostAction{
+			Type: model.PostActionTypeButton,
+			Name: "Create war room",
+			Integration: &model.PostActionIntegration{
+				URL: fmt.Sprintf("%s%s", pluginURL, constants.PathCreateWarRoom),
+				Context: map[string]interface{}{
+					constants.ContextNameRecordID: sr.SysID,
+				},
+			},
+		})
+	}
+
 	slackAttachment := &model.SlackAttachment{
 		Title:   fmt.Sprintf("[%s](%s): %s", sr.Number, titleLink, sr.ShortDescription),
 		Fields:  fields,
@@ -216,6 +314,21 @@ func GetNestedFieldValue(field interface{}, fieldType, serviceNowURL string) (st
 	return fmt.Sprintf("[%s](%s)", nf.DisplayValue, url), nil
 }
 
+// GetNestedFieldSysID returns the sys ID of the record referred to by a nested field, or an empty string if the field is empty
+func GetNestedFieldSysID(field interface{}) string {
+	jsonObject, ok := field.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	nf := NestedField{}
+	if err := nf.LoadFromMap(jsonObject); err != nil || nf.Link == "" {
+		return ""
+	}
+
+	return GetSysID(nf.Link)
+}
+
 func GetSysID(link string) string {
 	linkData := strings.Split(link, "/")
 	return linkData[len(linkData)-1]
@@ -0,0 +1,98 @@
+package serializer
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
+)
+
+var searchStateRegex = regexp.MustCompile(`^-?[A-Za-z0-9_]+$`)
+
+// RecordSearchFilters narrows down the records returned while searching for records
+type RecordSearchFilters struct {
+	ActiveOnly    bool
+	AssignedToMe  bool
+	State         string
+	PublishedOnly bool
+
+	// MatchKeywords uses the keyword search of ServiceNow instead of matching the search term as a whole
+	MatchKeywords bool
+}
+
+func (f *RecordSearchFilters) Validate() error {
+	if f.State != "" && !searchStateRegex.MatchString(f.State) {
+		return errors.New(constants.ErrorInvalidSearchState)
+	}
+
+	return nil
+}
+
+// IsSupportedBy checks if the filters can be applied to the records of the given type.
+// The state and assigned to me filters need the fields of the task table.
+func (f *RecordSearchFilters) IsSupportedBy(recordType string) bool {
+	if f.State == "" && !f.AssignedToMe {
+		return true
+	}
+
+	return !constants.RecordTypesWithoutTaskFields[recordType]
+}
+
+// Query returns the encoded query for the filters, which can be appended to another query with "^"
+func (f *RecordSearchFilters) Query() string {
+	if f == nil {
+		return ""
+	}
+
+	var conditions []string
+	if f.ActiveOnly {
+		conditions = append(conditions, constants.FieldActive+"=true")
+	}
+
+	if f.AssignedToMe {
+		conditions = append(conditions, constants.QueryAssignedToMe)
+	}
+
+	if f.State != "" {
+		conditions = append(conditions, constants.FieldState+"="+f.State)
+	}
+
+	if f.PublishedOnly {
+		conditions = append(conditions, constants.FieldWorkflowState+"="+constants.KnowledgeWorkflowStatePublished)
+	}
+
+	return strings.Join(conditions, "^")
+}
+
+// RankSearchResults sorts the records found for the search term by their relevance.
+// Records whose number is the search term come first, followed by the records whose number starts with it,
+// the records whose short description starts with it and then the rest. Records of the same rank are sorted
+// by their last update, most recent first.
+func RankSearchResults(records []*ServiceNowPartialRecord, searchTerm string) {
+	searchTerm = strings.ToLower(searchTerm)
+	rank := func(record *ServiceNowPartialRecord) int {
+		number := strings.ToLower(record.Number)
+		switch {
+		case number == searchTerm:
+			return 0
+		case strings.HasPrefix(number, searchTerm):
+			return 1
+		case strings.HasPrefix(strings.ToLower(record.ShortDescription), searchTerm):
+			return 2
+		default:
+			return 3
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		rankI, rankJ := rank(records[i]), rank(records[j])
+		if rankI != rankJ {
+			return rankI < rankJ
+		}
+
+		// The dates are returned by ServiceNow in the "yyyy-mm-dd hh:mm:ss" format, so they can be compared as strings
+		return records[i].UpdatedOn > records[j].UpdatedOn
+	})
+}
@@ -5,19 +5,34 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/mattermost/mattermost-plugin-servicenow/server/constants"
 )
 
 type ServiceNowState struct {
-	Label string `json:"label"`
-	Value string `json:"value"`
+	Label          string        `json:"label"`
+	Value          string        `json:"value"`
+	RequiredFields []*StateField `json:"required_fields,omitempty"`
 }
 
 type ServiceNowStatesResult struct {
 	Result []*ServiceNowState `json:"result"`
 }
 
+// StateField is a field which needs to be filled for moving a record to a state
+type StateField struct {
+	Name    string              `json:"name"`
+	Label   string              `json:"label"`
+	Type    string              `json:"type"`
+	Options []*ServiceNowChoice `json:"options,omitempty"`
+}
+
 type ServiceNowUpdateStatePayload struct {
-	State string `json:"state"`
+	State          string `json:"state"`
+	CloseCode      string `json:"close_code,omitempty"`
+	CloseNotes     string `json:"close_notes,omitempty"`
+	HoldReason     string `json:"hold_reason,omitempty"`
+	ResolutionCode string `json:"resolution_code,omitempty"`
 }
 
 func ServiceNowStatePayloadFromJSON(data io.Reader) (*ServiceNowUpdateStatePayload, error) {
@@ -35,5 +50,97 @@ func (s *ServiceNowUpdateStatePayload) Validate() error {
 		return fmt.Errorf("state value cannot be empty")
 	}
 
+	s.CloseCode = strings.TrimSpace(s.CloseCode)
+	s.CloseNotes = strings.TrimSpace(s.CloseNotes)
+	s.HoldReason = strings.TrimSpace(s.HoldReason)
+	s.ResolutionCode = strings.TrimSpace(s.ResolutionCode)
 	return nil
 }
+
+// ValidateRequiredFields checks that all the fields required for the state are filled
+// and the values of the fields having options are one of the allowed values
+func (s *ServiceNowUpdateStatePayload) ValidateRequiredFields(fields []*StateField) error {
+	for _, field := range fields {
+		value := s.getFieldValue(field.Name)
+		if value == "" {
+			return fmt.Errorf(constants.ErrorMissingRequiredField, field.Label)
+		}
+
+		// ServiceNow returns no choices for a field whose choices are not configured, so any value is accepted
+		if len(field.Options) == 0 {
+			continue
+		}
+
+		valid := false
+		for _, option := range field.Options {
+			if option.Value == value {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf(constants.ErrorInvalidChoice, field.Label)
+		}
+	}
+
+	return nil
+}
+
+func (s *ServiceNowUpdateStatePayload) getFieldValue(name string) string {
+	switch name {
+	case constants.FieldCloseCode:
+		return s.CloseCode
+	case constants.FieldCloseNotes:
+		return s.CloseNotes
+	case constants.FieldHoldReason:
+		return s.HoldReason
+	case constants.FieldResolutionCode:
+		return s.ResolutionCode
+	}
+
+	return ""
+}
+
+// GetStateRequiredFields returns the fields required for moving a record of the given type to the given state, without their options
+func GetStateRequiredFields(recordType, state string) []*StateField {
+	var fields []*StateField
+	for _, name := range constants.StateRequiredFields[recordType][state] {
+		fields = append(fields, &StateField{
+			Name:  name,
+			Label: constants.StateFieldLabels[name],
+			Type:  constants.StateFieldTypes[name],
+		})
+	}
+
+	return fields
+}
+
+// HasStateModel checks if the states of the record type follow a state model, in which case a record can only be moved to specific states from its current state
+func HasStateModel(recordType string) bool {
+	_, ok := constants.StateTransitions[recordType]
+	return ok
+}
+
+// IsValidStateTransition checks if a record of a type following a state model can be moved from its current state to the given state
+func IsValidStateTransition(recordType, currentState, state string) bool {
+	for _, nextState := range constants.StateTransitions[recordType][currentState] {
+		if nextState == state {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetNextStates filters the states to which a record of a type following a state model can be moved from its current state
+func GetNextStates(states []*ServiceNowState, recordType, currentState string) []*ServiceNowState {
+	nextStates := []*ServiceNowState{}
+	for _, state := range states {
+		if IsValidStateTransition(recordType, currentState, state.Value) {
+			nextStates = append(nextStates, state)
+		}
+	}
+
+	return nextStates
+}
@@ -24,6 +24,19 @@ type SubscriptionPayload struct {
 	SubscriptionEvents *string `json:"subscription_events"`
 	RecordNumber       *string `json:"record_number"`
 	ServerURL          *string `json:"server_url"`
+
+	// Filters and delivery mode are stored in the plugin's KV store and are not sent to ServiceNow
+	Filters      *SubscriptionFilters `json:"filters,omitempty"`
+	DeliveryMode *string              `json:"delivery_mode,omitempty"`
+}
+
+// SubscriptionFilters restricts the records for which a bulk subscription sends notifications
+type SubscriptionFilters struct {
+	AssignmentGroup     string   `json:"assignment_group,omitempty"`
+	AssignmentGroupName string   `json:"assignment_group_name,omitempty"`
+	Priorities          []string `json:"priorities,omitempty"`
+	Category            string   `json:"category,omitempty"`
+	Query               string   `json:"query,omitempty"`
 }
 
 type SubscriptionResponse struct {
@@ -40,15 +53,187 @@ type SubscriptionResponse struct {
 	IsActive           string `json:"is_active"`
 	Number             string `json:"number"`
 	ShortDescription   string `json:"short_description"`
+
+	Filters      *SubscriptionFilters `json:"filters,omitempty"`
+	DeliveryMode string               `json:"delivery_mode,omitempty"`
 }
 
-func (s *SubscriptionResponse) GetFormattedSubscription() string {
+func (s *SubscriptionResponse) GetFormattedSubscription(recordTypes *RecordTypeRegistry) string {
 	subscriptionEvents := GetFormattedSubscriptionEvents(s.SubscriptionEvents)
 
 	if s.Type == constants.SubscriptionTypeRecord {
-		return fmt.Sprintf("\n|%s|%s|%s|%s|%s|%s|%s|", s.SysID, constants.FormattedRecordTypes[s.RecordType], s.Number, s.ShortDescription, subscriptionEvents, s.UserName, s.ChannelName)
+		return fmt.Sprintf("\n|%s|%s|%s|%s|%s|%s|%s|", s.SysID, recordTypes.GetDisplayName(s.RecordType), s.Number, s.ShortDescription, subscriptionEvents, s.UserName, s.ChannelName)
+	}
+
+	if s.Type == constants.SubscriptionTypePersonal {
+		return fmt.Sprintf("\n|%s|%s|%s|", s.SysID, recordTypes.GetDisplayName(s.RecordType), subscriptionEvents)
+	}
+	return fmt.Sprintf("\n|%s|%s|%s|%s|%s|%s|%s|", s.SysID, recordTypes.GetDisplayName(s.RecordType), subscriptionEvents, s.Filters.GetFormattedFilters(), GetFormattedDeliveryMode(s.DeliveryMode), s.UserName, s.ChannelName)
+}
+
+// IsDigest returns true if the notifications of the subscription are posted as digests
+func (s *SubscriptionResponse) IsDigest() bool {
+	return s.DeliveryMode != "" && s.DeliveryMode != constants.DeliveryModeImmediate
+}
+
+func GetFormattedDeliveryMode(deliveryMode string) string {
+	if formattedDeliveryMode, ok := constants.FormattedDeliveryModes[deliveryMode]; ok {
+		return formattedDeliveryMode
+	}
+
+	return constants.FormattedDeliveryModes[constants.DeliveryModeImmediate]
+}
+
+// MatchesEvent checks if the notification event was sent for the subscription to this Mattermost server.
+// The fields of the event are sent by ServiceNow, so the event is trusted only if they match the subscription.
+func (s *SubscriptionResponse) MatchesEvent(event *ServiceNowEvent, siteURL string) error {
+	switch {
+	case s.IsActive != "true":
+		return fmt.Errorf("subscription is not active")
+	case strings.TrimRight(s.ServerURL, "/") != strings.TrimRight(siteURL, "/"):
+		return fmt.Errorf("subscription is for another server")
+	case s.Type != event.SubscriptionType:
+		return fmt.Errorf("subscription type does not match")
+	case s.UserID != event.UserID:
+		return fmt.Errorf("subscriber does not match")
+	case s.ChannelID != event.ChannelID:
+		return fmt.Errorf("channel does not match")
+	case s.RecordType != event.RecordType:
+		return fmt.Errorf("record type does not match")
+	case s.Type == constants.SubscriptionTypeRecord && s.RecordID != event.RecordID:
+		return fmt.Errorf("record does not match")
+	}
+
+	// An event without the event occurred can't be checked against the events of the subscription
+	if event.EventOccurred == "" {
+		return fmt.Errorf("event occurred is missing")
 	}
-	return fmt.Sprintf("\n|%s|%s|%s|%s|%s|", s.SysID, constants.FormattedRecordTypes[s.RecordType], subscriptionEvents, s.UserName, s.ChannelName)
+
+	for _, subscriptionEvent := range strings.Split(s.SubscriptionEvents, ",") {
+		if strings.TrimSpace(subscriptionEvent) == event.EventOccurred {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("event %s is not subscribed", event.EventOccurred)
+}
+
+// IsEmpty returns true if none of the filters are set
+func (f *SubscriptionFilters) IsEmpty() bool {
+	return f == nil || (f.AssignmentGroup == "" && len(f.Priorities) == 0 && f.Category == "" && f.Query == "")
+}
+
+// Equals returns true if both the filters select the same records
+func (f *SubscriptionFilters) Equals(other *SubscriptionFilters) bool {
+	if f.IsEmpty() || other.IsEmpty() {
+		return f.IsEmpty() && other.IsEmpty()
+	}
+
+	if f.AssignmentGroup != other.AssignmentGroup || f.Category != other.Category || f.Query != other.Query || len(f.Priorities) != len(other.Priorities) {
+		return false
+	}
+
+	priorities := make(map[string]bool, len(f.Priorities))
+	for _, priority := range f.Priorities {
+		priorities[strings.TrimSpace(priority)] = true
+	}
+
+	for _, priority := range other.Priorities {
+		if !priorities[strings.TrimSpace(priority)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *SubscriptionFilters) IsValid() error {
+	f.AssignmentGroup = strings.TrimSpace(f.AssignmentGroup)
+	f.Category = strings.TrimSpace(f.Category)
+	f.Query = strings.TrimSpace(f.Query)
+
+	if f.AssignmentGroup != "" {
+		if valid, err := regexp.MatchString(fmt.Sprintf("^%s$", constants.ServiceNowSysIDRegex), f.AssignmentGroup); err != nil || !valid {
+			return fmt.Errorf("assignment group filter is not valid")
+		}
+	}
+
+	for _, priority := range f.Priorities {
+		if !constants.ValidPriorities[strings.TrimSpace(priority)] {
+			return fmt.Errorf("priority filter %s is not valid", priority)
+		}
+	}
+
+	// The category is a single value, so a caret in it would add conditions to the query
+	if strings.Contains(f.Category, "^") {
+		return fmt.Errorf("category filter must not contain \"^\"")
+	}
+
+	if strings.Contains(f.Query, "^NQ") || strings.Contains(f.Query, "ORDERBY") {
+		return fmt.Errorf("query filter must not contain new queries or ordering")
+	}
+
+	// The query is appended to the other filters, so it must not start with an operator which would combine it with them
+	if strings.HasPrefix(f.Query, "^") || strings.HasPrefix(f.Query, "OR") || strings.HasPrefix(f.Query, "NQ") {
+		return fmt.Errorf("query filter must start with a condition")
+	}
+
+	return nil
+}
+
+// ToEncodedQuery combines all the filters into a single ServiceNow encoded query
+func (f *SubscriptionFilters) ToEncodedQuery() string {
+	var conditions []string
+	if f.AssignmentGroup != "" {
+		conditions = append(conditions, fmt.Sprintf("%s=%s", constants.FieldAssignmentGroup, f.AssignmentGroup))
+	}
+
+	if len(f.Priorities) > 0 {
+		priorities := make([]string, 0, len(f.Priorities))
+		for _, priority := range f.Priorities {
+			priorities = append(priorities, strings.TrimSpace(priority))
+		}
+		conditions = append(conditions, fmt.Sprintf("%sIN%s", constants.FieldPriority, strings.Join(priorities, ",")))
+	}
+
+	if f.Category != "" {
+		conditions = append(conditions, fmt.Sprintf("%s=%s", constants.FieldCategory, f.Category))
+	}
+
+	if f.Query != "" {
+		conditions = append(conditions, f.Query)
+	}
+
+	return strings.Join(conditions, "^")
+}
+
+func (f *SubscriptionFilters) GetFormattedFilters() string {
+	if f.IsEmpty() {
+		return "N/A"
+	}
+
+	var filters []string
+	if f.AssignmentGroup != "" {
+		assignmentGroup := f.AssignmentGroupName
+		if assignmentGroup == "" {
+			assignmentGroup = f.AssignmentGroup
+		}
+		filters = append(filters, fmt.Sprintf("Assignment group: %s", assignmentGroup))
+	}
+
+	if len(f.Priorities) > 0 {
+		filters = append(filters, fmt.Sprintf("Priority: %s", strings.Join(f.Priorities, ", ")))
+	}
+
+	if f.Category != "" {
+		filters = append(filters, fmt.Sprintf("Category: %s", f.Category))
+	}
+
+	if f.Query != "" {
+		filters = append(filters, fmt.Sprintf("Query: `%s`", f.Query))
+	}
+
+	return strings.Join(filters, ", ")
 }
 
 type SubscriptionResult struct {
@@ -59,7 +244,7 @@ type SubscriptionsResult struct {
 	Result []*SubscriptionResponse `json:"result"`
 }
 
-func (s *SubscriptionPayload) IsValidForUpdation(siteURL string) error {
+func (s *SubscriptionPayload) IsValidForUpdation(siteURL string, recordTypes *RecordTypeRegistry) error {
 	if s.UserID != nil && !model.IsValidId(*s.UserID) {
 		return fmt.Errorf("userID is not valid")
 	}
@@ -81,7 +266,15 @@ func (s *SubscriptionPayload) IsValidForUpdation(siteURL string) error {
 		s.RecordID = &recordID
 	}
 
-	if s.RecordType != nil && !constants.ValidSubscriptionRecordTypes[*s.RecordType] {
+	if err := s.validateFilters(); err != nil {
+		return err
+	}
+
+	if err := s.validateDeliveryMode(); err != nil {
+		return err
+	}
+
+	if s.RecordType != nil && !recordTypes.IsSubscribable(*s.RecordType) {
 		return fmt.Errorf("recordType is not valid")
 	}
 
@@ -101,7 +294,7 @@ func (s *SubscriptionPayload) IsValidForUpdation(siteURL string) error {
 	return nil
 }
 
-func (s *SubscriptionPayload) IsValidForCreation(siteURL string) error {
+func (s *SubscriptionPayload) IsValidForCreation(siteURL string, recordTypes *RecordTypeRegistry) error {
 	if s.UserID == nil {
 		return fmt.Errorf("userID is required")
 	} else if !model.IsValidId(*s.UserID) {
@@ -133,9 +326,17 @@ func (s *SubscriptionPayload) IsValidForCreation(siteURL string) error {
 		s.RecordID = &recordID
 	}
 
+	if err := s.validateFilters(); err != nil {
+		return err
+	}
+
+	if err := s.validateDeliveryMode(); err != nil {
+		return err
+	}
+
 	if s.RecordType == nil {
 		return fmt.Errorf("recordType is required")
-	} else if !constants.ValidSubscriptionRecordTypes[*s.RecordType] {
+	} else if !recordTypes.IsSubscribable(*s.RecordType) {
 		return fmt.Errorf("recordType is not valid")
 	}
 
@@ -166,6 +367,34 @@ func (s *SubscriptionPayload) IsValidForCreation(siteURL string) error {
 	return nil
 }
 
+func (s *SubscriptionPayload) validateFilters() error {
+	if s.Filters == nil {
+		return nil
+	}
+
+	if *s.Type != constants.SubscriptionTypeBulk && !s.Filters.IsEmpty() {
+		return fmt.Errorf("filters are only supported for bulk subscriptions")
+	}
+
+	return s.Filters.IsValid()
+}
+
+func (s *SubscriptionPayload) validateDeliveryMode() error {
+	if s.DeliveryMode == nil {
+		return nil
+	}
+
+	if !constants.ValidDeliveryModes[*s.DeliveryMode] {
+		return fmt.Errorf("delivery mode is not valid")
+	}
+
+	if *s.Type != constants.SubscriptionTypeBulk && *s.DeliveryMode != constants.DeliveryModeImmediate {
+		return fmt.Errorf("digests are only supported for bulk subscriptions")
+	}
+
+	return nil
+}
+
 func SubscriptionFromJSON(data io.Reader) (*SubscriptionPayload, error) {
 	var sp *SubscriptionPayload
 	if err := json.NewDecoder(data).Decode(&sp); err != nil {
@@ -203,7 +432,7 @@ func (s *SubscriptionResponse) CreateSubscriptionCreatedPost(botID, serviceNowUR
 		postTitle = fmt.Sprintf("%s subscription created for %s [%s](%s)", cases.Title(language.Und).String(s.Type), recordType, s.Number, titleLink)
 	} else {
 		titleLink = fmt.Sprintf(constants.PathRecordList, serviceNowURL, s.RecordType)
-		postTitle = fmt.Sprintf("%s subscription created for [%s](%s)", constants.BulkSubscription, recordType, titleLink)
+		postTitle = fmt.Sprintf("%s subscription created for [%s](%s)", s.getSubscriptionLabel(), recordType, titleLink)
 	}
 
 	slackAttachment := &model.SlackAttachment{
@@ -220,6 +449,20 @@ func (s *SubscriptionResponse) CreateSubscriptionCreatedPost(botID, serviceNowUR
 		},
 	}
 
+	if !s.Filters.IsEmpty() {
+		slackAttachment.Fields = append(slackAttachment.Fields, &model.SlackAttachmentField{
+			Title: "Filter(s)",
+			Value: s.Filters.GetFormattedFilters(),
+		})
+	}
+
+	if s.IsDigest() {
+		slackAttachment.Fields = append(slackAttachment.Fields, &model.SlackAttachmentField{
+			Title: "Delivery",
+			Value: GetFormattedDeliveryMode(s.DeliveryMode),
+		})
+	}
+
 	model.ParseSlackAttachment(post, []*model.SlackAttachment{slackAttachment})
 	return post
 }
@@ -238,7 +481,7 @@ func (s *SubscriptionResponse) CreateSubscriptionEditedPost(botID, serviceNowURL
 		postText = fmt.Sprintf("%s subscription for %s [%s](%s)", cases.Title(language.Und).String(s.Type), recordType, s.Number, textLink)
 	} else {
 		textLink = fmt.Sprintf(constants.PathRecordList, serviceNowURL, s.RecordType)
-		postText = fmt.Sprintf("%s subscription for [%s](%s)", constants.BulkSubscription, recordType, textLink)
+		postText = fmt.Sprintf("%s subscription for [%s](%s)", s.getSubscriptionLabel(), recordType, textLink)
 	}
 
 	slackAttachment := &model.SlackAttachment{
@@ -258,6 +501,29 @@ func (s *SubscriptionResponse) CreateSubscriptionEditedPost(botID, serviceNowURL
 		},
 	}
 
+	if !s.Filters.IsEmpty() {
+		slackAttachment.Fields = append(slackAttachment.Fields, &model.SlackAttachmentField{
+			Title: "Filter(s)",
+			Value: s.Filters.GetFormattedFilters(),
+		})
+	}
+
+	if s.IsDigest() {
+		slackAttachment.Fields = append(slackAttachment.Fields, &model.SlackAttachmentField{
+			Title: "Delivery",
+			Value: GetFormattedDeliveryMode(s.DeliveryMode),
+		})
+	}
+
 	model.ParseSlackAttachment(post, []*model.SlackAttachment{slackAttachment})
 	return post
 }
+
+// getSubscriptionLabel returns the label of a subscription which is not for a single record
+func (s *SubscriptionResponse) getSubscriptionLabel() string {
+	if s.Type == constants.SubscriptionTypePersonal {
+		return constants.PersonalSubscription
+	}
+
+	return constants.BulkSubscription
+}
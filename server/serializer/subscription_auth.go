@@ -1,6 +1,7 @@
 package serializer
 
 type SubscriptionAuthPayload struct {
+	SysID     string `json:"sys_id,omitempty"`
 	ServerURL string `json:"server_url"`
 	APISecret string `json:"api_secret"`
 }
@@ -8,3 +9,10 @@ type SubscriptionAuthPayload struct {
 type SubscriptionAuthDetails struct {
 	Result []*SubscriptionAuthPayload `json:"result"`
 }
+
+// PreviousWebhookSecret is the webhook secret replaced by the last rotation,
+// which is still accepted until the grace period after the rotation has passed
+type PreviousWebhookSecret struct {
+	Secret    string `json:"secret"`
+	RotatedAt int64  `json:"rotated_at"`
+}
@@ -9,6 +9,15 @@ type ServiceNowUser struct {
 	Username string `json:"user_name"`
 }
 
+// ServiceNowGroupMember is the membership of a user in a ServiceNow group
+type ServiceNowGroupMember struct {
+	User string `json:"user"`
+}
+
+type ServiceNowGroupMembersResult struct {
+	Result []*ServiceNowGroupMember `json:"result"`
+}
+
 type User struct {
 	MattermostUserID string
 	OAuth2Token      string
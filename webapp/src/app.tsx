@@ -18,6 +18,7 @@ const GetConfig = (): JSX.Element => {
 
     useEffect(() => {
         makeApiRequest(Constants.pluginApiServiceConfigs.getConfig.apiServiceName);
+        makeApiRequest(Constants.pluginApiServiceConfigs.getRecordTypes.apiServiceName);
         makeApiRequest(Constants.pluginApiServiceConfigs.getConnectedUser.apiServiceName);
     }, []);
 
@@ -137,6 +137,7 @@ const Rhs = (): JSX.Element => {
             subscriptionEvents,
             id: subscription.sys_id,
             userId: subscription.user_id,
+            deliveryMode: subscription.delivery_mode,
         };
         dispatch(setGlobalModalState({modalId: 'editSubscription', data: subscriptionData}));
     }, [dispatch]);
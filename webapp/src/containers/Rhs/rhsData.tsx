@@ -10,6 +10,7 @@ import Spinner from 'src/components/spinner';
 import Constants, {SubscriptionEvents, SubscriptionType, RecordType, SubscriptionTypeLabelMap, SubscriptionEventLabels} from 'src/plugin_constants';
 
 import usePluginApi from 'src/hooks/usePluginApi';
+import useRecordTypes from 'src/hooks/useRecordTypes';
 
 import {setGlobalModalState} from 'src/reducers/globalModal';
 
@@ -51,6 +52,7 @@ const RhsData = ({
 }: RhsDataProps) => {
     const dispatch = useDispatch();
     const {makeApiRequest, getApiState} = usePluginApi();
+    const {getRecordTypeLabel} = useRecordTypes();
     const {currentTeamId} = useSelector((state: GlobalState) => state.entities.teams);
 
     const getChannelState = useCallback(() => {
@@ -82,7 +84,7 @@ const RhsData = ({
 
     const getSubscriptionCardHeader = useCallback((subscription: SubscriptionData): JSX.Element => {
         const isSubscriptionTypeRecord = subscription.type === SubscriptionType.RECORD;
-        const header = isSubscriptionTypeRecord ? subscription.number : BulkSubscriptionHeaders[subscription.record_type as BulkSubscriptionRecordType];
+        const header = isSubscriptionTypeRecord ? subscription.number : (BulkSubscriptionHeaders[subscription.record_type as BulkSubscriptionRecordType] ?? getRecordTypeLabel(subscription.record_type));
         const serviceNowBaseURL = getConfigState().data?.ServiceNowBaseURL;
 
         return (
@@ -100,7 +102,7 @@ const RhsData = ({
                 {isSubscriptionTypeRecord && ` | ${subscription.short_description}`}
             </>
         );
-    }, [getConfigState().data?.ServiceNowBaseURL]);
+    }, [getConfigState().data?.ServiceNowBaseURL, getRecordTypeLabel]);
 
     return (
         <>
@@ -4,7 +4,7 @@ import {useDispatch} from 'react-redux';
 import usePluginApi from 'src/hooks/usePluginApi';
 
 import {resetGlobalModalState} from 'src/reducers/globalModal';
-import {isAddSubscriptionModalOpen} from 'src/selectors';
+import {getGlobalModalState, isAddSubscriptionModalOpen} from 'src/selectors';
 
 import AddOrEditSubscriptionModal from '../subComponents';
 
@@ -16,6 +16,7 @@ const AddSubscriptions = () => {
         <AddOrEditSubscriptionModal
             open={isAddSubscriptionModalOpen(pluginState)}
             close={() => dispatch(resetGlobalModalState())}
+            recordData={getGlobalModalState(pluginState).data as AddSubscriptionModalData | undefined}
         />
     );
 };
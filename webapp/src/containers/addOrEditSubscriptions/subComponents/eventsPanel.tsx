@@ -18,6 +18,7 @@ type EventsPanelProps = {
     subscriptionType: SubscriptionType;
     record: string;
     recordType: RecordType;
+    recordTypeLabel?: string;
 }
 
 const EventsPanel = forwardRef<HTMLDivElement, EventsPanelProps>(({
@@ -33,6 +34,7 @@ const EventsPanel = forwardRef<HTMLDivElement, EventsPanelProps>(({
     subscriptionType,
     record,
     recordType,
+    recordTypeLabel,
 }: EventsPanelProps, eventsPanelRef): JSX.Element => {
     const handleSelectedEventsChange = (selected: boolean, event: SubscriptionEvents) => {
         const filterEvents = (events: SubscriptionEvents[]): SubscriptionEvents[] => (
@@ -58,7 +60,7 @@ const EventsPanel = forwardRef<HTMLDivElement, EventsPanelProps>(({
                         {channel?.label}
                     </p>
                     <h4 className='events-panel__prev-data-header font-14 wt-400 margin-top-15 record-header'>{`Record${subscriptionType === SubscriptionType.BULK ? ' type' : ''}`}</h4>
-                    <p className='events-panel__prev-data-text font-14 wt-400 margin-v-5'>{subscriptionType === SubscriptionType.RECORD ? record : RecordTypeLabelMap[recordType]}</p>
+                    <p className='events-panel__prev-data-text font-14 wt-400 margin-v-5'>{subscriptionType === SubscriptionType.RECORD ? record : recordTypeLabel || RecordTypeLabelMap[recordType]}</p>
                 </div>
                 <label className='events-panel__label font-16 margin-bottom-12 wt-400'>{'Available events:'}</label>
                 {subscriptionType === SubscriptionType.BULK && (
@@ -5,9 +5,10 @@ import Cookies from 'js-cookie';
 
 import {CustomModal as Modal, ModalHeader, ModalLoader, ResultPanel} from '@brightscout/mattermost-ui-library';
 
-import Constants, {PanelDefaultHeights, SubscriptionEvents, SubscriptionType, RecordType} from 'src/plugin_constants';
+import Constants, {PanelDefaultHeights, SubscriptionEvents, SubscriptionType, RecordType, DeliveryMode} from 'src/plugin_constants';
 
 import usePluginApi from 'src/hooks/usePluginApi';
+import useRecordTypes from 'src/hooks/useRecordTypes';
 
 import {setConnected} from 'src/reducers/connectedState';
 import {refetch} from 'src/reducers/refetchState';
@@ -26,9 +27,10 @@ type AddOrEditSubscriptionProps = {
     open: boolean;
     close: () => void;
     subscriptionData?: EditSubscriptionData;
+    recordData?: AddSubscriptionModalData;
 };
 
-const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscriptionProps) => {
+const AddOrEditSubscription = ({open, close, subscriptionData, recordData}: AddOrEditSubscriptionProps) => {
     // Channel panel values
     const [channel, setChannel] = useState<string | null>(null);
     const [channelOptions, setChannelOptions] = useState<DropdownOptionType[]>([]);
@@ -36,6 +38,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
 
     // Subscription type panel values
     const [subscriptionType, setSubscriptionType] = useState<SubscriptionType | null>(null);
+    const [deliveryMode, setDeliveryMode] = useState<DeliveryMode>(DeliveryMode.IMMEDIATE);
 
     // Record panel values
     const [recordValue, setRecordValue] = useState('');
@@ -73,6 +76,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
 
     // usePluginApi hook
     const {makeApiRequest, getApiState} = usePluginApi();
+    const {getRecordTypeOptions, getRecordTypeLabel} = useRecordTypes();
 
     // Create refs to access height of the panels and providing height to modal-dialog
     // We've made all the panels absolute positioned to apply animations and because they are absolute positioned, their parent container, which is modal-dialog, won't expand the same as their heights
@@ -108,6 +112,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
 
             // Set initial values for subscription-type panel
             setSubscriptionType(subscriptionData.type);
+            setDeliveryMode(subscriptionData.deliveryMode ?? DeliveryMode.IMMEDIATE);
 
             // Set initial values for record-type panel
             setRecordType(subscriptionData.recordType);
@@ -119,7 +124,19 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
             // Set initial value for events panel
             setSubscriptionEvents(subscriptionData.subscriptionEvents);
         }
-    }, [open, subscriptionData, currentChannelId]);
+
+        // Prefill the record subscription of the record the modal is opened for
+        if (open && recordData) {
+            if (recordData.channel) {
+                setChannel(recordData.channel);
+            }
+
+            setSubscriptionType(SubscriptionType.RECORD);
+            setRecordType(recordData.recordType);
+            setRecordId(recordData.recordId);
+            setSuggestionChosen(true);
+        }
+    }, [open, subscriptionData, recordData, currentChannelId]);
 
     useEffect(() => {
         const createSubscriptionState = getCreateSubscriptionState();
@@ -155,6 +172,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
     // Reset input field states
     const resetFieldStates = useCallback(() => {
         setSubscriptionType(null);
+        setDeliveryMode(DeliveryMode.IMMEDIATE);
         setRecordValue('');
         setSuggestionChosen(false);
         setRecordType(null);
@@ -259,7 +277,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
 
             setModalDialogHeight(height);
         }
-    }, [subscriptionTypePanelOpen, eventsPanelOpen, searchRecordsPanelOpen, recordTypePanelOpen, apiError, apiResponseValid, suggestionChosen, successPanelOpen]);
+    }, [subscriptionTypePanelOpen, subscriptionType, eventsPanelOpen, searchRecordsPanelOpen, recordTypePanelOpen, apiError, apiResponseValid, suggestionChosen, successPanelOpen]);
 
     // Returns action handler for primary button in the result panel
     const getResultPanelPrimaryBtnActionOrText = useCallback((action: boolean) => {
@@ -300,6 +318,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
             subscription_events: subscriptionEvents.join(','),
             channel_id: channel as string,
             record_number: recordNumber,
+            delivery_mode: subscriptionType === SubscriptionType.BULK ? deliveryMode : undefined,
         };
 
         // Set payload
@@ -325,6 +344,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
             channel_id: channel as string,
             sys_id: subscriptionData?.id as string,
             record_number: recordNumber,
+            delivery_mode: subscriptionType === SubscriptionType.BULK ? deliveryMode : undefined,
         };
 
         // Set payload
@@ -378,6 +398,8 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
                     onBack={() => setSubscriptionTypePanelOpen(false)}
                     subscriptionType={subscriptionType}
                     setSubscriptionType={setSubscriptionType}
+                    deliveryMode={deliveryMode}
+                    setDeliveryMode={setDeliveryMode}
                 />
                 <RecordTypePanel
                     className={`
@@ -393,7 +415,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
                     setRecordType={setRecordType}
                     setResetRecordPanelStates={setResetRecordPanelStates}
                     showFooter={true}
-                    recordTypeOptions={Constants.recordTypeOptions}
+                    recordTypeOptions={getRecordTypeOptions('subscribe')}
                 />
                 <SearchRecordsPanel
                     className={`
@@ -434,6 +456,7 @@ const AddOrEditSubscription = ({open, close, subscriptionData}: AddOrEditSubscri
                     subscriptionType={subscriptionType as SubscriptionType}
                     record={recordValue}
                     recordType={recordType as RecordType}
+                    recordTypeLabel={recordType ? getRecordTypeLabel(recordType) : ''}
                     continueBtnDisabled={showModalLoader || !subscriptionEvents.length}
                     backBtnDisabled={showModalLoader}
                 />
@@ -4,13 +4,14 @@ import {shallow, ShallowWrapper} from 'enzyme';
 
 import {ModalSubtitleAndError} from '@brightscout/mattermost-ui-library';
 
-import {SubscriptionType} from 'src/plugin_constants';
+import {DeliveryMode, SubscriptionType} from 'src/plugin_constants';
 
 import SubscriptionTypePanel from './subscriptionTypePanel';
 
 const mockOnContinue = jest.fn();
 const mockOnBack = jest.fn();
 const mockSetSubscriptionType = jest.fn();
+const mockSetDeliveryMode = jest.fn();
 
 const subscriptionTypePanelProps = {
     className: 'mockClassName',
@@ -20,6 +21,8 @@ const subscriptionTypePanelProps = {
     requiredFieldValidationErr: true,
     subscriptionType: SubscriptionType.RECORD,
     setSubscriptionType: mockSetSubscriptionType,
+    deliveryMode: DeliveryMode.IMMEDIATE,
+    setDeliveryMode: mockSetDeliveryMode,
 };
 
 describe('Subscription Type Panel', () => {
@@ -47,6 +50,17 @@ describe('Subscription Type Panel', () => {
         expect(component.find('ModalFooter')).toHaveLength(1);
     });
 
+    it('Should render the delivery mode dropdown only for bulk subscriptions', () => {
+        const bulkSubscriptionComponent = shallow(
+            <SubscriptionTypePanel
+                {...subscriptionTypePanelProps}
+                subscriptionType={SubscriptionType.BULK}
+            />);
+
+        expect(bulkSubscriptionComponent.find('Dropdown')).toHaveLength(2);
+        expect(component.find('Dropdown')).toHaveLength(1);
+    });
+
     it('Should render the error correctly', () => {
         expect(component.contains(
             <ModalSubtitleAndError error='mockError'/>,
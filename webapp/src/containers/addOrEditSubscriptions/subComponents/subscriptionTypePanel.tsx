@@ -2,7 +2,7 @@ import React, {forwardRef, useState, useEffect} from 'react';
 
 import {ModalSubtitleAndError, ModalFooter, Dropdown} from '@brightscout/mattermost-ui-library';
 
-import Constants, {SubscriptionType, SubscriptionTypeLabelMap} from 'src/plugin_constants';
+import Constants, {DeliveryMode, DeliveryModeLabelMap, SubscriptionType, SubscriptionTypeLabelMap} from 'src/plugin_constants';
 
 type SubscriptionTypePanelProps = {
     className?: string;
@@ -13,6 +13,8 @@ type SubscriptionTypePanelProps = {
     requiredFieldValidationErr?: boolean;
     subscriptionType: SubscriptionType | null;
     setSubscriptionType: (value: SubscriptionType) => void;
+    deliveryMode: DeliveryMode;
+    setDeliveryMode: (value: DeliveryMode) => void;
 }
 
 const subscriptionTypeOptions: DropdownOptionType[] = [
@@ -26,6 +28,11 @@ const subscriptionTypeOptions: DropdownOptionType[] = [
     },
 ];
 
+const deliveryModeOptions: DropdownOptionType[] = Object.values(DeliveryMode).map((deliveryMode) => ({
+    label: DeliveryModeLabelMap[deliveryMode],
+    value: deliveryMode,
+}));
+
 const SubscriptionTypePanel = forwardRef<HTMLDivElement, SubscriptionTypePanelProps>(({
     className,
     error,
@@ -34,6 +41,8 @@ const SubscriptionTypePanel = forwardRef<HTMLDivElement, SubscriptionTypePanelPr
     actionBtnDisabled,
     subscriptionType,
     setSubscriptionType,
+    deliveryMode,
+    setDeliveryMode,
 }: SubscriptionTypePanelProps, subscriptionTypePanelRef): JSX.Element => {
     const [validationFailed, setValidationFailed] = useState(false);
 
@@ -70,6 +79,17 @@ const SubscriptionTypePanel = forwardRef<HTMLDivElement, SubscriptionTypePanelPr
                     required={true}
                     error={validationFailed && Constants.RequiredMsg}
                 />
+                {/* The notifications of bulk subscriptions can be grouped in digests */}
+                {subscriptionType === SubscriptionType.BULK && (
+                    <div className='subscription-type-panel__delivery-mode'>
+                        <Dropdown
+                            placeholder='Select Delivery Mode'
+                            value={deliveryMode}
+                            onChange={(newValue) => setDeliveryMode(newValue as DeliveryMode)}
+                            options={deliveryModeOptions}
+                        />
+                    </div>
+                )}
                 <ModalSubtitleAndError error={error}/>
             </div>
             <ModalFooter
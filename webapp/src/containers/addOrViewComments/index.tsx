@@ -42,19 +42,28 @@ const AddOrViewComments = () => {
         resetFieldStates();
     }, []);
 
+    const isWorkNote = () => Boolean((getGlobalModalState(pluginState).data as CommentAndStateModalData)?.isWorkNote);
+
     const getCommentsPayload = (): CommentsPayload => {
         const data = getGlobalModalState(pluginState).data as CommentAndStateModalData;
-        return {
+        const payload: CommentsPayload = {
             record_type: data?.recordType || '',
             record_id: data?.recordId || '',
-            comments,
         };
+
+        if (data?.isWorkNote) {
+            payload.work_notes = comments;
+        } else {
+            payload.comments = comments;
+        }
+
+        return payload;
     };
 
     const getCommentsState = () => {
         const payload = getCommentsPayload();
         const {isLoading, isSuccess, isError, data, error: apiErr} = getApiState(Constants.pluginApiServiceConfigs.getComments.apiServiceName, payload);
-        return {isLoading, isSuccess, isError, data: data as string, error: apiErr};
+        return {isLoading, isSuccess, isError, data: data as CommentsData, error: apiErr};
     };
 
     const addCommentState = () => {
@@ -83,7 +92,7 @@ const AddOrViewComments = () => {
         const {isLoading, isSuccess, error, data, isError} = getCommentsState();
         if (isSuccess) {
             setShowErrorPanel(false);
-            setCommentsData(data);
+            setCommentsData(Utils.getFormattedComments(data));
         }
 
         if (isError && error) {
@@ -136,7 +145,7 @@ const AddOrViewComments = () => {
         >
             <>
                 <ModalHeader
-                    title='Add comments'
+                    title={isWorkNote() ? 'Add work note' : 'Add comments'}
                     onHide={hideModal}
                     showCloseIconInHeader={true}
                 />
@@ -159,7 +168,7 @@ const AddOrViewComments = () => {
                                     ${((!commentsData.length || apiError) && !showModalLoader) && 'comment-body__height'}`}
                         >
                             <TextArea
-                                placeholder='Write new comment here'
+                                placeholder={isWorkNote() ? 'Write new work note here' : 'Write new comment here'}
                                 value={comments}
                                 onChange={onChangeHandle}
                                 className='comment-body__text-area'
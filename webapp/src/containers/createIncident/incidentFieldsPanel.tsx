@@ -0,0 +1,184 @@
+import React, {useCallback, useEffect, useState} from 'react';
+
+import {AutoSuggest, Dropdown} from '@brightscout/mattermost-ui-library';
+
+import usePluginApi from 'src/hooks/usePluginApi';
+
+import Constants from 'src/plugin_constants';
+
+import Utils from 'src/utils';
+
+export type IncidentFieldValues = Pick<IncidentPayload, 'urgency' | 'impact' | 'category' | 'subcategory' | 'assignment_group' | 'cmdb_ci'>;
+
+type IncidentFieldsPanelProps = {
+    open: boolean;
+    fieldValues: IncidentFieldValues;
+    setFieldValues: React.Dispatch<React.SetStateAction<IncidentFieldValues>>;
+    setApiError: (error: APIError | null) => void;
+    showModalLoader: boolean;
+}
+
+type ReferenceSearchConfig = {
+    apiServiceName: ApiServiceName;
+    field: 'assignment_group' | 'cmdb_ci';
+    placeholder: string;
+}
+
+const referenceSearchConfigs: ReferenceSearchConfig[] = [
+    {
+        apiServiceName: Constants.pluginApiServiceConfigs.searchAssignmentGroups.apiServiceName,
+        field: 'assignment_group',
+        placeholder: 'Search assignment group',
+    },
+    {
+        apiServiceName: Constants.pluginApiServiceConfigs.searchConfigurationItems.apiServiceName,
+        field: 'cmdb_ci',
+        placeholder: 'Search configuration item',
+    },
+];
+
+const choiceFieldPlaceholders: Record<IncidentFieldWithChoices, string> = {
+    urgency: 'Urgency',
+    impact: 'Impact',
+    category: 'Category',
+    subcategory: 'Subcategory',
+};
+
+// Shows the optional fields of the incident whose values are loaded from ServiceNow
+const IncidentFieldsPanel = ({
+    open,
+    fieldValues,
+    setFieldValues,
+    setApiError,
+    showModalLoader,
+}: IncidentFieldsPanelProps): JSX.Element => {
+    const [choicesParams, setChoicesParams] = useState<Record<IncidentFieldWithChoices, GetIncidentFieldChoicesParams | null>>({
+        urgency: null,
+        impact: null,
+        category: null,
+        subcategory: null,
+    });
+    const [referenceSearchParams, setReferenceSearchParams] = useState<Record<ReferenceSearchConfig['field'], SearchReferenceParams | null>>({
+        assignment_group: null,
+        cmdb_ci: null,
+    });
+    const [referenceInputValues, setReferenceInputValues] = useState<Record<ReferenceSearchConfig['field'], string>>({
+        assignment_group: '',
+        cmdb_ci: '',
+    });
+
+    // usePluginApi hook
+    const {makeApiRequest, getApiState} = usePluginApi();
+
+    const getChoices = useCallback((params: GetIncidentFieldChoicesParams) => {
+        setChoicesParams((prev) => ({...prev, [params.field]: params}));
+        makeApiRequest(Constants.pluginApiServiceConfigs.getIncidentFieldChoices.apiServiceName, params);
+    }, []);
+
+    const getChoicesState = (field: IncidentFieldWithChoices) => {
+        const {isLoading, isError, data, error} = getApiState(Constants.pluginApiServiceConfigs.getIncidentFieldChoices.apiServiceName, choicesParams[field] as GetIncidentFieldChoicesParams);
+        return {isLoading, isError, data: data as ServiceNowChoice[] | undefined, error};
+    };
+
+    const getReferenceSearchState = ({apiServiceName, field}: ReferenceSearchConfig) => {
+        const {isLoading, isError, data, error} = getApiState(apiServiceName, referenceSearchParams[field] as SearchReferenceParams);
+        return {isLoading, isError, data: data as ServiceNowReference[] | undefined, error};
+    };
+
+    const searchReferences = useCallback(({apiServiceName, field, searchFor}: Record<string, string>) => {
+        const params: SearchReferenceParams = {search: searchFor};
+        setReferenceSearchParams((prev) => ({...prev, [field]: params}));
+        makeApiRequest(apiServiceName, params);
+    }, []);
+
+    const debouncedSearchReferences = useCallback(Utils.debounce(searchReferences, 500), [searchReferences]);
+
+    const handleChoiceChange = (field: IncidentFieldWithChoices, value: string) => {
+        if (field === 'category') {
+            // The subcategories depend on the category
+            setFieldValues((prev) => ({...prev, category: value, subcategory: undefined}));
+            getChoices({field: 'subcategory', dependentValue: value});
+            return;
+        }
+
+        setFieldValues((prev) => ({...prev, [field]: value}));
+    };
+
+    const handleReferenceInputChange = ({apiServiceName, field}: ReferenceSearchConfig, currentValue: string) => {
+        setReferenceInputValues((prev) => ({...prev, [field]: currentValue}));
+        setFieldValues((prev) => ({...prev, [field]: undefined}));
+        if (currentValue.length >= Constants.DefaultCharThresholdToShowSuggestions) {
+            debouncedSearchReferences({apiServiceName, field, searchFor: currentValue});
+        }
+    };
+
+    const handleReferenceSelection = ({field}: ReferenceSearchConfig, suggestion: Record<string, string> | null) => {
+        setReferenceInputValues((prev) => ({...prev, [field]: suggestion?.name || ''}));
+        setFieldValues((prev) => ({...prev, [field]: suggestion?.sys_id || undefined}));
+    };
+
+    // Load the choices of the fields which don't depend on another field when the modal is opened
+    useEffect(() => {
+        if (!open) {
+            setReferenceInputValues({assignment_group: '', cmdb_ci: ''});
+            return;
+        }
+
+        getChoices({field: 'urgency'});
+        getChoices({field: 'impact'});
+        getChoices({field: 'category'});
+    }, [open]);
+
+    const choicesError = (Object.keys(choiceFieldPlaceholders) as IncidentFieldWithChoices[]).map((field) => getChoicesState(field).error).find(Boolean);
+    const referencesError = referenceSearchConfigs.map((config) => getReferenceSearchState(config).error).find(Boolean);
+    useEffect(() => {
+        const error = choicesError || referencesError;
+        if (error) {
+            setApiError(error);
+        }
+    }, [choicesError, referencesError]);
+
+    return (
+        <>
+            {(Object.keys(choiceFieldPlaceholders) as IncidentFieldWithChoices[]).map((field) => {
+                const {isLoading, data} = getChoicesState(field);
+                return (
+                    <div
+                        key={field}
+                        className='incident-body__dropdown'
+                    >
+                        <Dropdown
+                            placeholder={choiceFieldPlaceholders[field]}
+                            value={fieldValues[field] ?? null}
+                            onChange={(newValue) => handleChoiceChange(field, newValue as string)}
+                            options={(data ?? []).map((choice) => ({label: choice.label, value: choice.value}))}
+                            disabled={showModalLoader || isLoading || (field === 'subcategory' && !fieldValues.category)}
+                        />
+                    </div>
+                );
+            })}
+            {referenceSearchConfigs.map((config) => (
+                <div
+                    key={config.field}
+                    className='incident-body__auto-suggest'
+                >
+                    <AutoSuggest
+                        placeholder={config.placeholder}
+                        inputValue={referenceInputValues[config.field]}
+                        onInputValueChange={(currentValue: string) => handleReferenceInputChange(config, currentValue)}
+                        onChangeSelectedSuggestion={(suggestion: Record<string, string> | null) => handleReferenceSelection(config, suggestion)}
+                        disabled={showModalLoader}
+                        loadingSuggestions={getReferenceSearchState(config).isLoading}
+                        suggestionConfig={{
+                            suggestions: (getReferenceSearchState(config).data ?? []).map((reference) => ({sys_id: reference.sys_id, name: reference.name})),
+                            renderValue: (suggestion) => suggestion.name,
+                        }}
+                        charThresholdToShowSuggestions={Constants.DefaultCharThresholdToShowSuggestions}
+                    />
+                </div>
+            ))}
+        </>
+    );
+};
+
+export default IncidentFieldsPanel;
@@ -17,6 +17,7 @@ import {getGlobalModalState, isCreateIncidentModalOpen} from 'src/selectors';
 import Utils from 'src/utils';
 
 import CallerPanel from './callerPanel';
+import IncidentFieldsPanel, {IncidentFieldValues} from './incidentFieldsPanel';
 import SubscribeNewIncident from './subscribeToNewIncident';
 
 import './styles.scss';
@@ -25,6 +26,7 @@ const CreateIncident = () => {
     const [shortDescription, setShortDescription] = useState<string>('');
     const [description, setDescription] = useState<string>('');
     const [caller, setCaller] = useState<string | null>(null);
+    const [incidentFieldValues, setIncidentFieldValues] = useState<IncidentFieldValues>({});
     const [channel, setChannel] = useState<string | null>(null);
     const [channelOptions, setChannelOptions] = useState<DropdownOptionType[]>([]);
     const [showResultPanel, setShowResultPanel] = useState(false);
@@ -56,6 +58,7 @@ const CreateIncident = () => {
         setShortDescription('');
         setDescription('');
         setCaller(null);
+        setIncidentFieldValues({});
         setChannelOptions([]);
         setApiError(null);
         setValidationError(null);
@@ -110,10 +113,10 @@ const CreateIncident = () => {
             return;
         }
 
-        // Set the first impact and urgency values by default.
         const payload: IncidentPayload = {
             short_description: shortDescription,
             description,
+            ...incidentFieldValues,
             caller_id: caller ?? '',
             channel_id: channel ?? currentChannelId,
         };
@@ -248,6 +251,13 @@ const CreateIncident = () => {
                                 showModalLoader={showModalLoader}
                                 className={`incident-body__auto-suggest ${caller ? 'incident-body__suggestion-chosen' : ''}`}
                             />
+                            <IncidentFieldsPanel
+                                open={open}
+                                fieldValues={incidentFieldValues}
+                                setFieldValues={setIncidentFieldValues}
+                                setApiError={setApiError}
+                                showModalLoader={showModalLoader}
+                            />
                             <SubscribeNewIncident
                                 subscriptionPayload={subscriptionPayload}
                                 channel={channel}
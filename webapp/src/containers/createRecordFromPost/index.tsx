@@ -0,0 +1,133 @@
+import React, {useCallback, useEffect, useState} from 'react';
+import {useDispatch, useSelector} from 'react-redux';
+
+import {CircularLoader, CustomModal as Modal, ModalFooter, ModalHeader, ResultPanel} from '@brightscout/mattermost-ui-library';
+
+import RecordTypePanel from 'src/containers/addOrEditSubscriptions/subComponents/recordTypePanel';
+
+import usePluginApi from 'src/hooks/usePluginApi';
+import useRecordTypes from 'src/hooks/useRecordTypes';
+
+import Constants from 'src/plugin_constants';
+
+import {setConnected} from 'src/reducers/connectedState';
+import {resetGlobalModalState} from 'src/reducers/globalModal';
+import {getGlobalModalState, isCreateRecordFromPostModalOpen} from 'src/selectors';
+
+import Utils from 'src/utils';
+
+const CreateRecordFromPost = () => {
+    const [recordType, setRecordType] = useState<RecordType | null>(null);
+    const [createRecordPayload, setCreateRecordPayload] = useState<CreateRecordFromPostPayload | null>(null);
+    const [showResultPanel, setShowResultPanel] = useState(false);
+    const [validationFailed, setValidationFailed] = useState(false);
+    const siteUrl = useSelector(Utils.getSiteUrl);
+
+    // usePluginApi hook
+    const {pluginState, makeApiRequest, getApiState} = usePluginApi();
+    const {getRecordTypeOptions} = useRecordTypes();
+    const open = isCreateRecordFromPostModalOpen(pluginState);
+
+    // API error
+    const [apiError, setApiError] = useState<APIError | null>(null);
+
+    const dispatch = useDispatch();
+
+    const resetStates = useCallback(() => {
+        setRecordType(null);
+        setCreateRecordPayload(null);
+        setShowResultPanel(false);
+        setValidationFailed(false);
+        setApiError(null);
+    }, []);
+
+    const hideModal = useCallback(() => {
+        dispatch(resetGlobalModalState());
+        resetStates();
+    }, []);
+
+    const getStateForCreateRecordAPI = () => {
+        const {isLoading, isSuccess, isError, error: apiErr} = getApiState(Constants.pluginApiServiceConfigs.createRecordFromPost.apiServiceName, createRecordPayload as CreateRecordFromPostPayload);
+        return {isLoading, isSuccess, isError, error: apiErr};
+    };
+
+    const createRecord = () => {
+        if (!recordType) {
+            setValidationFailed(true);
+            return;
+        }
+
+        const data = getGlobalModalState(pluginState).data as CreateRecordFromPostModalData;
+        if (data) {
+            const payload: CreateRecordFromPostPayload = {post_id: data.postId, record_type: recordType};
+            setCreateRecordPayload(payload);
+            makeApiRequest(Constants.pluginApiServiceConfigs.createRecordFromPost.apiServiceName, payload);
+        }
+    };
+
+    useEffect(() => {
+        const {isError, isSuccess, error} = getStateForCreateRecordAPI();
+        if (isError && error) {
+            if (error.id === Constants.ApiErrorIdNotConnected || error.id === Constants.ApiErrorIdRefreshTokenExpired) {
+                dispatch(setConnected(false));
+            }
+
+            setApiError(error);
+            setShowResultPanel(true);
+        }
+
+        if (isSuccess) {
+            setApiError(null);
+            setShowResultPanel(true);
+        }
+    }, [getStateForCreateRecordAPI().isError, getStateForCreateRecordAPI().isSuccess]);
+
+    const {isLoading: creatingRecord} = getStateForCreateRecordAPI();
+    return (
+        <Modal
+            show={open}
+            onHide={hideModal}
+            className='servicenow-modal'
+        >
+            <>
+                <ModalHeader
+                    title='Create a record'
+                    onHide={hideModal}
+                    showCloseIconInHeader={true}
+                />
+                {creatingRecord && <CircularLoader/>}
+                {showResultPanel ? (
+                    <ResultPanel
+                        className='wizard__secondary-panel--slide-in result-panel'
+                        header={Utils.getResultPanelHeader(apiError, hideModal, siteUrl, Constants.RecordCreatedMsg)}
+                        primaryBtn={{
+                            text: 'Close',
+                            onClick: hideModal,
+                        }}
+                        iconClass={apiError && 'fa-times-circle-o result-panel-icon--error'}
+                    />
+                ) : (
+                    <>
+                        <RecordTypePanel
+                            recordType={recordType}
+                            setRecordType={setRecordType}
+                            setResetRecordPanelStates={() => setValidationFailed(false)}
+                            placeholder='Record Type'
+                            recordTypeOptions={getRecordTypeOptions('create')}
+                            error={validationFailed ? Constants.RequiredMsg : ''}
+                        />
+                        <ModalFooter
+                            onConfirm={createRecord}
+                            confirmBtnText='Create'
+                            confirmDisabled={creatingRecord}
+                            onHide={hideModal}
+                            cancelDisabled={creatingRecord}
+                        />
+                    </>
+                )}
+            </>
+        </Modal>
+    );
+};
+
+export default CreateRecordFromPost;
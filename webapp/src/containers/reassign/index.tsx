@@ -0,0 +1,196 @@
+import React, {useCallback, useEffect, useState} from 'react';
+import {useDispatch, useSelector} from 'react-redux';
+
+import {AutoSuggest, CircularLoader, CustomModal as Modal, ModalFooter, ModalHeader, ResultPanel} from '@brightscout/mattermost-ui-library';
+
+import CallerPanel from 'src/containers/createIncident/callerPanel';
+
+import usePluginApi from 'src/hooks/usePluginApi';
+
+import Constants from 'src/plugin_constants';
+
+import {setConnected} from 'src/reducers/connectedState';
+import {resetGlobalModalState} from 'src/reducers/globalModal';
+import {getGlobalModalState, isReassignModalOpen} from 'src/selectors';
+
+import Utils from 'src/utils';
+
+const Reassign = () => {
+    const [assignedTo, setAssignedTo] = useState<string | null>(null);
+    const [assignmentGroup, setAssignmentGroup] = useState<string | null>(null);
+    const [groupAutoSuggestValue, setGroupAutoSuggestValue] = useState('');
+    const [groupSuggestions, setGroupSuggestions] = useState<Record<string, string>[]>([]);
+    const [searchGroupsParams, setSearchGroupsParams] = useState<SearchReferenceParams | null>(null);
+    const [reassignPayload, setReassignPayload] = useState<ReassignPayload | null>(null);
+    const [showResultPanel, setShowResultPanel] = useState(false);
+    const siteUrl = useSelector(Utils.getSiteUrl);
+
+    // usePluginApi hook
+    const {pluginState, makeApiRequest, getApiState} = usePluginApi();
+
+    // API error
+    const [apiError, setApiError] = useState<APIError | null>(null);
+
+    const dispatch = useDispatch();
+
+    const resetStates = useCallback(() => {
+        setAssignedTo(null);
+        setAssignmentGroup(null);
+        setGroupAutoSuggestValue('');
+        setGroupSuggestions([]);
+        setSearchGroupsParams(null);
+        setReassignPayload(null);
+        setApiError(null);
+        setShowResultPanel(false);
+    }, []);
+
+    const hideModal = useCallback(() => {
+        dispatch(resetGlobalModalState());
+        resetStates();
+    }, []);
+
+    const getStateForSearchGroupsAPI = () => {
+        const {isLoading, isSuccess, isError, data, error: apiErr} = getApiState(Constants.pluginApiServiceConfigs.searchAssignmentGroups.apiServiceName, searchGroupsParams as SearchReferenceParams);
+        return {isLoading, isSuccess, isError, data: data as ServiceNowReference[], error: apiErr};
+    };
+
+    const getStateForUpdateAssignmentAPI = () => {
+        const {isLoading, isSuccess, isError, error: apiErr} = getApiState(Constants.pluginApiServiceConfigs.updateAssignment.apiServiceName, reassignPayload as ReassignPayload);
+        return {isLoading, isSuccess, isError, error: apiErr};
+    };
+
+    const searchGroups = useCallback(({searchFor}: {searchFor?: string}) => {
+        const params: SearchReferenceParams = {search: searchFor || ''};
+        setSearchGroupsParams(params);
+        makeApiRequest(Constants.pluginApiServiceConfigs.searchAssignmentGroups.apiServiceName, params);
+    }, []);
+
+    const debouncedSearchGroups = useCallback(Utils.debounce(searchGroups, 500), [searchGroups]);
+
+    const handleGroupInputChange = (currentValue: string) => {
+        setGroupAutoSuggestValue(currentValue);
+        setAssignmentGroup(null);
+        if (currentValue.length >= Constants.DefaultCharThresholdToShowSuggestions) {
+            debouncedSearchGroups({searchFor: currentValue});
+        }
+    };
+
+    const handleGroupSelection = (groupSuggestion: Record<string, string> | null) => {
+        setGroupAutoSuggestValue(groupSuggestion?.name || '');
+        setAssignmentGroup(groupSuggestion?.sys_id || null);
+    };
+
+    const reassign = () => {
+        const data = getGlobalModalState(pluginState).data as CommentAndStateModalData;
+        if (data) {
+            const {recordType, recordId} = data;
+            const payload: ReassignPayload = {recordType, recordId};
+            if (assignedTo) {
+                payload.assigned_to = assignedTo;
+            }
+
+            if (assignmentGroup) {
+                payload.assignment_group = assignmentGroup;
+            }
+
+            setReassignPayload(payload);
+            makeApiRequest(Constants.pluginApiServiceConfigs.updateAssignment.apiServiceName, payload);
+        }
+    };
+
+    useEffect(() => {
+        const {isError, isSuccess, data, error} = getStateForSearchGroupsAPI();
+        if (isError && error) {
+            setApiError(error);
+        }
+
+        if (isSuccess) {
+            setGroupSuggestions(data.map((group) => ({sys_id: group.sys_id, name: group.name})));
+        }
+    }, [getStateForSearchGroupsAPI().isError, getStateForSearchGroupsAPI().isSuccess]);
+
+    useEffect(() => {
+        const {isError, isSuccess, error} = getStateForUpdateAssignmentAPI();
+        if (isError && error) {
+            if (error.id === Constants.ApiErrorIdNotConnected || error.id === Constants.ApiErrorIdRefreshTokenExpired) {
+                dispatch(setConnected(false));
+            }
+
+            setApiError(error);
+            setShowResultPanel(true);
+        }
+
+        if (isSuccess) {
+            setApiError(null);
+            setShowResultPanel(true);
+        }
+    }, [getStateForUpdateAssignmentAPI().isError, getStateForUpdateAssignmentAPI().isSuccess]);
+
+    const {isLoading: groupsLoading} = getStateForSearchGroupsAPI();
+    const {isLoading: reassigning} = getStateForUpdateAssignmentAPI();
+    return (
+        <Modal
+            show={isReassignModalOpen(pluginState)}
+            onHide={hideModal}
+            className='servicenow-modal'
+        >
+            <>
+                <ModalHeader
+                    title='Reassign'
+                    onHide={hideModal}
+                    showCloseIconInHeader={true}
+                />
+                {reassigning && <CircularLoader/>}
+                {showResultPanel ? (
+                    <ResultPanel
+                        className='wizard__secondary-panel--slide-in result-panel'
+                        header={Utils.getResultPanelHeader(apiError, hideModal, siteUrl, Constants.RecordReassignedMsg)}
+                        primaryBtn={{
+                            text: 'Close',
+                            onClick: hideModal,
+                        }}
+                        iconClass={apiError && 'fa-times-circle-o result-panel-icon--error'}
+                    />
+                ) : (
+                    <>
+                        <div className='padding-v-20 wizard__body-container'>
+                            {isReassignModalOpen(pluginState) && (
+                                <CallerPanel
+                                    caller={assignedTo}
+                                    setCaller={setAssignedTo}
+                                    showModalLoader={reassigning}
+                                    setApiError={setApiError}
+                                    placeholder='Select user'
+                                />
+                            )}
+                            <div className='padding-h-12 padding-top-10'>
+                                <AutoSuggest
+                                    placeholder='Search assignment group'
+                                    inputValue={groupAutoSuggestValue}
+                                    onInputValueChange={handleGroupInputChange}
+                                    onChangeSelectedSuggestion={handleGroupSelection}
+                                    disabled={reassigning}
+                                    loadingSuggestions={groupsLoading}
+                                    suggestionConfig={{
+                                        suggestions: groupSuggestions,
+                                        renderValue: (suggestion) => suggestion.name,
+                                    }}
+                                    charThresholdToShowSuggestions={Constants.DefaultCharThresholdToShowSuggestions}
+                                />
+                            </div>
+                        </div>
+                        <ModalFooter
+                            onConfirm={reassign}
+                            confirmBtnText='Reassign'
+                            confirmDisabled={reassigning || (!assignedTo && !assignmentGroup)}
+                            onHide={hideModal}
+                            cancelDisabled={reassigning}
+                        />
+                    </>
+                )}
+            </>
+        </Modal>
+    );
+};
+
+export default Reassign;
@@ -6,6 +6,7 @@ import {GlobalState} from 'mattermost-webapp/types/store';
 import {CustomModal as Modal, ModalFooter, ModalHeader, ModalLoader, ResultPanel} from '@brightscout/mattermost-ui-library';
 
 import usePluginApi from 'src/hooks/usePluginApi';
+import useRecordTypes from 'src/hooks/useRecordTypes';
 
 import Constants from 'src/plugin_constants';
 
@@ -43,6 +44,7 @@ const ShareRecords = () => {
 
     // usePluginApi hook
     const {pluginState, makeApiRequest, getApiState} = usePluginApi();
+    const {getRecordTypeOptions} = useRecordTypes();
 
     const dispatch = useDispatch();
 
@@ -161,7 +163,7 @@ const ShareRecords = () => {
                             setRecordType={setRecordType}
                             setResetRecordPanelStates={setResetRecordPanelStates}
                             placeholder='Record Type'
-                            recordTypeOptions={Constants.shareRecordTypeOptions}
+                            recordTypeOptions={getRecordTypeOptions('share')}
                         />
                         <SearchRecordsPanel
                             recordValue={recordValue}
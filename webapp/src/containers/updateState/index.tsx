@@ -1,7 +1,7 @@
 import React, {useCallback, useEffect, useState} from 'react';
 import {useDispatch, useSelector} from 'react-redux';
 
-import {CircularLoader, CustomModal as Modal, Dropdown, ModalFooter, ModalHeader, ResultPanel} from '@brightscout/mattermost-ui-library';
+import {CircularLoader, CustomModal as Modal, Dropdown, ModalFooter, ModalHeader, ResultPanel, TextArea} from '@brightscout/mattermost-ui-library';
 
 import usePluginApi from 'src/hooks/usePluginApi';
 
@@ -15,6 +15,7 @@ import Utils from 'src/utils';
 
 const UpdateState = () => {
     const [selectedState, setSelectedState] = useState<string | null>(null);
+    const [requiredFieldValues, setRequiredFieldValues] = useState<Record<string, string>>({});
     const [getRecordParams, setGetRecordParams] = useState<GetRecordParams | null>(null);
     const [getStatesParams, setGetStatesParams] = useState<GetStatesParams | null>(null);
     const [updateStatePayload, setUpdateStatePayload] = useState<UpdateStatePayload | null>(null);
@@ -31,6 +32,7 @@ const UpdateState = () => {
 
     const resetStates = useCallback(() => {
         setSelectedState(null);
+        setRequiredFieldValues({});
         setGetStatesParams(null);
         setUpdateStatePayload(null);
         setApiError(null);
@@ -70,7 +72,7 @@ const UpdateState = () => {
         const data = getGlobalModalState(pluginState).data as CommentAndStateModalData;
         if (data) {
             const {recordType, recordId} = data;
-            const payload: UpdateStatePayload = {recordType, recordId, state: selectedState ?? ''};
+            const payload: UpdateStatePayload = {recordType, recordId, state: selectedState ?? '', ...requiredFieldValues};
             setUpdateStatePayload(payload);
             makeApiRequest(Constants.pluginApiServiceConfigs.updateState.apiServiceName, payload);
         }
@@ -128,19 +130,32 @@ const UpdateState = () => {
         setApiError(null);
         const {data} = getGlobalModalState(pluginState);
 
-        const recordType = (data as CommentAndStateModalData)?.recordType;
+        const {recordType, recordId} = (data as CommentAndStateModalData) ?? {};
 
         if (recordType) {
-            const params: GetStatesParams = {recordType};
+            const params: GetStatesParams = {recordType, recordId};
             setGetStatesParams(params);
             makeApiRequest(Constants.pluginApiServiceConfigs.getStates.apiServiceName, params);
         }
     }, [getStateForGetRecordAPI().isError, getStateForGetRecordAPI().isSuccess]);
 
+    const handleStateChange = (state: string) => {
+        setSelectedState(state);
+        setRequiredFieldValues({});
+    };
+
+    const setRequiredFieldValue = (name: string, value: string) => {
+        setRequiredFieldValues((values) => ({...values, [name]: value}));
+    };
+
     const {isLoading: recordLoading} = getStateForGetRecordAPI();
     const {isLoading: statesLoading, data: stateOptions} = getStateForGetStatesAPI();
     const {isLoading: stateUpdating} = getStateForUpdateStateAPI();
     const showLoader = recordLoading || statesLoading || stateUpdating;
+
+    // Fields which ServiceNow requires for moving the record to the selected state, like the resolution code and notes for resolving an incident
+    const requiredFields = stateOptions?.find((state) => state.value === selectedState)?.required_fields ?? [];
+    const requiredFieldsFilled = requiredFields.every((field) => requiredFieldValues[field.name]?.trim());
     return (
         <Modal
             show={isUpdateStateModalOpen(pluginState)}
@@ -170,15 +185,32 @@ const UpdateState = () => {
                             <Dropdown
                                 placeholder='Select State'
                                 value={selectedState}
-                                onChange={setSelectedState}
+                                onChange={handleStateChange}
                                 options={stateOptions ?? []}
                                 required={true}
                             />
+                            {requiredFields.map((field) => (field.type === 'select' ? (
+                                <Dropdown
+                                    key={field.name}
+                                    placeholder={`Select ${field.label}`}
+                                    value={requiredFieldValues[field.name] ?? null}
+                                    onChange={(value: string) => setRequiredFieldValue(field.name, value)}
+                                    options={field.options ?? []}
+                                    required={true}
+                                />
+                            ) : (
+                                <TextArea
+                                    key={field.name}
+                                    placeholder={field.label}
+                                    value={requiredFieldValues[field.name] ?? ''}
+                                    onChange={(e: React.ChangeEvent<HTMLTextAreaElement>) => setRequiredFieldValue(field.name, e.target.value)}
+                                />
+                            )))}
                         </div>
                         <ModalFooter
                             onConfirm={updateState}
                             confirmBtnText='Update'
-                            confirmDisabled={showLoader || !selectedState}
+                            confirmDisabled={showLoader || !selectedState || !requiredFieldsFilled}
                             onHide={hideModal}
                             cancelDisabled={showLoader}
                         />
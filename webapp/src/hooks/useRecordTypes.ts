@@ -0,0 +1,40 @@
+import {useCallback} from 'react';
+
+import Constants, {RecordType, RecordTypeLabelMap} from 'src/plugin_constants';
+
+import usePluginApi from './usePluginApi';
+
+// The options shown until the record types configured on the server are loaded
+const defaultRecordTypeOptions: Record<RecordTypeFeature, DropdownOptionType[]> = {
+    search: Constants.shareRecordTypeOptions,
+    share: Constants.shareRecordTypeOptions,
+    subscribe: Constants.recordTypeOptions,
+    create: Constants.recordTypeOptions,
+};
+
+// Provides the record types configured on the server, including the tables added by the admin
+function useRecordTypes() {
+    const {getApiState} = usePluginApi();
+    const {data} = getApiState(Constants.pluginApiServiceConfigs.getRecordTypes.apiServiceName);
+    const recordTypes = data as RecordTypeData[] | undefined;
+
+    // Returns the options of the record types for which the given feature is enabled
+    const getRecordTypeOptions = useCallback((feature: RecordTypeFeature): DropdownOptionType[] => {
+        if (!recordTypes) {
+            return defaultRecordTypeOptions[feature];
+        }
+
+        return recordTypes.filter((recordType) => recordType[feature]).map((recordType) => ({
+            label: recordType.display_name,
+            value: recordType.name,
+        }));
+    }, [recordTypes]);
+
+    const getRecordTypeLabel = useCallback((name: string): string => (
+        recordTypes?.find((recordType) => recordType.name === name)?.display_name ?? RecordTypeLabelMap[name as RecordType] ?? name
+    ), [recordTypes]);
+
+    return {getRecordTypeOptions, getRecordTypeLabel};
+}
+
+export default useRecordTypes;
@@ -15,13 +15,16 @@ import AddSubscription from 'src/containers/addOrEditSubscriptions/addSubscripti
 import EditSubscription from 'src/containers/addOrEditSubscriptions/editSubscription';
 import CreateIncident from 'src/containers/createIncident';
 import CreateIncidentPostMenuAction from 'src/containers/createIncident/createIncidentMenu';
+import CreateRecordFromPost from 'src/containers/createRecordFromPost';
+import CreateRecordFromPostMenuAction from 'src/containers/createRecordFromPost/createRecordFromPostMenu';
 import ShareRecords from 'src/containers/shareRecords';
 import UpdateState from 'src/containers/updateState';
+import Reassign from 'src/containers/reassign';
 
 import Constants from 'src/plugin_constants';
 
 import DownloadButton from 'src/components/admin_settings/download_button';
-import {handleConnect, handleDisconnect, handleOpenAddSubscriptionModal, handleOpenEditSubscriptionModal, handleSubscriptionDeleted, handleOpenShareRecordModal, handleOpenCommentModal, handleOpenUpdateStateModal, handleOpenIncidentModal} from 'src/websocket';
+import {handleConnect, handleDisconnect, handleOpenAddSubscriptionModal, handleOpenEditSubscriptionModal, handleSubscriptionDeleted, handleOpenShareRecordModal, handleOpenCommentModal, handleOpenUpdateStateModal, handleOpenIncidentModal, handleOpenReassignModal} from 'src/websocket';
 import Utils from 'src/utils';
 
 import App from './app';
@@ -41,6 +44,8 @@ export default class Plugin {
         registry.registerRootComponent(CreateIncident);
         registry.registerRootComponent(ShareRecords);
         registry.registerRootComponent(UpdateState);
+        registry.registerRootComponent(Reassign);
+        registry.registerRootComponent(CreateRecordFromPost);
         registry.registerRootComponent(App);
         const {id, toggleRHSPlugin} = registry.registerRightHandSidebarComponent(Rhs, Constants.RightSidebarHeader);
         registry.registerChannelHeaderButtonAction(<ServiceNowIcon className='servicenow-icon'/>, () => store.dispatch(toggleRHSPlugin), null, Constants.ChannelHeaderTooltipText);
@@ -52,6 +57,7 @@ export default class Plugin {
         }
 
         registry.registerPostDropdownMenuComponent(CreateIncidentPostMenuAction);
+        registry.registerPostDropdownMenuComponent(CreateRecordFromPostMenuAction);
 
         registry.registerWebSocketEventHandler(`custom_${manifest.id}_connect`, handleConnect(store, id));
         registry.registerWebSocketEventHandler(`custom_${manifest.id}_disconnect`, handleDisconnect(store));
@@ -62,6 +68,7 @@ export default class Plugin {
         registry.registerWebSocketEventHandler(`custom_${manifest.id}_comment_modal`, handleOpenCommentModal(store));
         registry.registerWebSocketEventHandler(`custom_${manifest.id}_update_state`, handleOpenUpdateStateModal(store));
         registry.registerWebSocketEventHandler(`custom_${manifest.id}_create_incident`, handleOpenIncidentModal(store));
+        registry.registerWebSocketEventHandler(`custom_${manifest.id}_reassign_modal`, handleOpenReassignModal(store));
     }
 }
 
@@ -6,7 +6,7 @@ export enum ToggleSwitchLabelPositioning {
     Right = 'right',
 }
 
-export const UPDATE_SET_FILENAME = 'servicenow_for_mattermost_notifications_v2.1.xml';
+export const UPDATE_SET_FILENAME = 'servicenow_for_mattermost_notifications_v2.2.xml';
 export const CONNECT_ACCOUNT_LINK = '/oauth2/connect';
 export const SERVICENOW_ICON_URL = 'servicenow-icon.png';
 
@@ -35,6 +35,7 @@ const DeleteSubscriptionHeading = 'Confirm Subscription Delete';
 const DeleteSubscriptionMsg = 'Are you sure you want to delete the subscription?';
 const RecordSharedMsg = 'Record shared successfully!';
 const StateUpdatedMsg = 'State updated successfully!';
+const RecordReassignedMsg = 'Record reassigned successfully!';
 const CharThresholdToSuggestChannel = 0;
 const RequiredMsg = 'Required';
 const NoSubscriptionPresent = 'No more subscriptions present.';
@@ -43,6 +44,7 @@ const NoCommentsPresent = 'No more comments present.';
 const CommentsNotFound = 'No comments found.';
 const EmptyFieldsInServiceNow = 'N/A';
 const IncidentCreatedMsg = 'Incident created successfully!';
+const RecordCreatedMsg = 'Record created successfully!';
 const ChannelPanelToggleLabel = 'Subscribe to the new incident';
 const MaxShortDescriptionCharactersView = 75;
 const MaxShortDescriptionLimit = 160;
@@ -61,6 +63,12 @@ export enum SubscriptionType {
     BULK = 'object',
 }
 
+export enum DeliveryMode {
+    IMMEDIATE = 'immediate',
+    HOURLY = 'hourly',
+    DAILY = 'daily',
+}
+
 export enum RecordType {
     INCIDENT = 'incident',
     PROBLEM = 'problem',
@@ -93,6 +101,13 @@ export const SubscriptionTypeLabelMap: Record<SubscriptionType, string> = {
     [SubscriptionType.BULK]: 'Bulk subscription',
 };
 
+// Used to get the `DeliveryMode` labels to show in the UI
+export const DeliveryModeLabelMap: Record<DeliveryMode, string> = {
+    [DeliveryMode.IMMEDIATE]: 'Immediate',
+    [DeliveryMode.HOURLY]: 'Hourly digest',
+    [DeliveryMode.DAILY]: 'Daily digest',
+};
+
 // Used to get the `RecordType` labels to show in the UI
 export const RecordTypeLabelMap: Record<RecordType, string> = {
     [RecordType.INCIDENT]: 'Incident',
@@ -265,6 +280,11 @@ const pluginApiServiceConfigs: Record<ApiServiceName, PluginApiService> = {
         method: 'GET',
         apiServiceName: 'getConfig',
     },
+    getRecordTypes: {
+        path: '/record-types',
+        method: 'GET',
+        apiServiceName: 'getRecordTypes',
+    },
     getComments: {
         path: '/comments',
         method: 'GET',
@@ -300,11 +320,36 @@ const pluginApiServiceConfigs: Record<ApiServiceName, PluginApiService> = {
         method: 'POST',
         apiServiceName: 'createIncident',
     },
+    createRecordFromPost: {
+        path: '/create-from-post',
+        method: 'POST',
+        apiServiceName: 'createRecordFromPost',
+    },
     getConnectedUser: {
         path: '/connected',
         method: 'GET',
         apiServiceName: 'getConnectedUser',
     },
+    searchAssignmentGroups: {
+        path: '/incident/assignment-groups',
+        method: 'GET',
+        apiServiceName: 'searchAssignmentGroups',
+    },
+    searchConfigurationItems: {
+        path: '/incident/configuration-items',
+        method: 'GET',
+        apiServiceName: 'searchConfigurationItems',
+    },
+    getIncidentFieldChoices: {
+        path: '/incident/choices',
+        method: 'GET',
+        apiServiceName: 'getIncidentFieldChoices',
+    },
+    updateAssignment: {
+        path: '/assignment',
+        method: 'PATCH',
+        apiServiceName: 'updateAssignment',
+    },
 };
 
 export const PanelDefaultHeights = {
@@ -355,6 +400,7 @@ export default {
     DeleteSubscriptionMsg,
     RecordSharedMsg,
     StateUpdatedMsg,
+    RecordReassignedMsg,
     CharThresholdToSuggestChannel,
     RequiredMsg,
     recordTypeOptions,
@@ -368,6 +414,7 @@ export default {
     SubscriptionFilterCreatedByOptions,
     EmptyFieldsInServiceNow,
     IncidentCreatedMsg,
+    RecordCreatedMsg,
     ChannelPanelToggleLabel,
     MaxShortDescriptionCharactersView,
     MaxShortDescriptionLimit,
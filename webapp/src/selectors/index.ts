@@ -10,4 +10,8 @@ export const isCommentModalOpen = (state: PluginState): boolean => state.globalM
 
 export const isUpdateStateModalOpen = (state: PluginState): boolean => state.globalModalReducer.modalId === 'updateState';
 
+export const isReassignModalOpen = (state: PluginState): boolean => state.globalModalReducer.modalId === 'reassign';
+
 export const isCreateIncidentModalOpen = (state: PluginState): boolean => state.globalModalReducer.modalId === 'createIncident';
+
+export const isCreateRecordFromPostModalOpen = (state: PluginState): boolean => state.globalModalReducer.modalId === 'createRecordFromPost';
@@ -45,11 +45,11 @@ const pluginApi = createApi({
             }),
         }),
         [Constants.pluginApiServiceConfigs.searchRecords.apiServiceName]: builder.query<Suggestion[], SearchRecordsParams>({
-            query: ({recordType, search, perPage}) => ({
+            query: ({recordType, search, perPage, active, assignedToMe, state}) => ({
                 headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
                 url: `${Constants.pluginApiServiceConfigs.searchRecords.path}/${recordType}`,
                 method: Constants.pluginApiServiceConfigs.searchRecords.method,
-                params: {search, perPage: perPage || 10},
+                params: {search, perPage: perPage || 10, active, assigned_to_me: assignedToMe, state},
             }),
         }),
         [Constants.pluginApiServiceConfigs.getRecord.apiServiceName]: builder.query<RecordData, GetRecordParams>({
@@ -97,7 +97,14 @@ const pluginApi = createApi({
                 method: Constants.pluginApiServiceConfigs.getConfig.method,
             }),
         }),
-        [Constants.pluginApiServiceConfigs.getComments.apiServiceName]: builder.query<string, CommentsPayload>({
+        [Constants.pluginApiServiceConfigs.getRecordTypes.apiServiceName]: builder.query<RecordTypeData[], void>({
+            query: () => ({
+                headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
+                url: Constants.pluginApiServiceConfigs.getRecordTypes.path,
+                method: Constants.pluginApiServiceConfigs.getRecordTypes.method,
+            }),
+        }),
+        [Constants.pluginApiServiceConfigs.getComments.apiServiceName]: builder.query<CommentsData, CommentsPayload>({
             query: ({record_type, record_id}) => ({
                 headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
                 url: `${Constants.pluginApiServiceConfigs.getComments.path}/${record_type}/${record_id}`,
@@ -125,6 +132,7 @@ const pluginApi = createApi({
                 headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
                 url: `${Constants.pluginApiServiceConfigs.getStates.path}/${params.recordType}`,
                 method: Constants.pluginApiServiceConfigs.getStates.method,
+                params: params.recordId ? {record_id: params.recordId} : undefined,
             }),
         }),
         [Constants.pluginApiServiceConfigs.updateState.apiServiceName]: builder.query<void, UpdateStatePayload>({
@@ -135,6 +143,38 @@ const pluginApi = createApi({
                 body,
             }),
         }),
+        [Constants.pluginApiServiceConfigs.searchAssignmentGroups.apiServiceName]: builder.query<ServiceNowReference[], SearchReferenceParams>({
+            query: ({search, perPage}) => ({
+                headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
+                url: Constants.pluginApiServiceConfigs.searchAssignmentGroups.path,
+                method: Constants.pluginApiServiceConfigs.searchAssignmentGroups.method,
+                params: {search, perPage: perPage || 10},
+            }),
+        }),
+        [Constants.pluginApiServiceConfigs.searchConfigurationItems.apiServiceName]: builder.query<ServiceNowReference[], SearchReferenceParams>({
+            query: ({search, perPage}) => ({
+                headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
+                url: Constants.pluginApiServiceConfigs.searchConfigurationItems.path,
+                method: Constants.pluginApiServiceConfigs.searchConfigurationItems.method,
+                params: {search, perPage: perPage || 10},
+            }),
+        }),
+        [Constants.pluginApiServiceConfigs.getIncidentFieldChoices.apiServiceName]: builder.query<ServiceNowChoice[], GetIncidentFieldChoicesParams>({
+            query: ({field, dependentValue}) => ({
+                headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
+                url: `${Constants.pluginApiServiceConfigs.getIncidentFieldChoices.path}/${field}`,
+                method: Constants.pluginApiServiceConfigs.getIncidentFieldChoices.method,
+                params: dependentValue ? {dependent_value: dependentValue} : undefined,
+            }),
+        }),
+        [Constants.pluginApiServiceConfigs.updateAssignment.apiServiceName]: builder.query<void, ReassignPayload>({
+            query: ({recordType, recordId, ...body}) => ({
+                headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
+                url: `${Constants.pluginApiServiceConfigs.updateAssignment.path}/${recordType}/${recordId}`,
+                method: Constants.pluginApiServiceConfigs.updateAssignment.method,
+                body,
+            }),
+        }),
         [Constants.pluginApiServiceConfigs.getUsers.apiServiceName]: builder.query<CallerData[], void>({
             query: () => ({
                 headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
@@ -150,6 +190,14 @@ const pluginApi = createApi({
                 body,
             }),
         }),
+        [Constants.pluginApiServiceConfigs.createRecordFromPost.apiServiceName]: builder.query<RecordData, CreateRecordFromPostPayload>({
+            query: (body) => ({
+                headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
+                url: Constants.pluginApiServiceConfigs.createRecordFromPost.path,
+                method: Constants.pluginApiServiceConfigs.createRecordFromPost.method,
+                body,
+            }),
+        }),
         [Constants.pluginApiServiceConfigs.getConnectedUser.apiServiceName]: builder.query<ConnectedState, void>({
             query: () => ({
                 headers: {[Constants.HeaderCSRFToken]: Cookies.get(Constants.MMCSRF)},
@@ -3,9 +3,10 @@
 */
 
 // TODO: Create an enum for the below modal Ids
-type ModalId = 'addSubscription' | 'editSubscription' | 'shareRecord' | 'addOrViewComments' | 'updateState' | 'createIncident' | null
+type ModalId = 'addSubscription' | 'editSubscription' | 'shareRecord' | 'addOrViewComments' | 'updateState' | 'createIncident' | 'reassign' | 'createRecordFromPost' | null
 type SubscriptionType = import('../../plugin_constants').SubscriptionType;
 type RecordType = import('../../plugin_constants').RecordType;
+type DeliveryMode = import('../../plugin_constants').DeliveryMode;
 
 type ChannelData = {
     create_at: number;
@@ -66,6 +67,7 @@ type SubscriptionData = {
     sys_id: string;
     number: string;
     short_description: string;
+    delivery_mode?: DeliveryMode;
 }
 
 type ConfigData = {
@@ -82,11 +84,46 @@ type LinkData = {
     link: string;
 }
 
+type CommentEntry = {
+    author: string;
+    created_on: string;
+    type: 'comment' | 'work_note';
+    value: string;
+}
+
+type CommentsData = {
+    entries: CommentEntry[];
+    comments_and_work_notes?: string;
+}
+
 type StateData = {
     label: string;
     value: string;
+    required_fields?: StateField[];
+}
+
+type StateField = {
+    name: string;
+    label: string;
+    type: 'select' | 'textarea';
+    options?: StateData[];
 }
 
+type RecordTypeData = {
+    name: string;
+    display_name: string;
+    search: boolean;
+    share: boolean;
+    subscribe: boolean;
+    comment: boolean;
+    work_notes: boolean;
+    update_state: boolean;
+    assign: boolean;
+    create: boolean;
+}
+
+type RecordTypeFeature = 'search' | 'share' | 'subscribe' | 'create';
+
 type DropdownOptionType = {
     label?: string | JSX.Element;
     value: string;
@@ -106,6 +143,13 @@ type EditSubscriptionData = {
     subscriptionEvents: import('../../plugin_constants').SubscriptionEvents[],
     id: string;
     userId: string;
+    deliveryMode?: DeliveryMode;
+}
+
+type AddSubscriptionModalData = {
+    channel?: string,
+    recordType: RecordType,
+    recordId: string,
 }
 
 type RecordDataKeys = 'short_description' | 'state' | 'priority' | 'assigned_to' | 'assignment_group' | 'workflow_state' | 'author' | 'kb_category' | 'kb_knowledge_base';
@@ -125,6 +169,19 @@ type SubscriptionCardBody = {
     labelValuePairs?: Array<{ label: string, value: string }>,
 }
 
+type IncidentFieldWithChoices = 'urgency' | 'impact' | 'category' | 'subcategory';
+
+type ServiceNowChoice = {
+    label: string;
+    value: string;
+    dependent_value?: string;
+}
+
+type ServiceNowReference = {
+    sys_id: string;
+    name: string;
+}
+
 type CallerData = {
     mattermostUserID: string;
     username: string;
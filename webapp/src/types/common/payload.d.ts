@@ -7,9 +7,12 @@ type FetchChannelsParams = {
 }
 
 type SearchRecordsParams = {
-    recordType: RecordType | ShareRecordType;
+    recordType: RecordType | ShareRecordType | 'all';
     search: string;
     perPage?: number;
+    active?: boolean;
+    assignedToMe?: boolean;
+    state?: string;
 }
 
 type GetRecordParams = {
@@ -19,12 +22,17 @@ type GetRecordParams = {
 
 type GetStatesParams = {
     recordType: RecordType;
+    recordId?: string;
 }
 
 type UpdateStatePayload = {
     recordType: RecordType;
     recordId: string;
     state: string;
+    close_code?: string;
+    close_notes?: string;
+    hold_reason?: string;
+    resolution_code?: string;
 }
 
 type CreateSubscriptionPayload = {
@@ -37,6 +45,7 @@ type CreateSubscriptionPayload = {
     subscription_events: string;
     channel_id: string;
     record_number: string;
+    delivery_mode?: DeliveryMode;
 }
 
 type FetchSubscriptionsParams = {
@@ -57,12 +66,36 @@ type EditSubscriptionPayload = {
     channel_id: string;
     sys_id: string;
     record_number: string;
+    delivery_mode?: DeliveryMode;
+}
+
+type SearchReferenceParams = {
+    search: string;
+    perPage?: number;
+}
+
+type CreateRecordFromPostPayload = {
+    post_id: string;
+    record_type: RecordType;
+}
+
+type GetIncidentFieldChoicesParams = {
+    field: IncidentFieldWithChoices;
+    dependentValue?: string;
+}
+
+type ReassignPayload = {
+    recordType: RecordType;
+    recordId: string;
+    assigned_to?: string;
+    assignment_group?: string;
 }
 
 type CommentsPayload = {
     record_type: string;
     record_id: string;
     comments?: string;
+    work_notes?: string;
 }
 
 type ShareRecordPayload = {
@@ -83,8 +116,12 @@ type SubscriptionFilters = {
 type IncidentPayload = {
     short_description: string;
     description: string;
-    urgency?: number;
-    impact?: number;
+    urgency?: string;
+    impact?: string;
+    category?: string;
+    subcategory?: string;
+    assignment_group?: string;
+    cmdb_ci?: string;
     caller_id: string;
     channel_id: string;
 }
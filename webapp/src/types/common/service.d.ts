@@ -9,6 +9,7 @@ type ApiServiceName =
     'editSubscription' |
     'deleteSubscription' |
     'getConfig' |
+    'getRecordTypes' |
     'shareRecord' |
     'getComments' |
     'addComments' |
@@ -16,7 +17,12 @@ type ApiServiceName =
     'updateState' |
     'getUsers' |
     'createIncident' |
-    'getConnectedUser';
+    'createRecordFromPost' |
+    'getConnectedUser' |
+    'searchAssignmentGroups' |
+    'searchConfigurationItems' |
+    'getIncidentFieldChoices' |
+    'updateAssignment';
 
 type PluginApiService = {
     path: string,
@@ -40,4 +46,8 @@ type APIPayloadType =
     CommentsPayload |
     GetStatesParams |
     UpdateStateParams |
+    SearchReferenceParams |
+    GetIncidentFieldChoicesParams |
+    ReassignPayload |
+    CreateRecordFromPostPayload |
     string;
@@ -6,7 +6,7 @@ type ReduxState = {
 
 type GlobalModalState = {
     modalId: ModalId;
-    data?: EditSubscriptionData | CommentAndStateModalData | IncidentModalData | null;
+    data?: EditSubscriptionData | AddSubscriptionModalData | CommentAndStateModalData | IncidentModalData | CreateRecordFromPostModalData | null;
 }
 
 type CommentModalState = {
@@ -39,9 +39,14 @@ type UpdateStateModalReduxState = {
 type CommentAndStateModalData = {
     recordType: RecordType;
     recordId: string;
+    isWorkNote?: boolean;
 }
 
 type IncidentModalData = {
     description: string;
     senderId: string;
 }
+
+type CreateRecordFromPostModalData = {
+    postId: string;
+}
@@ -108,6 +108,22 @@ const getResultPanelHeader = (error: APIError | null, onClick: () => void, mmSit
 
 const getSiteUrl = (state: GlobalState) => state.entities.general.config.SiteURL;
 
+// Formats the comment entries in the same way as ServiceNow formats the "comments_and_work_notes" field,
+// which is returned when the comment entries of the record can't be fetched
+const getFormattedComments = (data?: CommentsData): string => {
+    if (!data) {
+        return '';
+    }
+
+    if (!data.entries?.length) {
+        return data.comments_and_work_notes || '';
+    }
+
+    return data.entries.map((entry) => (
+        `${entry.created_on} - ${entry.author} (${entry.type === 'work_note' ? 'Work notes' : 'Additional comments'})\n${entry.value}\n`
+    )).join('\n');
+};
+
 export default {
     getBaseUrls,
     debounce,
@@ -117,4 +133,5 @@ export default {
     validateKeysContainingLink,
     getResultPanelHeader,
     getSiteUrl,
+    getFormattedComments,
 };
@@ -0,0 +1,38 @@
+import {Store, Action} from 'redux';
+
+import {GlobalState} from 'mattermost-webapp/types/store';
+
+import {setGlobalModalState} from 'src/reducers/globalModal';
+
+import {handleOpenAddSubscriptionModal} from 'src/websocket';
+
+const getStore = () => ({dispatch: jest.fn()} as unknown as Store<GlobalState, Action<Record<string, unknown>>>);
+
+test('should open the add subscription modal without any data when no record is sent with the event', () => {
+    const store = getStore();
+    handleOpenAddSubscriptionModal(store)({event: 'custom_mattermost-plugin-servicenow_add_subscription', data: {}});
+
+    expect(store.dispatch).toHaveBeenCalledWith(setGlobalModalState({modalId: 'addSubscription'}));
+});
+
+test('should open the add subscription modal prefilled with the record sent with the event', () => {
+    const store = getStore();
+    handleOpenAddSubscriptionModal(store)({
+        event: 'custom_mattermost-plugin-servicenow_add_subscription',
+        data: {
+            record_type: 'sc_req_item',
+            record_id: 'mockRecordID',
+            record_number: 'RITM0010001',
+            channel_id: 'mockChannelID',
+        },
+    });
+
+    expect(store.dispatch).toHaveBeenCalledWith(setGlobalModalState({
+        modalId: 'addSubscription',
+        data: {
+            channel: 'mockChannelID',
+            recordType: 'sc_req_item' as RecordType,
+            recordId: 'mockRecordID',
+        },
+    }));
+});
@@ -26,7 +26,20 @@ export function handleDisconnect(store: Store<GlobalState, Action<Record<string,
 }
 
 export function handleOpenAddSubscriptionModal(store: Store<GlobalState, Action<Record<string, unknown>>>) {
-    return (_: WebsocketEventParams) => {
+    return (msg: WebsocketEventParams) => {
+        const {data} = msg;
+
+        // The modal is opened for a record, like a requested item of an order, when the record is sent with the event
+        if (data?.record_type && data?.record_id) {
+            const addSubscriptionModalData: AddSubscriptionModalData = {
+                channel: data.channel_id,
+                recordType: data.record_type as RecordType,
+                recordId: data.record_id,
+            };
+            store.dispatch(setGlobalModalState({modalId: 'addSubscription', data: addSubscriptionModalData}) as Action);
+            return;
+        }
+
         store.dispatch(setGlobalModalState({modalId: 'addSubscription'}) as Action);
     };
 }
@@ -70,6 +83,7 @@ export function handleOpenCommentModal(store: Store<GlobalState, Action<Record<s
         const commentModalData: CommentAndStateModalData = {
             recordType: data.record_type as RecordType,
             recordId: data.record_id,
+            isWorkNote: data.comment_type === 'work_note',
         };
         store.dispatch(setGlobalModalState({modalId: 'addOrViewComments', data: commentModalData}) as Action);
     };
@@ -86,6 +100,17 @@ export function handleOpenUpdateStateModal(store: Store<GlobalState, Action<Reco
     };
 }
 
+export function handleOpenReassignModal(store: Store<GlobalState, Action<Record<string, unknown>>>) {
+    return (msg: WebsocketEventParams) => {
+        const {data} = msg;
+        const reassignModalData: CommentAndStateModalData = {
+            recordType: data.record_type as RecordType,
+            recordId: data.record_id,
+        };
+        store.dispatch(setGlobalModalState({modalId: 'reassign', data: reassignModalData}) as Action);
+    };
+}
+
 export function handleOpenIncidentModal(store: Store<GlobalState, Action<Record<string, unknown>>>) {
     return (_: WebsocketEventParams) => {
         store.dispatch(setGlobalModalState({modalId: 'createIncident'}) as Action);